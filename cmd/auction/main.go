@@ -2,26 +2,49 @@ package main
 
 import (
 	"context"
-	"fullcycle-auction_go/configuration/database/mongodb"
+	"errors"
+	"expvar"
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/clock"
+	"fullcycle-auction_go/internal/infra/api/graphql/graphql_server"
+	"fullcycle-auction_go/internal/infra/api/grpc/grpc_server"
+	"fullcycle-auction_go/internal/infra/api/web/controller/api_key_controller"
 	"fullcycle-auction_go/internal/infra/api/web/controller/auction_controller"
 	"fullcycle-auction_go/internal/infra/api/web/controller/bid_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/category_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/live_controller"
 	"fullcycle-auction_go/internal/infra/api/web/controller/user_controller"
-	"fullcycle-auction_go/internal/infra/database/auction"
-	"fullcycle-auction_go/internal/infra/database/bid"
-	"fullcycle-auction_go/internal/infra/database/user"
+	"fullcycle-auction_go/internal/infra/api/web/controller/webhook_controller"
+	"fullcycle-auction_go/internal/infra/api/web/routes"
+	"fullcycle-auction_go/internal/infra/event"
+	"fullcycle-auction_go/internal/infra/idempotency"
+	"fullcycle-auction_go/internal/infra/rate_limiter"
+	"fullcycle-auction_go/internal/infra/token"
+	webhook_notifier "fullcycle-auction_go/internal/infra/webhook"
+	"fullcycle-auction_go/internal/usecase/api_key_usecase"
 	"fullcycle-auction_go/internal/usecase/auction_usecase"
 	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"fullcycle-auction_go/internal/usecase/category_usecase"
 	"fullcycle-auction_go/internal/usecase/user_usecase"
+	"fullcycle-auction_go/internal/usecase/webhook_usecase"
 	"log"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
-	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc"
 )
 
 func main() {
-	ctx := context.Background()
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
 	log.Println("Starting application...")
 	log.Println("MONGODB_URL:", os.Getenv("MONGODB_URL"))
@@ -33,57 +56,234 @@ func main() {
 		}
 	}
 
-	databaseConnection, err := mongodb.NewMongoDBConnection(ctx)
+	databaseDriver, err := getDatabaseDriver()
 	if err != nil {
-		log.Fatal("Failed to connect to MongoDB: ", err.Error())
+		log.Fatal("Invalid database configuration: ", err)
+	}
+
+	// "auction migrate" applies the pending migrations and exits; the
+	// server applies them on startup too.
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrations(ctx, databaseDriver); err != nil {
+			log.Fatal("Failed to apply migrations: ", err)
+		}
+		log.Println("Migrations applied")
 		return
 	}
 
-	log.Println("Successfully connected to MongoDB")
+	databaseConnection, err := connectDatabase(ctx, databaseDriver)
+	if err != nil {
+		log.Fatal("Failed to connect to the database: ", err.Error())
+		return
+	}
+
+	tokenVerifier, err := token.NewJWTVerifierFromEnv()
+	if err != nil {
+		log.Fatal("Failed to configure JWT verification: ", err)
+	}
 
 	router := gin.Default()
 
-	userController, bidController, auctionsController := initDependencies(databaseConnection)
-
-	router.GET("/auction", auctionsController.FindAuctions)
-	router.GET("/auction/:auctionId", auctionsController.FindAuctionById)
-	router.POST("/auction", auctionsController.CreateAuction)
-	router.GET("/auction/winner/:auctionId", auctionsController.FindWinningBidByAuctionId)
-	router.POST("/bid", bidController.CreateBid)
-	router.GET("/bid/:auctionId", bidController.FindBidByAuctionId)
-	router.GET("/user/:userId", userController.FindUserById)
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{"status": "ok"})
+	// Client IPs feed the rate limiter, so X-Forwarded-For is only trusted
+	// from the proxies listed in TRUSTED_PROXIES.
+	var trustedProxies []string
+	if value := os.Getenv("TRUSTED_PROXIES"); value != "" {
+		trustedProxies = strings.Split(value, ",")
+	}
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		log.Fatal("Invalid TRUSTED_PROXIES: ", err)
+	}
+
+	// Background workers run until the shutdown sequence cancels them, after
+	// the servers stopped and the pending bids were written.
+	workersCtx, cancelWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+
+	rateLimiter := rate_limiter.NewRateLimiter(databaseConnection.Mongo)
+	controllers, grpcServer, authenticator, bidUseCase := initDependencies(
+		workersCtx, &workers, databaseDriver, databaseConnection, tokenVerifier, rateLimiter)
+
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "50051"
+	}
+
+	grpcListener, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		log.Fatal("Failed to listen for gRPC: ", err)
+	}
+
+	go func() {
+		log.Println("gRPC server starting on :" + grpcPort)
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			log.Fatal("Failed to start gRPC server: ", err)
+		}
+	}()
+
+	routes.Register(router, controllers, routes.Middlewares{
+		Authenticator:    authenticator,
+		RateLimiter:      rateLimiter,
+		IdempotencyStore: idempotency.NewIdempotencyStore(databaseConnection.Mongo),
+		Shutdown:         ctx,
 	})
 
-	log.Println("Server starting on :8080")
-	if err := router.Run(":8080"); err != nil {
-		log.Fatal("Failed to start server: ", err)
+	httpServer := &http.Server{
+		Addr:    ":8080",
+		Handler: router,
+	}
+
+	go func() {
+		log.Println("Server starting on :8080")
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal("Failed to start server: ", err)
+		}
+	}()
+
+	<-ctx.Done()
+	stop()
+
+	shutdownTimeout := getShutdownTimeout()
+	log.Println("Shutting down, timeout:", shutdownTimeout)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	// Stop accepting requests first so no bid arrives after the batch is
+	// flushed, then stop the workers that still write to the database.
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		log.Println("Error shutting down HTTP server: ", err)
 	}
+
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+	select {
+	case <-grpcStopped:
+	case <-shutdownCtx.Done():
+		grpcServer.Stop()
+	}
+
+	if err := bidUseCase.Shutdown(shutdownCtx); err != nil {
+		log.Println("Error flushing pending bids: ", err)
+	}
+
+	cancelWorkers()
+	workersStopped := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(workersStopped)
+	}()
+	select {
+	case <-workersStopped:
+	case <-shutdownCtx.Done():
+		log.Println("Timed out waiting for background workers")
+	}
+
+	if err := databaseConnection.Close(shutdownCtx); err != nil {
+		log.Println("Error disconnecting from the database: ", err)
+	}
+
+	log.Println("Server stopped")
 }
 
-func initDependencies(database *mongo.Database) (
-	userController *user_controller.UserController,
-	bidController *bid_controller.BidController,
-	auctionController *auction_controller.AuctionController) {
+func getShutdownTimeout() time.Duration {
+	shutdownTimeout := os.Getenv("SHUTDOWN_TIMEOUT")
+	duration, err := time.ParseDuration(shutdownTimeout)
+	if err != nil || duration <= 0 {
+		return 30 * time.Second
+	}
+
+	return duration
+}
+
+func initDependencies(
+	ctx context.Context,
+	workers *sync.WaitGroup,
+	databaseDriver string,
+	database connection,
+	tokenVerifier auth.TokenVerifierInterface,
+	rateLimiter rate_limiter.RateLimiterInterface) (
+	controllers routes.Controllers,
+	grpcServer *grpc.Server,
+	authenticator *auth.Authenticator,
+	bidUseCase *bid_usecase.BidUseCase) {
+
+	eventDispatcher := event.NewEventDispatcher()
+
+	repositories := newRepositories(databaseDriver, database, eventDispatcher, clock.System)
+	auctionRepository := repositories.Auction
+	bidRepository := repositories.Bid
+	userRepository := repositories.User
+	webhookRepository := repositories.Webhook
+	apiKeyRepository := repositories.ApiKey
+	categoryRepository := repositories.Category
 
-	auctionRepository := auction.NewAuctionRepository(database)
-	bidRepository := bid.NewBidRepository(database, auctionRepository)
-	userRepository := user.NewUserRepository(database)
+	apiKeyUseCase := api_key_usecase.NewApiKeyUseCase(apiKeyRepository)
+	authenticator = auth.NewAuthenticator(tokenVerifier, apiKeyUseCase)
 
-	auctionCreateUseCase := auction_usecase.NewAuctionUseCase(auctionRepository, bidRepository)
-	auctionFindUseCase := auction_usecase.NewAuctionFindUseCase(auctionRepository, bidRepository)
+	webhookNotifier := webhook_notifier.NewWebhookNotifier(webhookRepository)
+	eventDispatcher.Listen(webhookNotifier.Enqueue)
+	workers.Add(2)
+	go func() {
+		defer workers.Done()
+		webhookNotifier.Start(ctx)
+	}()
+	go func() {
+		defer workers.Done()
+		auctionRepository.StartAuctionCloser(ctx)
+	}()
 
-	userController = user_controller.NewUserController(
-		user_usecase.NewUserUseCase(userRepository))
+	auctionCreateUseCase := auction_usecase.NewAuctionUseCase(
+		auctionRepository, bidRepository, categoryRepository, clock.System)
+	auctionFindUseCase := auction_usecase.NewAuctionFindUseCase(
+		auctionRepository, bidRepository, categoryRepository)
 
-	auctionController = auction_controller.NewAuctionController(
+	userUseCase := user_usecase.NewUserUseCase(userRepository)
+
+	controllers.UserController = user_controller.NewUserController(userUseCase)
+
+	controllers.AuctionController = auction_controller.NewAuctionController(
+		auctionCreateUseCase,
+		auctionFindUseCase,
+		eventDispatcher,
+	)
+
+	bidUseCase = bid_usecase.NewBidUseCase(ctx, bidRepository, clock.System)
+	expvar.Publish("bid_batcher", expvar.Func(func() interface{} {
+		return bidUseCase.Batcher.Stats()
+	}))
+
+	controllers.BidController = bid_controller.NewBidController(bidUseCase)
+
+	bidRateLimit := rate_limiter.NewBidRouteLimit(rateLimiter)
+	controllers.LiveController = live_controller.NewLiveController(bidUseCase, eventDispatcher, bidRateLimit)
+
+	grpcServer = grpc_server.NewGRPCServer(
 		auctionCreateUseCase,
 		auctionFindUseCase,
+		bidUseCase,
+		userUseCase,
+		eventDispatcher,
+		authenticator,
+		bidRateLimit,
 	)
 
-	bidController = bid_controller.NewBidController(
-		bid_usecase.NewBidUseCase(bidRepository))
+	controllers.GraphQLServer = graphql_server.NewGraphQLServer(
+		auctionFindUseCase,
+		bidUseCase,
+		userUseCase,
+		eventDispatcher,
+	)
+
+	controllers.WebhookController = webhook_controller.NewWebhookController(
+		webhook_usecase.NewWebhookUseCase(webhookRepository))
+
+	controllers.ApiKeyController = api_key_controller.NewApiKeyController(apiKeyUseCase)
+
+	controllers.CategoryController = category_controller.NewCategoryController(
+		category_usecase.NewCategoryUseCase(categoryRepository, auctionRepository, clock.System))
 
 	return
 }
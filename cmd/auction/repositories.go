@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"expvar"
+	"fmt"
+	"fullcycle-auction_go/configuration/database/mongodb"
+	"fullcycle-auction_go/configuration/database/sqldb"
+	"fullcycle-auction_go/internal/clock"
+	"fullcycle-auction_go/internal/entity/api_key_entity"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/entity/webhook_entity"
+	"fullcycle-auction_go/internal/infra/database/api_key"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"fullcycle-auction_go/internal/infra/database/bid"
+	"fullcycle-auction_go/internal/infra/database/category"
+	"fullcycle-auction_go/internal/infra/database/user"
+	"fullcycle-auction_go/internal/infra/database/webhook"
+	"log"
+	"os"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	driverMongo    = "mongo"
+	driverPostgres = "postgres"
+	driverSQLite   = "sqlite"
+	driverMemory   = "memory"
+)
+
+// auctionRepository is what the application needs from an auction backend
+// besides the entity interface: closing expired auctions in the background.
+type auctionRepository interface {
+	auction_entity.AuctionRepositoryInterface
+	StartAuctionCloser(ctx context.Context)
+}
+
+type repositories struct {
+	Auction  auctionRepository
+	Bid      bid_entity.BidEntityRepository
+	User     user_entity.UserRepositoryInterface
+	Webhook  webhook_entity.WebhookRepositoryInterface
+	ApiKey   api_key_entity.ApiKeyRepositoryInterface
+	Category category_entity.CategoryRepositoryInterface
+}
+
+// connection holds the database of the configured driver; the others are
+// nil.
+type connection struct {
+	Mongo *mongo.Database
+	SQL   *sql.DB
+}
+
+// getDatabaseDriver reads DB_DRIVER: "mongo" (default), "postgres",
+// "sqlite", or "memory", which keeps everything in process memory and needs
+// no database.
+func getDatabaseDriver() (string, error) {
+	driver := os.Getenv("DB_DRIVER")
+	switch driver {
+	case "":
+		return driverMongo, nil
+	case driverMongo:
+		return driver, nil
+	case driverPostgres, driverSQLite, driverMemory:
+		if os.Getenv("RATE_LIMIT_STORE") == driverMongo || os.Getenv("IDEMPOTENCY_STORE") == driverMongo {
+			return "", fmt.Errorf("RATE_LIMIT_STORE and IDEMPOTENCY_STORE cannot be mongo with DB_DRIVER=%s", driver)
+		}
+		return driver, nil
+	default:
+		return "", fmt.Errorf("unknown DB_DRIVER %q", driver)
+	}
+}
+
+func connectDatabase(ctx context.Context, driver string) (connection, error) {
+	switch driver {
+	case driverMongo:
+		database, err := mongodb.NewMongoDBConnection(ctx)
+		if err != nil {
+			return connection{}, err
+		}
+		log.Println("Successfully connected to MongoDB")
+
+		if err := mongodb.Migrate(ctx, database); err != nil {
+			database.Client().Disconnect(ctx)
+			return connection{}, err
+		}
+		return connection{Mongo: database}, nil
+	case driverPostgres:
+		db, err := sqldb.NewPostgresConnection(ctx)
+		if err != nil {
+			return connection{}, err
+		}
+		log.Println("Successfully connected to Postgres")
+		return connection{SQL: db}, nil
+	case driverSQLite:
+		db, err := sqldb.NewSQLiteConnection(ctx)
+		if err != nil {
+			return connection{}, err
+		}
+		log.Println("Successfully opened SQLite database")
+		return connection{SQL: db}, nil
+	default:
+		log.Println("Using in-memory repositories, data is lost on restart")
+		return connection{}, nil
+	}
+}
+
+// runMigrations brings the schema of driver up to date and disconnects:
+// connecting applies the pending migrations of every database driver.
+func runMigrations(ctx context.Context, driver string) error {
+	database, err := connectDatabase(ctx, driver)
+	if err != nil {
+		return err
+	}
+
+	return database.Close(ctx)
+}
+
+func (c connection) Close(ctx context.Context) error {
+	if c.Mongo != nil {
+		return c.Mongo.Client().Disconnect(ctx)
+	}
+	if c.SQL != nil {
+		return c.SQL.Close()
+	}
+
+	return nil
+}
+
+// newRepositories builds the repositories of driver on database.
+func newRepositories(
+	driver string,
+	database connection,
+	eventDispatcher event_entity.EventDispatcherInterface,
+	clock clock.Clock) repositories {
+
+	switch driver {
+	case driverMemory:
+		auctionRepository := auction.NewMemoryAuctionRepository(eventDispatcher, clock)
+		return repositories{
+			Auction:  auctionRepository,
+			Bid:      bid.NewMemoryBidRepository(auctionRepository, eventDispatcher, clock),
+			User:     user.NewMemoryUserRepositoryFromEnv(),
+			Webhook:  webhook.NewMemoryWebhookRepository(),
+			ApiKey:   api_key.NewMemoryApiKeyRepository(),
+			Category: category.NewMemoryCategoryRepository(),
+		}
+	case driverPostgres, driverSQLite:
+		dialect := sqldb.Postgres
+		if driver == driverSQLite {
+			dialect = sqldb.SQLite
+		}
+
+		return repositories{
+			Auction:  auction.NewSQLAuctionRepository(database.SQL, dialect, eventDispatcher, clock),
+			Bid:      bid.NewSQLBidRepository(database.SQL, dialect, eventDispatcher, clock),
+			User:     user.NewSQLUserRepository(database.SQL),
+			Webhook:  webhook.NewSQLWebhookRepository(database.SQL),
+			ApiKey:   api_key.NewSQLApiKeyRepository(database.SQL),
+			Category: category.NewSQLCategoryRepository(database.SQL),
+		}
+	}
+
+	auctionRepository := auction.NewAuctionRepository(database.Mongo, eventDispatcher, clock)
+	expvar.Publish("auction_state_cache", expvar.Func(func() interface{} {
+		return auctionRepository.StateCache.Stats()
+	}))
+
+	return repositories{
+		Auction:  auctionRepository,
+		Bid:      bid.NewBidRepository(database.Mongo, auctionRepository, eventDispatcher, clock),
+		User:     user.NewUserRepository(database.Mongo),
+		Webhook:  webhook.NewWebhookRepository(database.Mongo),
+		ApiKey:   api_key.NewApiKeyRepository(database.Mongo),
+		Category: category.NewCategoryRepository(database.Mongo),
+	}
+}
@@ -0,0 +1,97 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+const migrationsCollection = "schema_migrations"
+
+type migration struct {
+	version int64
+	name    string
+	// up must be safe to run again: instances starting together may both
+	// apply a migration before either records it.
+	up func(ctx context.Context, database *mongo.Database) error
+}
+
+type migrationRecord struct {
+	Version   int64  `bson:"_id"`
+	Name      string `bson:"name"`
+	AppliedAt int64  `bson:"applied_at"`
+}
+
+// Migrate applies the migrations that are not recorded in schema_migrations
+// yet, in version order.
+func Migrate(ctx context.Context, database *mongo.Database) error {
+	collection := database.Collection(migrationsCollection)
+
+	cursor, err := collection.Find(ctx, bson.M{})
+	if err != nil {
+		logger.Error("Error trying to find applied migrations", err)
+		return err
+	}
+
+	var records []migrationRecord
+	if err := cursor.All(ctx, &records); err != nil {
+		logger.Error("Error trying to decode applied migrations", err)
+		return err
+	}
+
+	applied := make(map[int64]bool, len(records))
+	for _, record := range records {
+		applied[record.Version] = true
+	}
+
+	for _, m := range migrations {
+		if applied[m.version] {
+			continue
+		}
+
+		if err := m.up(ctx, database); err != nil {
+			logger.Error("Error trying to apply migration", err,
+				zap.Int64("version", m.version), zap.String("name", m.name))
+			return fmt.Errorf("migration %d_%s: %w", m.version, m.name, err)
+		}
+
+		_, err := collection.InsertOne(ctx, migrationRecord{
+			Version:   m.version,
+			Name:      m.name,
+			AppliedAt: time.Now().Unix(),
+		})
+		if err != nil && !mongo.IsDuplicateKeyError(err) {
+			logger.Error("Error trying to record migration", err,
+				zap.Int64("version", m.version), zap.String("name", m.name))
+			return err
+		}
+
+		logger.Info("Applied migration", zap.Int64("version", m.version), zap.String("name", m.name))
+	}
+
+	return nil
+}
+
+func createIndexes(ctx context.Context, database *mongo.Database, collection string, indexes ...mongo.IndexModel) error {
+	_, err := database.Collection(collection).Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+// dropIndex drops the named index, doing nothing when it or its collection
+// does not exist.
+func dropIndex(ctx context.Context, database *mongo.Database, collection, name string) error {
+	_, err := database.Collection(collection).Indexes().DropOne(ctx, name)
+
+	var commandError mongo.CommandError
+	if errors.As(err, &commandError) && (commandError.Name == "IndexNotFound" || commandError.Name == "NamespaceNotFound") {
+		return nil
+	}
+
+	return err
+}
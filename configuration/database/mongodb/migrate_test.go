@@ -0,0 +1,66 @@
+package mongodb
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestMigrationsInVersionOrder(t *testing.T) {
+	assert.NotEmpty(t, migrations)
+	for i, m := range migrations {
+		assert.NotEmpty(t, m.name)
+		assert.NotNil(t, m.up)
+		if i > 0 {
+			assert.Greater(t, m.version, migrations[i-1].version)
+		}
+	}
+}
+
+// TestMigrateTwice needs a MongoDB and is skipped unless MONGODB_TEST_URL is
+// set.
+func TestMigrateTwice(t *testing.T) {
+	mongoURL := os.Getenv("MONGODB_TEST_URL")
+	if mongoURL == "" {
+		t.Skip("MONGODB_TEST_URL not set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURL))
+	if err != nil {
+		t.Fatalf("connecting to MongoDB: %v", err)
+	}
+	defer client.Disconnect(context.Background())
+
+	database := client.Database("test_migrate_" + time.Now().Format("20060102150405.000000"))
+	defer database.Drop(context.Background())
+
+	assert.NoError(t, Migrate(ctx, database))
+	assert.NoError(t, Migrate(ctx, database))
+
+	applied, err := database.Collection(migrationsCollection).CountDocuments(ctx, bson.M{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(migrations)), applied)
+
+	cursor, err := database.Collection("auctions").Indexes().List(ctx)
+	assert.NoError(t, err)
+	var indexes []bson.M
+	assert.NoError(t, cursor.All(ctx, &indexes))
+
+	var names []string
+	for _, index := range indexes {
+		names = append(names, index["name"].(string))
+	}
+	assert.Contains(t, names, "status_1_end_time_1")
+	assert.Contains(t, names, "search_text")
+	assert.NotContains(t, names, "product_name_text")
+	assert.NotContains(t, names, "end_time_1")
+}
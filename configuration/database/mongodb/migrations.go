@@ -0,0 +1,186 @@
+package mongodb
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// migrations is in version order. Applied migrations are recorded by
+// version, so released ones must not be changed: add a new one instead.
+var migrations = []migration{
+	{
+		// The indexes mongo-init.js used to create, so databases it did not
+		// initialize get them too.
+		version: 1,
+		name:    "create_indexes",
+		up: func(ctx context.Context, database *mongo.Database) error {
+			if err := createIndexes(ctx, database, "auctions",
+				mongo.IndexModel{Keys: bson.D{{Key: "end_time", Value: 1}}}); err != nil {
+				return err
+			}
+			if err := createIndexes(ctx, database, "webhooks",
+				mongo.IndexModel{Keys: bson.D{{Key: "events", Value: 1}}}); err != nil {
+				return err
+			}
+			if err := createIndexes(ctx, database, "webhook_deliveries",
+				mongo.IndexModel{Keys: bson.D{{Key: "webhook_id", Value: 1}}}); err != nil {
+				return err
+			}
+			if err := createIndexes(ctx, database, "api_keys",
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "hash", Value: 1}},
+					Options: options.Index().SetUnique(true),
+				},
+				mongo.IndexModel{Keys: bson.D{{Key: "user_id", Value: 1}}}); err != nil {
+				return err
+			}
+			for _, collection := range []string{"rate_limits", "idempotency_keys"} {
+				if err := createIndexes(ctx, database, collection, mongo.IndexModel{
+					Keys:    bson.D{{Key: "expires_at", Value: 1}},
+					Options: options.Index().SetExpireAfterSeconds(0),
+				}); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	},
+	{
+		// The closer looks auctions up by status and end_time, and bids are
+		// read by auction ordered by amount.
+		version: 2,
+		name:    "index_status_end_time_and_bid_amount",
+		up: func(ctx context.Context, database *mongo.Database) error {
+			if err := createIndexes(ctx, database, "auctions", mongo.IndexModel{
+				Keys: bson.D{{Key: "status", Value: 1}, {Key: "end_time", Value: 1}},
+			}); err != nil {
+				return err
+			}
+			if err := createIndexes(ctx, database, "bids", mongo.IndexModel{
+				Keys: bson.D{{Key: "auction_id", Value: 1}, {Key: "amount", Value: -1}},
+			}); err != nil {
+				return err
+			}
+
+			// status_1_end_time_1 covers every query end_time_1 served.
+			return dropIndex(ctx, database, "auctions", "end_time_1")
+		},
+	},
+	{
+		version: 3,
+		name:    "text_index_product_name",
+		up: func(ctx context.Context, database *mongo.Database) error {
+			return createIndexes(ctx, database, "auctions", mongo.IndexModel{
+				Keys: bson.D{{Key: "product_name", Value: "text"}},
+			})
+		},
+	},
+	{
+		// A collection has a single text index, so searching the category
+		// and description too replaces product_name_text. Weights follow
+		// auction_entity.ProductNameWeight and its siblings; language "none"
+		// matches whole words without stemming or stop words.
+		version: 4,
+		name:    "text_index_search_fields",
+		up: func(ctx context.Context, database *mongo.Database) error {
+			if err := dropIndex(ctx, database, "auctions", "product_name_text"); err != nil {
+				return err
+			}
+
+			return createIndexes(ctx, database, "auctions", mongo.IndexModel{
+				Keys: bson.D{
+					{Key: "product_name", Value: "text"},
+					{Key: "category", Value: "text"},
+					{Key: "description", Value: "text"},
+				},
+				Options: options.Index().
+					SetName("search_text").
+					SetWeights(bson.M{
+						"product_name": auction_entity.ProductNameWeight,
+						"category":     auction_entity.CategoryWeight,
+						"description":  auction_entity.DescriptionWeight,
+					}).
+					SetDefaultLanguage("none"),
+			})
+		},
+	},
+	{
+		version: 5,
+		name:    "create_category_indexes",
+		up: func(ctx context.Context, database *mongo.Database) error {
+			if err := createIndexes(ctx, database, "categories",
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "slug", Value: 1}},
+					Options: options.Index().SetUnique(true),
+				},
+				mongo.IndexModel{Keys: bson.D{{Key: "parent_id", Value: 1}}}); err != nil {
+				return err
+			}
+
+			return createIndexes(ctx, database, "auctions",
+				mongo.IndexModel{Keys: bson.D{{Key: "category", Value: 1}}})
+		},
+	},
+	{
+		// Auctions reference their category by id since version 5, so
+		// search matches the category name, kept in category_name, instead.
+		// Auctions created before the category tree keep their free-form
+		// category as the name.
+		version: 6,
+		name:    "text_index_category_name",
+		up: func(ctx context.Context, database *mongo.Database) error {
+			cursor, err := database.Collection("categories").Find(ctx, bson.M{},
+				options.Find().SetProjection(bson.M{"name": 1}))
+			if err != nil {
+				return err
+			}
+
+			var categories []struct {
+				Id   string `bson:"_id"`
+				Name string `bson:"name"`
+			}
+			if err := cursor.All(ctx, &categories); err != nil {
+				return err
+			}
+
+			auctions := database.Collection("auctions")
+			for _, category := range categories {
+				if _, err := auctions.UpdateMany(ctx,
+					bson.M{"category": category.Id, "category_name": bson.M{"$exists": false}},
+					bson.M{"$set": bson.M{"category_name": category.Name}}); err != nil {
+					return err
+				}
+			}
+			if _, err := auctions.UpdateMany(ctx,
+				bson.M{"category_name": bson.M{"$exists": false}},
+				bson.A{bson.M{"$set": bson.M{"category_name": "$category"}}}); err != nil {
+				return err
+			}
+
+			if err := dropIndex(ctx, database, "auctions", "search_text"); err != nil {
+				return err
+			}
+
+			return createIndexes(ctx, database, "auctions", mongo.IndexModel{
+				Keys: bson.D{
+					{Key: "product_name", Value: "text"},
+					{Key: "category_name", Value: "text"},
+					{Key: "description", Value: "text"},
+				},
+				Options: options.Index().
+					SetName("search_text").
+					SetWeights(bson.M{
+						"product_name":  auction_entity.ProductNameWeight,
+						"category_name": auction_entity.CategoryWeight,
+						"description":   auction_entity.DescriptionWeight,
+					}).
+					SetDefaultLanguage("none"),
+			})
+		},
+	},
+}
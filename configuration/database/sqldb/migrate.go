@@ -0,0 +1,129 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+//go:embed migrations
+var migrationFiles embed.FS
+
+type migration struct {
+	version int64
+	name    string
+	script  string
+}
+
+// Migrate applies the dialect's migrations that are not recorded in
+// schema_migrations yet, in version order, each in its own transaction.
+// Migration files are named <version>_<name>.sql.
+func Migrate(ctx context.Context, db *sql.DB, dialect Dialect) error {
+	if _, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at BIGINT NOT NULL
+	)`); err != nil {
+		logger.Error("Error trying to create schema_migrations", err)
+		return err
+	}
+
+	migrations, err := loadMigrations(dialect)
+	if err != nil {
+		logger.Error("Error trying to load migrations", err)
+		return err
+	}
+
+	for _, m := range migrations {
+		if err := applyMigration(ctx, db, dialect, m); err != nil {
+			logger.Error("Error trying to apply migration", err,
+				zap.Int64("version", m.version), zap.String("name", m.name))
+			return fmt.Errorf("migration %d_%s: %w", m.version, m.name, err)
+		}
+	}
+
+	return nil
+}
+
+func loadMigrations(dialect Dialect) ([]migration, error) {
+	entries, err := migrationFiles.ReadDir(dialect.migrationsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []migration
+	for _, entry := range entries {
+		base := strings.TrimSuffix(entry.Name(), ".sql")
+		versionText, name, ok := strings.Cut(base, "_")
+		if entry.IsDir() || base == entry.Name() || !ok {
+			return nil, fmt.Errorf("unexpected migration file %s", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(versionText, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected migration file %s", entry.Name())
+		}
+
+		script, err := migrationFiles.ReadFile(path.Join(dialect.migrationsDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migrations = append(migrations, migration{version: version, name: name, script: string(script)})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].version < migrations[j].version
+	})
+
+	return migrations, nil
+}
+
+func applyMigration(ctx context.Context, db *sql.DB, dialect Dialect, m migration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if dialect.LockMigrations != "" {
+		if _, err := tx.ExecContext(ctx, dialect.LockMigrations); err != nil {
+			return err
+		}
+	}
+
+	var applied int
+	if err := tx.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM schema_migrations WHERE version = $1", m.version).Scan(&applied); err != nil {
+		return err
+	}
+	if applied > 0 {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, m.script); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		"INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, $3)",
+		m.version, m.name, time.Now().Unix()); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	logger.Info("Applied migration", zap.Int64("version", m.version), zap.String("name", m.name))
+	return nil
+}
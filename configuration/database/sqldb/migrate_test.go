@@ -0,0 +1,120 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadMigrationsInVersionOrder(t *testing.T) {
+	for _, dialect := range []Dialect{Postgres, SQLite} {
+		migrations, err := loadMigrations(dialect)
+		assert.NoError(t, err, dialect.Name)
+		assert.NotEmpty(t, migrations, dialect.Name)
+
+		for i, m := range migrations {
+			assert.NotEmpty(t, m.name)
+			assert.NotEmpty(t, m.script)
+			if i > 0 {
+				assert.Greater(t, m.version, migrations[i-1].version)
+			}
+		}
+	}
+}
+
+func TestPlaceholders(t *testing.T) {
+	assert.Equal(t, "$2, $3, $4", Placeholders(2, 3))
+	assert.Equal(t, "$1", Placeholders(1, 1))
+}
+
+func TestMigrateSQLiteTwice(t *testing.T) {
+	db, err := OpenSQLite(filepath.Join(t.TempDir(), "auction.db"))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	assert.NoError(t, Migrate(ctx, db, SQLite))
+	assert.NoError(t, Migrate(ctx, db, SQLite))
+
+	migrations, err := loadMigrations(SQLite)
+	assert.NoError(t, err)
+
+	var applied int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&applied))
+	assert.Equal(t, len(migrations), applied)
+}
+
+func TestSQLiteIndexesExistingAuctionsForSearch(t *testing.T) {
+	db, err := OpenSQLite(filepath.Join(t.TempDir(), "auction.db"))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// Apply the migrations before the search index, as an older release did.
+	ctx := context.Background()
+	migrateUntil(t, db, "create_auction_search")
+
+	_, err = db.ExecContext(ctx, `INSERT INTO auctions
+		(id, product_name, category, description, condition, status, timestamp, end_time)
+		VALUES ('a', 'Smartphone', 'Phones', 'Câmera nova', 1, 0, 0, 0)`)
+	assert.NoError(t, err)
+	assert.NoError(t, Migrate(ctx, db, SQLite))
+
+	match, value, _ := SQLite.MatchesAuctionText([]string{"camera"}, "$1")
+	var id string
+	assert.NoError(t, db.QueryRowContext(ctx, "SELECT id FROM auctions WHERE "+match, value).Scan(&id))
+	assert.Equal(t, "a", id)
+}
+
+func TestSQLiteIndexesCategoryNamesForSearch(t *testing.T) {
+	db, err := OpenSQLite(filepath.Join(t.TempDir(), "auction.db"))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	migrateUntil(t, db, "add_auction_category_name")
+
+	_, err = db.ExecContext(ctx, `INSERT INTO categories (id, name, slug, timestamp)
+		VALUES ('70d49d43-0000-4000-8000-000000000000', 'Eletrônicos', 'eletronicos', 0)`)
+	assert.NoError(t, err)
+	for _, auction := range [][]string{{"a", "70d49d43-0000-4000-8000-000000000000"}, {"b", "Livros"}} {
+		_, err = db.ExecContext(ctx, `INSERT INTO auctions
+			(id, product_name, category, description, condition, status, timestamp, end_time)
+			VALUES ($1, 'Produto', $2, 'Descrição', 1, 0, 0, 0)`, auction[0], auction[1])
+		assert.NoError(t, err)
+		_, err = db.ExecContext(ctx, SQLite.IndexAuctionText, auction[0], "produto", auction[1], "descricao")
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, Migrate(ctx, db, SQLite))
+
+	for text, want := range map[string]string{"eletronicos": "a", "livros": "b"} {
+		match, value, _ := SQLite.MatchesAuctionText([]string{text}, "$1")
+		var id string
+		assert.NoError(t, db.QueryRowContext(ctx, "SELECT id FROM auctions WHERE "+match, value).Scan(&id), text)
+		assert.Equal(t, want, id, text)
+	}
+
+	match, value, _ := SQLite.MatchesAuctionText([]string{"70d49d43"}, "$1")
+	var matches int
+	assert.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM auctions WHERE "+match, value).Scan(&matches))
+	assert.Zero(t, matches)
+}
+
+// migrateUntil applies the migrations before the one named name.
+func migrateUntil(t *testing.T, db *sql.DB, name string) {
+	ctx := context.Background()
+	_, err := db.ExecContext(ctx,
+		"CREATE TABLE schema_migrations (version BIGINT PRIMARY KEY, name TEXT NOT NULL, applied_at BIGINT NOT NULL)")
+	assert.NoError(t, err)
+
+	migrations, err := loadMigrations(SQLite)
+	assert.NoError(t, err)
+	for _, m := range migrations {
+		if m.name == name {
+			return
+		}
+		assert.NoError(t, applyMigration(ctx, db, SQLite, m))
+	}
+}
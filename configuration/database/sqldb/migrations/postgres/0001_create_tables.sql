@@ -0,0 +1,77 @@
+-- seq keeps the insertion order, which is the order unsorted queries return
+-- rows in on every backend. Times are Unix seconds, as in MongoDB.
+
+CREATE TABLE auctions (
+    seq BIGSERIAL NOT NULL UNIQUE,
+    id TEXT PRIMARY KEY,
+    product_name TEXT NOT NULL,
+    category TEXT NOT NULL,
+    description TEXT NOT NULL,
+    condition INTEGER NOT NULL,
+    status INTEGER NOT NULL,
+    timestamp BIGINT NOT NULL,
+    end_time BIGINT NOT NULL
+);
+
+CREATE INDEX auctions_status_end_time ON auctions (status, end_time);
+
+CREATE TABLE bids (
+    seq BIGSERIAL NOT NULL UNIQUE,
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    auction_id TEXT NOT NULL REFERENCES auctions (id),
+    amount DOUBLE PRECISION NOT NULL,
+    timestamp BIGINT NOT NULL
+);
+
+CREATE INDEX bids_auction_id_amount ON bids (auction_id, amount DESC);
+
+CREATE TABLE users (
+    seq BIGSERIAL NOT NULL UNIQUE,
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL
+);
+
+CREATE TABLE webhooks (
+    seq BIGSERIAL NOT NULL UNIQUE,
+    id TEXT PRIMARY KEY,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    timestamp BIGINT NOT NULL
+);
+
+CREATE TABLE webhook_events (
+    webhook_id TEXT NOT NULL REFERENCES webhooks (id),
+    event_type TEXT NOT NULL,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (webhook_id, event_type)
+);
+
+CREATE INDEX webhook_events_event_type ON webhook_events (event_type);
+
+CREATE TABLE webhook_deliveries (
+    id TEXT PRIMARY KEY,
+    webhook_id TEXT NOT NULL,
+    event_id BIGINT NOT NULL,
+    event_type TEXT NOT NULL,
+    attempt INTEGER NOT NULL,
+    status_code INTEGER NOT NULL,
+    success BOOLEAN NOT NULL,
+    error TEXT NOT NULL,
+    timestamp BIGINT NOT NULL
+);
+
+CREATE INDEX webhook_deliveries_webhook_id ON webhook_deliveries (webhook_id, timestamp, attempt);
+
+-- Scopes and roles are stored comma-separated.
+CREATE TABLE api_keys (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    prefix TEXT NOT NULL,
+    hash TEXT NOT NULL UNIQUE,
+    scopes TEXT NOT NULL,
+    roles TEXT NOT NULL,
+    timestamp BIGINT NOT NULL,
+    revoked_at BIGINT
+);
@@ -0,0 +1,3 @@
+-- Webhooks registered before owners were recorded keep an empty user_id and
+-- are only managed by admins.
+ALTER TABLE webhooks ADD COLUMN user_id TEXT NOT NULL DEFAULT '';
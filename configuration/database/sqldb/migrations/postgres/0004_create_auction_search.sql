@@ -0,0 +1,16 @@
+-- search_vector holds the words of product_name (weight A), category (B) and
+-- description (C), lowercased and without accents; the repository fills it
+-- in when it inserts an auction. translate only strips the Latin-1 accents
+-- from the auctions created before this migration.
+
+ALTER TABLE auctions ADD COLUMN search_vector tsvector NOT NULL DEFAULT ''::tsvector;
+
+UPDATE auctions SET search_vector =
+    setweight(to_tsvector('simple', translate(lower(product_name),
+        'áàâãäåçéèêëíìîïñóòôõöúùûüý', 'aaaaaaceeeeiiiinooooouuuuy')), 'A') ||
+    setweight(to_tsvector('simple', translate(lower(category),
+        'áàâãäåçéèêëíìîïñóòôõöúùûüý', 'aaaaaaceeeeiiiinooooouuuuy')), 'B') ||
+    setweight(to_tsvector('simple', translate(lower(description),
+        'áàâãäåçéèêëíìîïñóòôõöúùûüý', 'aaaaaaceeeeiiiinooooouuuuy')), 'C');
+
+CREATE INDEX auctions_search_vector ON auctions USING GIN (search_vector);
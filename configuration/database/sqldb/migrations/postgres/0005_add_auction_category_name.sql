@@ -0,0 +1,16 @@
+-- Auctions reference their category by id since 0002, so search matches the
+-- category name, kept in category_name, instead. Auctions created before the
+-- taxonomy keep their free-form category as the name.
+
+ALTER TABLE auctions ADD COLUMN category_name TEXT NOT NULL DEFAULT '';
+
+UPDATE auctions SET category_name = COALESCE(
+    (SELECT name FROM categories WHERE categories.id = auctions.category), category);
+
+UPDATE auctions SET search_vector =
+    setweight(to_tsvector('simple', translate(lower(product_name),
+        'áàâãäåçéèêëíìîïñóòôõöúùûüý', 'aaaaaaceeeeiiiinooooouuuuy')), 'A') ||
+    setweight(to_tsvector('simple', translate(lower(category_name),
+        'áàâãäåçéèêëíìîïñóòôõöúùûüý', 'aaaaaaceeeeiiiinooooouuuuy')), 'B') ||
+    setweight(to_tsvector('simple', translate(lower(description),
+        'áàâãäåçéèêëíìîïñóòôõöúùûüý', 'aaaaaaceeeeiiiinooooouuuuy')), 'C');
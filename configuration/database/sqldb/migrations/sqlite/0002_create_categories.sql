@@ -0,0 +1,15 @@
+-- Auctions keep referencing categories through auctions.category, without a
+-- foreign key: auctions created before the taxonomy hold free-form names.
+
+CREATE TABLE categories (
+    seq INTEGER PRIMARY KEY AUTOINCREMENT,
+    id TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    slug TEXT NOT NULL UNIQUE,
+    parent_id TEXT REFERENCES categories (id),
+    timestamp INTEGER NOT NULL
+);
+
+CREATE INDEX categories_parent_id ON categories (parent_id);
+
+CREATE INDEX auctions_category ON auctions (category);
@@ -0,0 +1,13 @@
+-- auctions_search indexes the words of every auction under its seq. The
+-- repository fills it in when it inserts an auction; the tokenizer folds
+-- case and accents, so existing auctions are indexed from their raw text.
+
+CREATE VIRTUAL TABLE auctions_search USING fts5(
+    product_name,
+    category,
+    description,
+    tokenize = 'unicode61 remove_diacritics 2'
+);
+
+INSERT INTO auctions_search (rowid, product_name, category, description)
+    SELECT seq, product_name, category, description FROM auctions;
@@ -0,0 +1,11 @@
+-- Auctions reference their category by id since 0002, so search matches the
+-- category name, kept in category_name, instead. Auctions created before the
+-- taxonomy keep their free-form category as the name.
+
+ALTER TABLE auctions ADD COLUMN category_name TEXT NOT NULL DEFAULT '';
+
+UPDATE auctions SET category_name = COALESCE(
+    (SELECT name FROM categories WHERE categories.id = auctions.category), category);
+
+UPDATE auctions_search SET category =
+    (SELECT category_name FROM auctions WHERE auctions.seq = auctions_search.rowid);
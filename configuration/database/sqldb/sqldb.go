@@ -0,0 +1,112 @@
+// Package sqldb connects to the SQL databases the repositories can run on
+// and keeps their schema up to date.
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"os"
+	"strings"
+
+	_ "github.com/lib/pq"
+)
+
+const (
+	POSTGRES_URL = "POSTGRES_URL"
+)
+
+// Dialect holds what differs between the SQL databases. Queries use $1, $2,
+// ... bind parameters, which every supported driver accepts.
+type Dialect struct {
+	Name string
+
+	// ForUpdate is appended to a SELECT to lock the rows it returns until
+	// the transaction ends.
+	ForUpdate string
+
+	// ForUpdateSkipLocked is like ForUpdate but leaves out the rows another
+	// transaction holds, so several instances can share the work.
+	ForUpdateSkipLocked string
+
+	// MatchesRegex returns a condition that is true when column matches the
+	// case-insensitive regular expression bound to param.
+	MatchesRegex func(column, param string) string
+
+	// IndexAuctionText adds an auction to the text search index, or
+	// replaces its entry. It binds the auction id to $1 and the words of its
+	// product name, category name and description, lowercased, without
+	// accents and joined by spaces, to $2, $3 and $4.
+	IndexAuctionText string
+
+	// MatchesAuctionText returns a condition that is true for the auctions
+	// holding any of terms, the value to bind to param, and an expression
+	// ranking those auctions, higher first, or "" when the dialect leaves
+	// ranking to the application.
+	MatchesAuctionText func(terms []string, param string) (condition string, value string, rank string)
+
+	// LockMigrations runs at the start of every migration transaction so
+	// instances starting together apply migrations one at a time.
+	LockMigrations string
+
+	migrationsDir string
+}
+
+var Postgres = Dialect{
+	Name:                "postgres",
+	ForUpdate:           " FOR UPDATE",
+	ForUpdateSkipLocked: " FOR UPDATE SKIP LOCKED",
+	MatchesRegex: func(column, param string) string {
+		return column + " ~* " + param
+	},
+	// The weights of ts_rank, given for D, C, B and A, keep the 10:5:1 ratio
+	// of auction_entity.ProductNameWeight and its siblings.
+	IndexAuctionText: `UPDATE auctions SET search_vector =
+		setweight(to_tsvector('simple', $2::text), 'A') ||
+		setweight(to_tsvector('simple', $3::text), 'B') ||
+		setweight(to_tsvector('simple', $4::text), 'C')
+		WHERE id = $1`,
+	MatchesAuctionText: func(terms []string, param string) (string, string, string) {
+		query := "to_tsquery('simple', " + param + ")"
+		return "search_vector @@ " + query,
+			strings.Join(terms, " | "),
+			"ts_rank('{0, 0.1, 0.5, 1}', search_vector, " + query + ")"
+	},
+	LockMigrations: "SELECT pg_advisory_xact_lock(4242)",
+	migrationsDir:  "migrations/postgres",
+}
+
+// NewPostgresConnection opens the database at POSTGRES_URL and applies the
+// pending migrations.
+func NewPostgresConnection(ctx context.Context) (*sql.DB, error) {
+	db, err := sql.Open("postgres", os.Getenv(POSTGRES_URL))
+	if err != nil {
+		logger.Error("Error trying to open postgres database", err)
+		return nil, err
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		logger.Error("Error trying to ping postgres database", err)
+		db.Close()
+		return nil, err
+	}
+
+	if err := Migrate(ctx, db, Postgres); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// Placeholders returns count bind parameters numbered from first, for IN
+// lists: Placeholders(2, 3) is "$2, $3, $4".
+func Placeholders(first, count int) string {
+	placeholders := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		placeholders = append(placeholders, fmt.Sprintf("$%d", first+i))
+	}
+
+	return strings.Join(placeholders, ", ")
+}
@@ -0,0 +1,107 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"modernc.org/sqlite"
+)
+
+const (
+	SQLITE_PATH = "SQLITE_PATH"
+)
+
+func init() {
+	// SQLite parses REGEXP but leaves the function to the application:
+	// "column REGEXP pattern" calls regexp(pattern, column).
+	sqlite.MustRegisterDeterministicScalarFunction("regexp", 2,
+		func(ctx *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+			pattern, ok := args[0].(string)
+			if !ok {
+				return nil, fmt.Errorf("regexp pattern must be text")
+			}
+			value, _ := args[1].(string)
+
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, err
+			}
+
+			return re.MatchString(value), nil
+		})
+}
+
+// SQLite has no row locks. Its connections begin every transaction with
+// BEGIN IMMEDIATE, which takes the database write lock up front, so the
+// transactions that would lock rows on Postgres run one at a time.
+var SQLite = Dialect{
+	Name:                "sqlite",
+	ForUpdate:           "",
+	ForUpdateSkipLocked: "",
+	MatchesRegex: func(column, param string) string {
+		return column + " REGEXP '(?i)' || " + param
+	},
+	IndexAuctionText: `INSERT OR REPLACE INTO auctions_search (rowid, product_name, category, description)
+		SELECT seq, $2, $3, $4 FROM auctions WHERE id = $1`,
+	// bm25 barely tells matches apart while a word is in most auctions, so
+	// the FTS5 index only finds the matches.
+	MatchesAuctionText: func(terms []string, param string) (string, string, string) {
+		quoted := make([]string, 0, len(terms))
+		for _, term := range terms {
+			quoted = append(quoted, `"`+term+`"`)
+		}
+		return "seq IN (SELECT rowid FROM auctions_search WHERE auctions_search MATCH " + param + ")",
+			strings.Join(quoted, " OR "),
+			""
+	},
+	LockMigrations: "",
+	migrationsDir:  "migrations/sqlite",
+}
+
+// NewSQLiteConnection opens the database file at SQLITE_PATH, creating it
+// when missing, and applies the pending migrations.
+func NewSQLiteConnection(ctx context.Context) (*sql.DB, error) {
+	path := os.Getenv(SQLITE_PATH)
+	if path == "" {
+		path = "auction.db"
+	}
+
+	db, err := OpenSQLite(path)
+	if err != nil {
+		logger.Error("Error trying to open sqlite database", err)
+		return nil, err
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		logger.Error("Error trying to ping sqlite database", err)
+		db.Close()
+		return nil, err
+	}
+
+	if err := Migrate(ctx, db, SQLite); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// OpenSQLite opens the database file at path with foreign keys enforced,
+// write-ahead logging, and transactions that wait up to five seconds for
+// the write lock instead of failing.
+func OpenSQLite(path string) (*sql.DB, error) {
+	query := url.Values{}
+	query.Add("_pragma", "foreign_keys(1)")
+	query.Add("_pragma", "journal_mode(WAL)")
+	query.Add("_pragma", "busy_timeout(5000)")
+	query.Set("_txlock", "immediate")
+
+	return sql.Open("sqlite", "file:"+path+"?"+query.Encode())
+}
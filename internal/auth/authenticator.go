@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/internal_error"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const (
+	BearerScheme = "Bearer"
+	ApiKeyScheme = "ApiKey"
+)
+
+// Authenticator resolves Authorization header values for every transport, so
+// HTTP, WebSocket and gRPC accept the same credentials.
+type Authenticator struct {
+	tokenVerifier  TokenVerifierInterface
+	apiKeyVerifier ApiKeyVerifierInterface
+}
+
+func NewAuthenticator(
+	tokenVerifier TokenVerifierInterface,
+	apiKeyVerifier ApiKeyVerifierInterface) *Authenticator {
+
+	return &Authenticator{
+		tokenVerifier:  tokenVerifier,
+		apiKeyVerifier: apiKeyVerifier,
+	}
+}
+
+// Authenticate returns the caller for an Authorization header value such as
+// "Bearer <jwt>" or "ApiKey <key>".
+func (a *Authenticator) Authenticate(
+	ctx context.Context, authorization string) (*Identity, *internal_error.InternalError) {
+
+	scheme, credential, _ := strings.Cut(strings.TrimSpace(authorization), " ")
+	credential = strings.TrimSpace(credential)
+	if credential == "" {
+		return nil, internal_error.NewUnauthorizedError("Missing credentials")
+	}
+
+	switch {
+	case strings.EqualFold(scheme, BearerScheme) && a.tokenVerifier != nil:
+		identity, err := a.tokenVerifier.Verify(credential)
+		if err != nil {
+			logger.Info("Rejected bearer token", zap.String("reason", err.Error()))
+			return nil, internal_error.NewUnauthorizedError("Invalid bearer token")
+		}
+		return identity, nil
+	case strings.EqualFold(scheme, ApiKeyScheme) && a.apiKeyVerifier != nil:
+		return a.apiKeyVerifier.VerifyApiKey(ctx, credential)
+	default:
+		return nil, internal_error.NewUnauthorizedError("Unsupported authorization scheme")
+	}
+}
@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+type Scope string
+
+const (
+	ScopeReadOnly       Scope = "read-only"
+	ScopeBid            Scope = "bid"
+	ScopeManageAuctions Scope = "manage-auctions"
+)
+
+var Scopes = []Scope{ScopeReadOnly, ScopeBid, ScopeManageAuctions}
+
+func IsValidScope(scope Scope) bool {
+	for _, value := range Scopes {
+		if value == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type Identity struct {
+	UserId string
+	Roles  []Role
+	// Scopes is only set for API keys. Interactive callers are limited by
+	// their roles alone.
+	Scopes   []Scope
+	ApiKeyId string
+}
+
+func (i *Identity) HasRole(role Role) bool {
+	for _, r := range i.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// HasScope reports whether the caller may use routes guarded by scope. Every
+// API key can read, so ScopeReadOnly is granted by any scope.
+func (i *Identity) HasScope(scope Scope) bool {
+	if i.ApiKeyId == "" || scope == ScopeReadOnly {
+		return true
+	}
+
+	for _, s := range i.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type TokenVerifierInterface interface {
+	Verify(token string) (*Identity, error)
+}
+
+type ApiKeyVerifierInterface interface {
+	VerifyApiKey(ctx context.Context, key string) (*Identity, *internal_error.InternalError)
+}
+
+type identityKey struct{}
+
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+func IdentityFromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok && identity != nil
+}
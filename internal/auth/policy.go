@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+type Role string
+
+const (
+	RoleAdmin  Role = "admin"
+	RoleSeller Role = "seller"
+	RoleBidder Role = "bidder"
+)
+
+type Action string
+
+const (
+	CreateAuction    Action = "auction:create"
+	PlaceBid         Action = "bid:create"
+	IssueApiKey      Action = "api_key:create"
+	RevokeApiKey     Action = "api_key:revoke"
+	ManageCategories Action = "category:manage"
+	RegisterWebhook  Action = "webhook:create"
+	ManageWebhook    Action = "webhook:manage"
+	ViewMetrics      Action = "metrics:view"
+)
+
+type Rule struct {
+	// Roles may perform the action on any resource.
+	Roles []Role
+	// Owner lets the owner of the resource perform the action without one of
+	// the roles above.
+	Owner bool
+	// Scope is required from API key callers on top of the checks above.
+	Scope Scope
+}
+
+var Policy = map[Action]Rule{
+	CreateAuction:    {Roles: []Role{RoleSeller}, Scope: ScopeManageAuctions},
+	PlaceBid:         {Roles: []Role{RoleBidder}, Scope: ScopeBid},
+	IssueApiKey:      {Roles: []Role{RoleBidder, RoleSeller, RoleAdmin}},
+	RevokeApiKey:     {Roles: []Role{RoleAdmin}, Owner: true},
+	ManageCategories: {Roles: []Role{RoleAdmin}, Scope: ScopeManageAuctions},
+	RegisterWebhook:  {Roles: []Role{RoleSeller, RoleAdmin}, Scope: ScopeManageAuctions},
+	ManageWebhook:    {Roles: []Role{RoleAdmin}, Owner: true, Scope: ScopeManageAuctions},
+	ViewMetrics:      {Roles: []Role{RoleAdmin}, Scope: ScopeReadOnly},
+}
+
+// Authorize checks the caller stored in ctx against the rule of action.
+// ownerId is the user that owns the resource, or "" when ownership does not
+// apply. Actions without a rule are denied.
+func Authorize(ctx context.Context, action Action, ownerId string) *internal_error.InternalError {
+	identity, ok := IdentityFromContext(ctx)
+	if !ok {
+		return internal_error.NewUnauthorizedError("Authentication is required")
+	}
+
+	rule, ok := Policy[action]
+	if !ok {
+		return internal_error.NewForbiddenError("You are not allowed to perform " + string(action))
+	}
+
+	if rule.Scope != "" && !identity.HasScope(rule.Scope) {
+		return internal_error.NewForbiddenError("API key lacks the " + string(rule.Scope) + " scope")
+	}
+
+	if rule.Owner && ownerId != "" && ownerId == identity.UserId {
+		return nil
+	}
+
+	for _, role := range rule.Roles {
+		if identity.HasRole(role) {
+			return nil
+		}
+	}
+
+	return internal_error.NewForbiddenError("You are not allowed to perform " + string(action))
+}
@@ -0,0 +1,67 @@
+package auth_test
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/auth"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	userId      = "6b0d6f1c-0b5e-4d6b-8f8e-2b9d1a3c4e5f"
+	otherUserId = "9e1f2a3b-4c5d-4e6f-8a7b-0c1d2e3f4a5b"
+)
+
+func as(roles ...auth.Role) context.Context {
+	return auth.WithIdentity(context.Background(), &auth.Identity{UserId: userId, Roles: roles})
+}
+
+func withApiKey(scopes ...auth.Scope) context.Context {
+	return auth.WithIdentity(context.Background(), &auth.Identity{
+		UserId: userId, Roles: []auth.Role{auth.RoleBidder}, Scopes: scopes, ApiKeyId: otherUserId})
+}
+
+func TestAuthorize(t *testing.T) {
+	testCases := []struct {
+		name    string
+		ctx     context.Context
+		action  auth.Action
+		ownerId string
+		err     string
+	}{
+		{"anonymous", context.Background(), auth.PlaceBid, "", "unauthorized"},
+		{"seller creates auction", as(auth.RoleSeller), auth.CreateAuction, "", ""},
+		{"bidder creates auction", as(auth.RoleBidder), auth.CreateAuction, "", "forbidden"},
+		{"bidder places bid", as(auth.RoleBidder), auth.PlaceBid, "", ""},
+		{"bidder revokes own api key", as(auth.RoleBidder), auth.RevokeApiKey, userId, ""},
+		{"bidder revokes other api key", as(auth.RoleBidder), auth.RevokeApiKey, otherUserId, "forbidden"},
+		{"admin revokes other api key", as(auth.RoleAdmin), auth.RevokeApiKey, otherUserId, ""},
+		{"admin manages categories", as(auth.RoleAdmin), auth.ManageCategories, "", ""},
+		{"seller manages categories", as(auth.RoleSeller), auth.ManageCategories, "", "forbidden"},
+		{"read-only api key places bid", withApiKey(auth.ScopeReadOnly), auth.PlaceBid, "", "forbidden"},
+		{"bid api key places bid", withApiKey(auth.ScopeBid), auth.PlaceBid, "", ""},
+		{"seller registers webhook", as(auth.RoleSeller), auth.RegisterWebhook, "", ""},
+		{"bidder registers webhook", as(auth.RoleBidder), auth.RegisterWebhook, "", "forbidden"},
+		{"seller manages own webhook", as(auth.RoleSeller), auth.ManageWebhook, userId, ""},
+		{"seller manages other webhook", as(auth.RoleSeller), auth.ManageWebhook, otherUserId, "forbidden"},
+		{"admin manages webhook without owner", as(auth.RoleAdmin), auth.ManageWebhook, "", ""},
+		{"read-only api key manages own webhook", withApiKey(auth.ScopeReadOnly), auth.ManageWebhook, userId, "forbidden"},
+		{"admin views metrics", as(auth.RoleAdmin), auth.ViewMetrics, "", ""},
+		{"seller views metrics", as(auth.RoleSeller), auth.ViewMetrics, "", "forbidden"},
+		{"unknown action", as(auth.RoleAdmin), auth.Action("auction:delete"), "", "forbidden"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := auth.Authorize(testCase.ctx, testCase.action, testCase.ownerId)
+			if testCase.err == "" {
+				assert.Nil(t, err)
+				return
+			}
+			if assert.NotNil(t, err) {
+				assert.Equal(t, testCase.err, err.Err)
+			}
+		})
+	}
+}
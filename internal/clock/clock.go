@@ -0,0 +1,42 @@
+// Package clock abstracts the current time so auction timing can be tested
+// without sleeping: the application uses System, tests use a FakeClock.
+package clock
+
+import "time"
+
+type Clock interface {
+	Now() time.Time
+
+	// NewTicker behaves like time.NewTicker.
+	NewTicker(d time.Duration) Ticker
+}
+
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// System is the clock of the machine.
+var System Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return systemTicker{ticker: time.NewTicker(d)}
+}
+
+type systemTicker struct {
+	ticker *time.Ticker
+}
+
+func (st systemTicker) C() <-chan time.Time {
+	return st.ticker.C
+}
+
+func (st systemTicker) Stop() {
+	st.ticker.Stop()
+}
@@ -0,0 +1,93 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// FakeClock only moves when Advance is called. Its tickers fire during
+// Advance, dropping ticks nobody received like time.Ticker does.
+type FakeClock struct {
+	mutex   sync.Mutex
+	now     time.Time
+	tickers []*fakeTicker
+}
+
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+func (fc *FakeClock) Now() time.Time {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	return fc.now
+}
+
+func (fc *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for FakeClock.NewTicker")
+	}
+
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	ticker := &fakeTicker{
+		clock:  fc,
+		period: d,
+		next:   fc.now.Add(d),
+		c:      make(chan time.Time, 1),
+	}
+	fc.tickers = append(fc.tickers, ticker)
+
+	return ticker
+}
+
+// Advance moves the clock forward by d and fires the tickers that are due.
+func (fc *FakeClock) Advance(d time.Duration) {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	fc.now = fc.now.Add(d)
+	for _, ticker := range fc.tickers {
+		for !ticker.next.After(fc.now) {
+			select {
+			case ticker.c <- ticker.next:
+			default:
+			}
+			ticker.next = ticker.next.Add(ticker.period)
+		}
+	}
+}
+
+// Tickers returns how many tickers are running, so a test can wait for a
+// goroutine to create its ticker before advancing the clock.
+func (fc *FakeClock) Tickers() int {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	return len(fc.tickers)
+}
+
+type fakeTicker struct {
+	clock  *FakeClock
+	period time.Duration
+	next   time.Time
+	c      chan time.Time
+}
+
+func (ft *fakeTicker) C() <-chan time.Time {
+	return ft.c
+}
+
+func (ft *fakeTicker) Stop() {
+	ft.clock.mutex.Lock()
+	defer ft.clock.mutex.Unlock()
+
+	for i, ticker := range ft.clock.tickers {
+		if ticker == ft {
+			ft.clock.tickers = append(ft.clock.tickers[:i], ft.clock.tickers[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package clock_test
+
+import (
+	"fullcycle-auction_go/internal/clock"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var start = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func TestFakeClockOnlyMovesOnAdvance(t *testing.T) {
+	fakeClock := clock.NewFakeClock(start)
+	assert.Equal(t, start, fakeClock.Now())
+
+	fakeClock.Advance(90 * time.Second)
+	assert.Equal(t, start.Add(90*time.Second), fakeClock.Now())
+}
+
+func TestFakeTickerFiresWhenDueAndDropsMissedTicks(t *testing.T) {
+	fakeClock := clock.NewFakeClock(start)
+	ticker := fakeClock.NewTicker(10 * time.Second)
+	assert.Equal(t, 1, fakeClock.Tickers())
+
+	fakeClock.Advance(9 * time.Second)
+	assert.Empty(t, ticker.C())
+
+	fakeClock.Advance(time.Second)
+	assert.Equal(t, start.Add(10*time.Second), <-ticker.C())
+
+	// Three periods pass but only one tick fits in the channel.
+	fakeClock.Advance(30 * time.Second)
+	assert.Equal(t, start.Add(20*time.Second), <-ticker.C())
+	assert.Empty(t, ticker.C())
+
+	ticker.Stop()
+	assert.Zero(t, fakeClock.Tickers())
+	fakeClock.Advance(time.Minute)
+	assert.Empty(t, ticker.C())
+}
@@ -0,0 +1,105 @@
+package api_key_entity
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/internal_error"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const keyPrefix = "ak_"
+
+type ApiKey struct {
+	Id     string
+	UserId string
+	Name   string
+	// Prefix is the first characters of the key, kept so owners can tell
+	// keys apart without the secret being stored.
+	Prefix    string
+	Hash      string
+	Scopes    []auth.Scope
+	Roles     []auth.Role
+	Timestamp time.Time
+	RevokedAt *time.Time
+}
+
+// CreateApiKey returns the key entity together with the plaintext key, which
+// is never persisted and must be shown to the caller only once.
+func CreateApiKey(
+	userId, name string,
+	scopes []auth.Scope,
+	roles []auth.Role) (*ApiKey, string, *internal_error.InternalError) {
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", internal_error.NewInternalServerError("Error trying to generate api key")
+	}
+	plaintext := keyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	apiKey := &ApiKey{
+		Id:        uuid.New().String(),
+		UserId:    userId,
+		Name:      strings.TrimSpace(name),
+		Prefix:    plaintext[:len(keyPrefix)+6],
+		Hash:      HashKey(plaintext),
+		Scopes:    scopes,
+		Roles:     roles,
+		Timestamp: time.Now(),
+	}
+
+	if err := apiKey.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	return apiKey, plaintext, nil
+}
+
+func (ak *ApiKey) Validate() *internal_error.InternalError {
+	if ak.Name == "" {
+		return internal_error.NewBadRequestError("Name is required")
+	}
+
+	if len(ak.Scopes) == 0 {
+		return internal_error.NewBadRequestError("At least one scope is required")
+	}
+
+	for _, scope := range ak.Scopes {
+		if !auth.IsValidScope(scope) {
+			return internal_error.NewBadRequestError("Invalid scope " + string(scope))
+		}
+	}
+
+	return nil
+}
+
+func (ak *ApiKey) IsRevoked() bool {
+	return ak.RevokedAt != nil
+}
+
+// HashKey uses a plain SHA-256: keys carry 256 bits of randomness, so a slow
+// password hash adds nothing and would make every request expensive.
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+type ApiKeyRepositoryInterface interface {
+	CreateApiKey(
+		ctx context.Context, apiKeyEntity *ApiKey) *internal_error.InternalError
+
+	FindApiKeyById(
+		ctx context.Context, id string) (*ApiKey, *internal_error.InternalError)
+
+	FindApiKeyByHash(
+		ctx context.Context, hash string) (*ApiKey, *internal_error.InternalError)
+
+	RevokeApiKey(
+		ctx context.Context, id string, revokedAt time.Time) *internal_error.InternalError
+}
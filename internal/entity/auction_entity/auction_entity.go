@@ -8,9 +8,11 @@ import (
 	"github.com/google/uuid"
 )
 
+// CreateAuction builds an active auction created at now.
 func CreateAuction(
 	productName, category, description string,
-	condition ProductCondition) (*Auction, *internal_error.InternalError) {
+	condition ProductCondition,
+	now time.Time) (*Auction, *internal_error.InternalError) {
 	auction := &Auction{
 		Id:          uuid.New().String(),
 		ProductName: productName,
@@ -18,7 +20,7 @@ func CreateAuction(
 		Description: description,
 		Condition:   condition,
 		Status:      Active,
-		Timestamp:   time.Now(),
+		Timestamp:   now,
 	}
 
 	if err := auction.Validate(); err != nil {
@@ -48,14 +50,19 @@ func (au *Auction) Validate() *internal_error.InternalError {
 	return nil
 }
 
+// Auction.Category holds the category id, or the free-form category of
+// auctions created before the category tree. CategoryName is the name of the
+// category, which search matches in place of the id.
 type Auction struct {
-	Id          string
-	ProductName string
-	Category    string
-	Description string
-	Condition   ProductCondition
-	Status      AuctionStatus
-	Timestamp   time.Time
+	Id           string
+	ProductName  string
+	Category     string
+	CategoryName string
+	Description  string
+	Condition    ProductCondition
+	Status       AuctionStatus
+	Timestamp    time.Time
+	EndTime      time.Time
 }
 
 type ProductCondition int
@@ -77,11 +84,22 @@ type AuctionRepositoryInterface interface {
 		ctx context.Context,
 		auctionEntity *Auction) *internal_error.InternalError
 
+	// FindAuctions returns the auctions in any of categories, or in every
+	// category when it is empty.
 	FindAuctions(
 		ctx context.Context,
 		status AuctionStatus,
-		category, productName string) ([]Auction, *internal_error.InternalError)
+		categories []string,
+		productName string) ([]Auction, *internal_error.InternalError)
 
 	FindAuctionById(
 		ctx context.Context, id string) (*Auction, *internal_error.InternalError)
+
+	SearchAuctions(
+		ctx context.Context, query SearchQuery) (*SearchResult, *internal_error.InternalError)
+
+	// UpdateCategoryName stores name as the CategoryName of every auction
+	// in the category categoryId, so search finds them by the new name.
+	UpdateCategoryName(
+		ctx context.Context, categoryId, name string) *internal_error.InternalError
 }
@@ -0,0 +1,75 @@
+package auction_entity
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+)
+
+// Search relevance weights: a term in the product name counts ten times as
+// much as one in the description.
+const (
+	ProductNameWeight = 10
+	CategoryWeight    = 5
+	DescriptionWeight = 1
+)
+
+// SearchQuery matches auctions whose product name, category name or
+// description contains any of the words of Text. Status, Category (an id)
+// and Condition narrow the results; nil and empty values do not filter.
+type SearchQuery struct {
+	Text      string
+	Status    *AuctionStatus
+	Category  string
+	Condition ProductCondition
+	Limit     int
+}
+
+type SearchHit struct {
+	Auction Auction
+	Score   float64
+}
+
+type CategoryCount struct {
+	Category string
+	Count    int
+}
+
+type ConditionCount struct {
+	Condition ProductCondition
+	Count     int
+}
+
+// SearchResult holds the best Limit hits, highest score first, and the
+// number of matches. Categories are counted by Auction.Category. Each facet counts the matches without its own filter,
+// so Categories lists every category the text matches in even when the
+// query picks one.
+type SearchResult struct {
+	Hits       []SearchHit
+	Total      int
+	Categories []CategoryCount
+	Conditions []ConditionCount
+}
+
+// SearchTerms splits text into lowercase words without accents, the form
+// auctions are matched in. Anything that is not a letter or a digit
+// separates words, so the terms carry no query syntax.
+func SearchTerms(text string) []string {
+	folded, _, err := transform.String(
+		transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), text)
+	if err != nil {
+		folded = text
+	}
+
+	return strings.FieldsFunc(strings.ToLower(folded), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
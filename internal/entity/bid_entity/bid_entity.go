@@ -15,13 +15,14 @@ type Bid struct {
 	Timestamp time.Time
 }
 
-func CreateBid(userId, auctionId string, amount float64) (*Bid, *internal_error.InternalError) {
+// CreateBid builds a bid placed at now.
+func CreateBid(userId, auctionId string, amount float64, now time.Time) (*Bid, *internal_error.InternalError) {
 	bid := &Bid{
 		Id:        uuid.New().String(),
 		UserId:    userId,
 		AuctionId: auctionId,
 		Amount:    amount,
-		Timestamp: time.Now(),
+		Timestamp: now,
 	}
 
 	if err := bid.Validate(); err != nil {
@@ -43,14 +44,27 @@ func (b *Bid) Validate() *internal_error.InternalError {
 	return nil
 }
 
+// BidFailure is a bid of a batch that was not placed, and why.
+type BidFailure struct {
+	Bid Bid
+	Err *internal_error.InternalError
+}
+
 type BidEntityRepository interface {
+	// CreateBid places the bids in the order given and returns the ones that
+	// were not placed; the others are persisted.
 	CreateBid(
 		ctx context.Context,
-		bidEntities []Bid) *internal_error.InternalError
+		bidEntities []Bid) []BidFailure
 
 	FindBidByAuctionId(
 		ctx context.Context, auctionId string) ([]Bid, *internal_error.InternalError)
 
+	FindBidsByAuctionIds(
+		ctx context.Context, auctionIds []string) ([]Bid, *internal_error.InternalError)
+
+	// FindWinningBidByAuctionId returns the highest bid on the auction, or
+	// nil when it has no bids.
 	FindWinningBidByAuctionId(
 		ctx context.Context, auctionId string) (*Bid, *internal_error.InternalError)
 }
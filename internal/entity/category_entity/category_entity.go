@@ -0,0 +1,139 @@
+package category_entity
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Category is a node of the category tree. Root categories have no
+// ParentId.
+type Category struct {
+	Id        string
+	Name      string
+	Slug      string
+	ParentId  string
+	Timestamp time.Time
+}
+
+// CreateCategory builds a category created at now. An empty slug is derived
+// from the name.
+func CreateCategory(name, slug, parentId string, now time.Time) (*Category, *internal_error.InternalError) {
+	category := &Category{
+		Id:        uuid.New().String(),
+		Timestamp: now,
+	}
+
+	if err := category.Change(name, slug, parentId); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// Change sets the editable fields and validates them. An empty slug is
+// derived from the name.
+func (c *Category) Change(name, slug, parentId string) *internal_error.InternalError {
+	c.Name = strings.TrimSpace(name)
+	c.Slug = slug
+	if c.Slug == "" {
+		c.Slug = Slugify(c.Name)
+	}
+	c.ParentId = parentId
+
+	return c.Validate()
+}
+
+func (c *Category) Validate() *internal_error.InternalError {
+	if len(c.Name) <= 1 {
+		return internal_error.NewBadRequestError("Name too short")
+	}
+
+	if len(c.Name) > 100 {
+		return internal_error.NewBadRequestError("Name too long")
+	}
+
+	if !slugPattern.MatchString(c.Slug) || len(c.Slug) > 100 {
+		return internal_error.NewBadRequestError(
+			"Slug must be lowercase letters and digits separated by single hyphens")
+	}
+
+	if c.ParentId != "" {
+		if _, err := uuid.Parse(c.ParentId); err != nil {
+			return internal_error.NewBadRequestError("Invalid parent category ID format")
+		}
+		if c.ParentId == c.Id {
+			return internal_error.NewBadRequestError("A category cannot be its own parent")
+		}
+	}
+
+	return nil
+}
+
+// Slugify turns a name into a slug: "Celulares & Acessórios" becomes
+// "celulares-acessorios".
+func Slugify(name string) string {
+	return strings.Join(auction_entity.SearchTerms(name), "-")
+}
+
+// SubtreeIds returns id followed by the ids of all its descendants among
+// categories, parents before children.
+func SubtreeIds(categories []Category, id string) []string {
+	children := make(map[string][]string)
+	for _, category := range categories {
+		if category.ParentId != "" {
+			children[category.ParentId] = append(children[category.ParentId], category.Id)
+		}
+	}
+
+	ids := []string{id}
+	for i := 0; i < len(ids); i++ {
+		ids = append(ids, children[ids[i]]...)
+	}
+
+	return ids
+}
+
+// FindCategory looks value up as a category id and then as a slug.
+func FindCategory(
+	ctx context.Context,
+	repository CategoryRepositoryInterface,
+	value string) (*Category, *internal_error.InternalError) {
+
+	if _, err := uuid.Parse(value); err == nil {
+		category, err := repository.FindCategoryById(ctx, value)
+		if err == nil || err.Err != "not_found" {
+			return category, err
+		}
+	}
+
+	return repository.FindCategoryBySlug(ctx, value)
+}
+
+type CategoryRepositoryInterface interface {
+	CreateCategory(
+		ctx context.Context, category *Category) *internal_error.InternalError
+
+	UpdateCategory(
+		ctx context.Context, category *Category) *internal_error.InternalError
+
+	DeleteCategory(
+		ctx context.Context, id string) *internal_error.InternalError
+
+	FindCategoryById(
+		ctx context.Context, id string) (*Category, *internal_error.InternalError)
+
+	FindCategoryBySlug(
+		ctx context.Context, slug string) (*Category, *internal_error.InternalError)
+
+	// FindCategories returns every category in creation order.
+	FindCategories(
+		ctx context.Context) ([]Category, *internal_error.InternalError)
+}
@@ -0,0 +1,55 @@
+package event_entity
+
+import "time"
+
+type EventType string
+
+const (
+	AuctionCreated EventType = "auction.created"
+	AuctionClosed  EventType = "auction.closed"
+	BidCreated     EventType = "bid.created"
+)
+
+var EventTypes = []EventType{AuctionCreated, AuctionClosed, BidCreated}
+
+type Event struct {
+	Id        uint64      `json:"id"`
+	Type      EventType   `json:"type"`
+	AuctionId string      `json:"auction_id"`
+	Data      interface{} `json:"data"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
+type AuctionData struct {
+	Id          string    `json:"id"`
+	ProductName string    `json:"product_name"`
+	Category    string    `json:"category"`
+	Status      int       `json:"status"`
+	EndTime     time.Time `json:"end_time"`
+}
+
+type BidData struct {
+	Id        string    `json:"id"`
+	UserId    string    `json:"user_id"`
+	AuctionId string    `json:"auction_id"`
+	Amount    float64   `json:"amount"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func IsValidEventType(eventType EventType) bool {
+	for _, value := range EventTypes {
+		if value == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+type EventDispatcherInterface interface {
+	Publish(event Event)
+
+	Subscribe(bufferSize int) (<-chan Event, func())
+
+	EventsSince(auctionId string, lastEventId uint64) []Event
+}
@@ -13,4 +13,7 @@ type User struct {
 type UserRepositoryInterface interface {
 	FindUserById(
 		ctx context.Context, userId string) (*User, *internal_error.InternalError)
+
+	FindUsersByIds(
+		ctx context.Context, userIds []string) ([]User, *internal_error.InternalError)
 }
@@ -0,0 +1,173 @@
+package webhook_entity
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Webhook struct {
+	Id string
+	// UserId is the user that registered the webhook. Webhooks registered
+	// before owners were recorded have none and only admins manage them.
+	UserId    string
+	Url       string
+	Events    []event_entity.EventType
+	Secret    string
+	Timestamp time.Time
+}
+
+type Delivery struct {
+	Id         string
+	WebhookId  string
+	EventId    uint64
+	EventType  event_entity.EventType
+	Attempt    int
+	StatusCode int
+	Success    bool
+	Error      string
+	Timestamp  time.Time
+}
+
+func CreateWebhook(
+	userId string,
+	webhookUrl string,
+	events []event_entity.EventType,
+	secret string) (*Webhook, *internal_error.InternalError) {
+
+	if secret == "" {
+		generatedSecret, err := generateSecret()
+		if err != nil {
+			return nil, internal_error.NewInternalServerError("Error trying to generate webhook secret")
+		}
+		secret = generatedSecret
+	}
+
+	webhook := &Webhook{
+		Id:        uuid.New().String(),
+		UserId:    userId,
+		Url:       webhookUrl,
+		Events:    events,
+		Secret:    secret,
+		Timestamp: time.Now(),
+	}
+
+	if err := webhook.Validate(); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (w *Webhook) Validate() *internal_error.InternalError {
+	parsedUrl, err := url.ParseRequestURI(w.Url)
+	if err != nil || (parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https") || parsedUrl.Host == "" {
+		return internal_error.NewBadRequestError("Url is not a valid http(s) url")
+	}
+
+	// Host names are checked again against the addresses they resolve to
+	// when a delivery connects.
+	host := strings.TrimSuffix(strings.ToLower(parsedUrl.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return internal_error.NewBadRequestError("Url must not point to a private or loopback address")
+	}
+	if ip := net.ParseIP(host); ip != nil && !IsPublicIP(ip) {
+		return internal_error.NewBadRequestError("Url must not point to a private or loopback address")
+	}
+
+	if len(w.Events) == 0 {
+		return internal_error.NewBadRequestError("At least one event type is required")
+	}
+
+	for _, eventType := range w.Events {
+		if !event_entity.IsValidEventType(eventType) {
+			return internal_error.NewBadRequestError("Invalid event type " + string(eventType))
+		}
+	}
+
+	if len(w.Secret) < 16 {
+		return internal_error.NewBadRequestError("Secret too short")
+	}
+
+	return nil
+}
+
+// nonPublicNetworks are the ranges net.IP has no predicate for: "this
+// network" and carrier-grade NAT.
+var nonPublicNetworks = []*net.IPNet{
+	mustParseCIDR("0.0.0.0/8"),
+	mustParseCIDR("100.64.0.0/10"),
+}
+
+// IsPublicIP reports whether webhooks may be delivered to ip. Loopback,
+// private, link-local (which includes cloud metadata endpoints such as
+// 169.254.169.254), multicast and unspecified addresses are refused.
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func mustParseCIDR(value string) *net.IPNet {
+	_, network, err := net.ParseCIDR(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return network
+}
+
+func (w *Webhook) Subscribes(eventType event_entity.EventType) bool {
+	for _, value := range w.Events {
+		if value == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
+
+type WebhookRepositoryInterface interface {
+	CreateWebhook(
+		ctx context.Context, webhookEntity *Webhook) *internal_error.InternalError
+
+	FindWebhookById(
+		ctx context.Context, id string) (*Webhook, *internal_error.InternalError)
+
+	FindWebhooksByEventType(
+		ctx context.Context,
+		eventType event_entity.EventType) ([]Webhook, *internal_error.InternalError)
+
+	CreateDelivery(
+		ctx context.Context, deliveryEntity *Delivery) *internal_error.InternalError
+
+	FindDeliveriesByWebhookId(
+		ctx context.Context, webhookId string) ([]Delivery, *internal_error.InternalError)
+}
@@ -0,0 +1,90 @@
+package graphql_server
+
+import (
+	"context"
+	_ "embed"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"fullcycle-auction_go/internal/usecase/user_usecase"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schemaString string
+
+type GraphQLServer struct {
+	schema      *graphql.Schema
+	bidUseCase  bid_usecase.BidUseCaseInterface
+	userUseCase user_usecase.UserUseCaseInterface
+}
+
+type GraphQLRequest struct {
+	Query         string                 `json:"query" binding:"required"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+func NewGraphQLServer(
+	auctionFindUseCase auction_usecase.AuctionFindUseCaseInterface,
+	bidUseCase bid_usecase.BidUseCaseInterface,
+	userUseCase user_usecase.UserUseCaseInterface,
+	eventDispatcher event_entity.EventDispatcherInterface) *GraphQLServer {
+
+	schema := graphql.MustParseSchema(schemaString, &rootResolver{
+		auctionFindUseCase: auctionFindUseCase,
+		userUseCase:        userUseCase,
+		eventDispatcher:    eventDispatcher,
+	})
+
+	return &GraphQLServer{
+		schema:      schema,
+		bidUseCase:  bidUseCase,
+		userUseCase: userUseCase,
+	}
+}
+
+func (gs *GraphQLServer) Handle(c *gin.Context) {
+	var request GraphQLRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		restErr := validation.ValidateErr(err)
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	ctx := context.WithValue(c.Request.Context(), loadersKey{}, newLoaders(gs.bidUseCase, gs.userUseCase))
+
+	if !strings.Contains(c.GetHeader("Accept"), "text/event-stream") {
+		c.JSON(http.StatusOK, gs.schema.Exec(ctx, request.Query, request.OperationName, request.Variables))
+		return
+	}
+
+	responses, err := gs.schema.Subscribe(ctx, request.Query, request.OperationName, request.Variables)
+	if err != nil {
+		restErr := rest_err.NewBadRequestError(err.Error())
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Stream(func(w io.Writer) bool {
+		response, ok := <-responses
+		if !ok {
+			c.Render(-1, sse.Event{Event: "complete", Data: ""})
+			return false
+		}
+
+		c.Render(-1, sse.Event{Event: "next", Data: response})
+		return true
+	})
+}
@@ -0,0 +1,183 @@
+package graphql_server_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/infra/api/graphql/graphql_server"
+	"fullcycle-auction_go/internal/infra/event"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"fullcycle-auction_go/internal/usecase/user_usecase"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	activeAuctionId    = "0b7f2a52-7e4e-4b3e-9a55-1f2d3c4b5a61"
+	completedAuctionId = "5d1c8e7a-3b2f-4e6d-8c9a-0f1e2d3c4b5a"
+	userA              = "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"
+	userB              = "1f2e3d4c-5b6a-4978-8a6b-5c4d3e2f1a0b"
+)
+
+type auctionFindUseCaseStub struct{}
+
+func (auctionFindUseCaseStub) FindAuctionById(
+	ctx context.Context, id string) (*auction_usecase.AuctionOutputDTO, *internal_error.InternalError) {
+	return &auction_usecase.AuctionOutputDTO{Id: id, ProductName: "Product", Status: auction_entity.Active}, nil
+}
+
+func (auctionFindUseCaseStub) FindAuctions(
+	ctx context.Context,
+	status auction_entity.AuctionStatus,
+	category, productName string,
+	includeSubcategories bool) ([]auction_usecase.AuctionOutputDTO, *internal_error.InternalError) {
+	return []auction_usecase.AuctionOutputDTO{
+		{Id: activeAuctionId, ProductName: "Active", Status: auction_entity.Active},
+		{Id: completedAuctionId, ProductName: "Completed", Status: auction_entity.Completed},
+	}, nil
+}
+
+func (auctionFindUseCaseStub) SearchAuctions(
+	ctx context.Context,
+	query auction_entity.SearchQuery) (*auction_usecase.SearchOutputDTO, *internal_error.InternalError) {
+	return &auction_usecase.SearchOutputDTO{}, nil
+}
+
+func (auctionFindUseCaseStub) FindWinningBidByAuctionId(
+	ctx context.Context, auctionId string) (*auction_usecase.WinningInfoOutputDTO, *internal_error.InternalError) {
+	return nil, nil
+}
+
+type bidUseCaseStub struct {
+	bid_usecase.BidUseCaseInterface
+	calls int32
+}
+
+func (bs *bidUseCaseStub) FindBidsByAuctionIds(
+	ctx context.Context, auctionIds []string) ([]bid_usecase.BidOutputDTO, *internal_error.InternalError) {
+	atomic.AddInt32(&bs.calls, 1)
+	return []bid_usecase.BidOutputDTO{
+		{Id: "b3", AuctionId: completedAuctionId, UserId: userB, Amount: 30},
+		{Id: "b2", AuctionId: activeAuctionId, UserId: userB, Amount: 20},
+		{Id: "b1", AuctionId: activeAuctionId, UserId: userA, Amount: 10},
+	}, nil
+}
+
+type userUseCaseStub struct {
+	calls int32
+}
+
+func (us *userUseCaseStub) FindUserById(
+	ctx context.Context, id string) (*user_usecase.UserOutputDTO, *internal_error.InternalError) {
+	return nil, internal_error.NewInternalServerError("not batched")
+}
+
+func (us *userUseCaseStub) FindUsersByIds(
+	ctx context.Context, ids []string) ([]user_usecase.UserOutputDTO, *internal_error.InternalError) {
+	atomic.AddInt32(&us.calls, 1)
+	users := []user_usecase.UserOutputDTO{}
+	for _, id := range ids {
+		users = append(users, user_usecase.UserOutputDTO{Id: id, Name: "name-" + id[:4]})
+	}
+	return users, nil
+}
+
+func newServer(dispatcher *event.EventDispatcher) (*httptest.Server, *bidUseCaseStub, *userUseCaseStub) {
+	gin.SetMode(gin.TestMode)
+
+	bidUseCase := &bidUseCaseStub{}
+	userUseCase := &userUseCaseStub{}
+	router := gin.New()
+	router.POST("/graphql", graphql_server.NewGraphQLServer(
+		auctionFindUseCaseStub{}, bidUseCase, userUseCase, dispatcher).Handle)
+
+	return httptest.NewServer(router), bidUseCase, userUseCase
+}
+
+func TestGraphQLBatchesBidAndUserLookups(t *testing.T) {
+	server, bidUseCase, userUseCase := newServer(event.NewEventDispatcher())
+	defer server.Close()
+
+	query := `{"query": "{ auctions { id status bids(top: 1) { amount user { name } } winner { amount user { id } } } }"}`
+	response, err := http.Post(server.URL+"/graphql", "application/json", strings.NewReader(query))
+	assert.NoError(t, err)
+	defer response.Body.Close()
+
+	var body struct {
+		Data struct {
+			Auctions []struct {
+				Id     string
+				Status string
+				Bids   []struct {
+					Amount float64
+					User   struct{ Name string }
+				}
+				Winner *struct {
+					Amount float64
+					User   struct{ Id string }
+				}
+			}
+		}
+		Errors []interface{}
+	}
+	assert.NoError(t, json.NewDecoder(response.Body).Decode(&body))
+	assert.Empty(t, body.Errors)
+
+	assert.Len(t, body.Data.Auctions, 2)
+	assert.Equal(t, []float64{20}, []float64{body.Data.Auctions[0].Bids[0].Amount})
+	assert.Nil(t, body.Data.Auctions[0].Winner)
+	assert.Equal(t, "COMPLETED", body.Data.Auctions[1].Status)
+	assert.Equal(t, 30.0, body.Data.Auctions[1].Winner.Amount)
+	assert.Equal(t, userB, body.Data.Auctions[1].Winner.User.Id)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&bidUseCase.calls))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&userUseCase.calls))
+}
+
+func TestGraphQLSubscriptionStreamsNewBids(t *testing.T) {
+	dispatcher := event.NewEventDispatcher()
+	server, _, _ := newServer(dispatcher)
+	defer server.Close()
+
+	query := `{"query": "subscription { bidCreated(auctionId: \"` + activeAuctionId + `\") { amount } }"}`
+	request, _ := http.NewRequest(http.MethodPost, server.URL+"/graphql", strings.NewReader(query))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "text/event-stream")
+
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		dispatcher.Publish(event_entity.Event{
+			Type: event_entity.BidCreated, AuctionId: activeAuctionId, Data: event_entity.BidData{Amount: 55}})
+		dispatcher.Publish(event_entity.Event{Type: event_entity.AuctionClosed, AuctionId: activeAuctionId})
+	}()
+
+	response, err := http.DefaultClient.Do(request)
+	assert.NoError(t, err)
+	defer response.Body.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(response.Body)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	assert.Equal(t, []string{
+		"event:next",
+		`data:{"data":{"bidCreated":{"amount":55}}}`,
+		"event:complete",
+		"data:",
+	}, lines)
+}
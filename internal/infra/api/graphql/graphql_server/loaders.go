@@ -0,0 +1,125 @@
+package graphql_server
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"fullcycle-auction_go/internal/usecase/user_usecase"
+	"sync"
+)
+
+type loadersKey struct{}
+
+// batchLoader collects every key known to the current request and fetches
+// them in a single call the first time any of them is loaded, so resolving
+// a list of N auctions costs one bid query and one user query instead of N.
+type batchLoader[K comparable, V any] struct {
+	mutex   sync.Mutex
+	fetch   func(ctx context.Context, keys []K) (map[K]V, *internal_error.InternalError)
+	pending []K
+	results map[K]V
+	fetched map[K]bool
+}
+
+func newBatchLoader[K comparable, V any](
+	fetch func(ctx context.Context, keys []K) (map[K]V, *internal_error.InternalError)) *batchLoader[K, V] {
+
+	return &batchLoader[K, V]{
+		fetch:   fetch,
+		results: make(map[K]V),
+		fetched: make(map[K]bool),
+	}
+}
+
+func (bl *batchLoader[K, V]) Prime(keys ...K) {
+	bl.mutex.Lock()
+	defer bl.mutex.Unlock()
+
+	for _, key := range keys {
+		if !bl.fetched[key] {
+			bl.pending = append(bl.pending, key)
+		}
+	}
+}
+
+func (bl *batchLoader[K, V]) Load(ctx context.Context, key K) (V, *internal_error.InternalError) {
+	bl.mutex.Lock()
+	defer bl.mutex.Unlock()
+
+	if !bl.fetched[key] {
+		keys := append(bl.pending, key)
+		bl.pending = nil
+
+		var unique []K
+		for _, value := range keys {
+			if !bl.fetched[value] {
+				bl.fetched[value] = true
+				unique = append(unique, value)
+			}
+		}
+
+		results, err := bl.fetch(ctx, unique)
+		if err != nil {
+			for _, value := range unique {
+				delete(bl.fetched, value)
+			}
+			var zero V
+			return zero, err
+		}
+
+		for value, result := range results {
+			bl.results[value] = result
+		}
+	}
+
+	return bl.results[key], nil
+}
+
+type loaders struct {
+	bidsByAuction *batchLoader[string, []bid_usecase.BidOutputDTO]
+	users         *batchLoader[string, *user_usecase.UserOutputDTO]
+}
+
+func newLoaders(
+	bidUseCase bid_usecase.BidUseCaseInterface,
+	userUseCase user_usecase.UserUseCaseInterface) *loaders {
+
+	return &loaders{
+		bidsByAuction: newBatchLoader(func(
+			ctx context.Context,
+			auctionIds []string) (map[string][]bid_usecase.BidOutputDTO, *internal_error.InternalError) {
+
+			bids, err := bidUseCase.FindBidsByAuctionIds(ctx, auctionIds)
+			if err != nil {
+				return nil, err
+			}
+
+			result := make(map[string][]bid_usecase.BidOutputDTO, len(auctionIds))
+			for _, bid := range bids {
+				result[bid.AuctionId] = append(result[bid.AuctionId], bid)
+			}
+
+			return result, nil
+		}),
+		users: newBatchLoader(func(
+			ctx context.Context,
+			userIds []string) (map[string]*user_usecase.UserOutputDTO, *internal_error.InternalError) {
+
+			users, err := userUseCase.FindUsersByIds(ctx, userIds)
+			if err != nil {
+				return nil, err
+			}
+
+			result := make(map[string]*user_usecase.UserOutputDTO, len(users))
+			for i := range users {
+				result[users[i].Id] = &users[i]
+			}
+
+			return result, nil
+		}),
+	}
+}
+
+func loadersFromContext(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
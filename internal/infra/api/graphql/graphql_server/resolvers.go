@@ -0,0 +1,274 @@
+package graphql_server
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"fullcycle-auction_go/internal/usecase/user_usecase"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/graph-gophers/graphql-go"
+)
+
+type rootResolver struct {
+	auctionFindUseCase auction_usecase.AuctionFindUseCaseInterface
+	userUseCase        user_usecase.UserUseCaseInterface
+	eventDispatcher    event_entity.EventDispatcherInterface
+}
+
+func (r *rootResolver) Auction(ctx context.Context, args struct{ Id graphql.ID }) (*auctionResolver, error) {
+	if err := uuid.Validate(string(args.Id)); err != nil {
+		return nil, err
+	}
+
+	auction, err := r.auctionFindUseCase.FindAuctionById(ctx, string(args.Id))
+	if err != nil {
+		if err.Err == "not_found" {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	loadersFromContext(ctx).bidsByAuction.Prime(auction.Id)
+
+	return &auctionResolver{auction: *auction}, nil
+}
+
+func (r *rootResolver) Auctions(ctx context.Context, args struct {
+	Status               *string
+	Category             *string
+	ProductName          *string
+	IncludeSubcategories *bool
+}) ([]*auctionResolver, error) {
+	status := auction_entity.Active
+	if args.Status != nil && *args.Status == "COMPLETED" {
+		status = auction_entity.Completed
+	}
+
+	auctions, err := r.auctionFindUseCase.FindAuctions(
+		ctx, status, stringValue(args.Category), stringValue(args.ProductName),
+		args.IncludeSubcategories != nil && *args.IncludeSubcategories)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*auctionResolver, 0, len(auctions))
+	auctionIds := make([]string, 0, len(auctions))
+	for _, auction := range auctions {
+		resolvers = append(resolvers, &auctionResolver{auction: auction})
+		auctionIds = append(auctionIds, auction.Id)
+	}
+	loadersFromContext(ctx).bidsByAuction.Prime(auctionIds...)
+
+	return resolvers, nil
+}
+
+func (r *rootResolver) User(ctx context.Context, args struct{ Id graphql.ID }) (*userResolver, error) {
+	user, err := loadersFromContext(ctx).users.Load(ctx, string(args.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, nil
+	}
+
+	return &userResolver{user: *user}, nil
+}
+
+func (r *rootResolver) BidCreated(ctx context.Context, args struct{ AuctionId graphql.ID }) (<-chan *bidResolver, error) {
+	if err := uuid.Validate(string(args.AuctionId)); err != nil {
+		return nil, err
+	}
+
+	if _, err := r.auctionFindUseCase.FindAuctionById(ctx, string(args.AuctionId)); err != nil {
+		return nil, err
+	}
+
+	events, unsubscribe := r.eventDispatcher.Subscribe(64)
+	bids := make(chan *bidResolver)
+
+	go func() {
+		defer close(bids)
+		defer unsubscribe()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+
+				if event.AuctionId != string(args.AuctionId) {
+					continue
+				}
+
+				if event.Type == event_entity.AuctionClosed {
+					return
+				}
+
+				bidData, isBid := event.Data.(event_entity.BidData)
+				if !isBid {
+					continue
+				}
+
+				select {
+				case bids <- &bidResolver{bid: bid_usecase.BidOutputDTO{
+					Id:        bidData.Id,
+					UserId:    bidData.UserId,
+					AuctionId: bidData.AuctionId,
+					Amount:    bidData.Amount,
+					Timestamp: bidData.Timestamp,
+				}}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return bids, nil
+}
+
+type auctionResolver struct {
+	auction auction_usecase.AuctionOutputDTO
+}
+
+func (a *auctionResolver) Id() graphql.ID {
+	return graphql.ID(a.auction.Id)
+}
+
+func (a *auctionResolver) ProductName() string {
+	return a.auction.ProductName
+}
+
+func (a *auctionResolver) Category() string {
+	return a.auction.Category
+}
+
+func (a *auctionResolver) Description() string {
+	return a.auction.Description
+}
+
+func (a *auctionResolver) Condition() string {
+	switch a.auction.Condition {
+	case auction_entity.Used:
+		return "USED"
+	case auction_entity.Refurbished:
+		return "REFURBISHED"
+	default:
+		return "NEW"
+	}
+}
+
+func (a *auctionResolver) Status() string {
+	if a.auction.Status == auction_entity.Completed {
+		return "COMPLETED"
+	}
+
+	return "ACTIVE"
+}
+
+func (a *auctionResolver) Timestamp() string {
+	return a.auction.Timestamp.Format(time.RFC3339)
+}
+
+func (a *auctionResolver) Bids(ctx context.Context, args struct{ Top *int32 }) ([]*bidResolver, error) {
+	bids, err := loadersFromContext(ctx).bidsByAuction.Load(ctx, a.auction.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.Top != nil && int(*args.Top) >= 0 && int(*args.Top) < len(bids) {
+		bids = bids[:*args.Top]
+	}
+
+	return newBidResolvers(ctx, bids), nil
+}
+
+func (a *auctionResolver) Winner(ctx context.Context) (*bidResolver, error) {
+	if a.auction.Status != auction_entity.Completed {
+		return nil, nil
+	}
+
+	bids, err := loadersFromContext(ctx).bidsByAuction.Load(ctx, a.auction.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bids) == 0 {
+		return nil, nil
+	}
+
+	return newBidResolvers(ctx, bids[:1])[0], nil
+}
+
+type bidResolver struct {
+	bid bid_usecase.BidOutputDTO
+}
+
+func newBidResolvers(ctx context.Context, bids []bid_usecase.BidOutputDTO) []*bidResolver {
+	resolvers := make([]*bidResolver, 0, len(bids))
+	userIds := make([]string, 0, len(bids))
+	for _, bid := range bids {
+		resolvers = append(resolvers, &bidResolver{bid: bid})
+		userIds = append(userIds, bid.UserId)
+	}
+	loadersFromContext(ctx).users.Prime(userIds...)
+
+	return resolvers
+}
+
+func (b *bidResolver) Id() graphql.ID {
+	return graphql.ID(b.bid.Id)
+}
+
+func (b *bidResolver) AuctionId() graphql.ID {
+	return graphql.ID(b.bid.AuctionId)
+}
+
+func (b *bidResolver) Amount() float64 {
+	return b.bid.Amount
+}
+
+func (b *bidResolver) Timestamp() string {
+	return b.bid.Timestamp.Format(time.RFC3339)
+}
+
+func (b *bidResolver) User(ctx context.Context) (*userResolver, error) {
+	user, err := loadersFromContext(ctx).users.Load(ctx, b.bid.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, nil
+	}
+
+	return &userResolver{user: *user}, nil
+}
+
+type userResolver struct {
+	user user_usecase.UserOutputDTO
+}
+
+func (u *userResolver) Id() graphql.ID {
+	return graphql.ID(u.user.Id)
+}
+
+func (u *userResolver) Name() string {
+	return u.user.Name
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
@@ -0,0 +1,201 @@
+package grpc_server
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/infra/api/grpc/pb"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type AuctionService struct {
+	pb.UnimplementedAuctionServiceServer
+
+	auctionUseCase     auction_usecase.AuctionUseCaseInterface
+	auctionFindUseCase auction_usecase.AuctionFindUseCaseInterface
+	eventDispatcher    event_entity.EventDispatcherInterface
+}
+
+func (as *AuctionService) CreateAuction(
+	ctx context.Context, request *pb.CreateAuctionRequest) (*pb.Auction, error) {
+
+	auction, err := as.auctionUseCase.CreateAuction(ctx, auction_usecase.AuctionInputDTO{
+		ProductName: request.GetProductName(),
+		Category:    request.GetCategory(),
+		Description: request.GetDescription(),
+		Condition:   auction_entity.ProductCondition(request.GetCondition()),
+	})
+	if err != nil {
+		return nil, ConvertError(err)
+	}
+
+	return toAuctionMessage(*auction), nil
+}
+
+func (as *AuctionService) GetAuction(
+	ctx context.Context, request *pb.GetAuctionRequest) (*pb.Auction, error) {
+
+	if err := uuid.Validate(request.GetAuctionId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid auction ID")
+	}
+
+	auction, err := as.auctionFindUseCase.FindAuctionById(ctx, request.GetAuctionId())
+	if err != nil {
+		return nil, ConvertError(err)
+	}
+
+	return toAuctionMessage(*auction), nil
+}
+
+func (as *AuctionService) ListAuctions(
+	ctx context.Context, request *pb.ListAuctionsRequest) (*pb.ListAuctionsResponse, error) {
+
+	auctionStatus := auction_entity.Active
+	if request.GetStatus() != pb.AuctionStatus_AUCTION_STATUS_UNSPECIFIED {
+		auctionStatus = toAuctionStatus(request.GetStatus())
+	}
+
+	auctions, err := as.auctionFindUseCase.FindAuctions(
+		ctx, auctionStatus, request.GetCategory(), request.GetProductName(), false)
+	if err != nil {
+		return nil, ConvertError(err)
+	}
+
+	response := &pb.ListAuctionsResponse{}
+	for _, auction := range auctions {
+		response.Auctions = append(response.Auctions, toAuctionMessage(auction))
+	}
+
+	return response, nil
+}
+
+func (as *AuctionService) GetWinningBid(
+	ctx context.Context, request *pb.GetWinningBidRequest) (*pb.WinningInfo, error) {
+
+	if err := uuid.Validate(request.GetAuctionId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid auction ID")
+	}
+
+	winningInfo, err := as.auctionFindUseCase.FindWinningBidByAuctionId(ctx, request.GetAuctionId())
+	if err != nil {
+		return nil, ConvertError(err)
+	}
+
+	response := &pb.WinningInfo{Auction: toAuctionMessage(winningInfo.Auction)}
+	if winningInfo.Bid != nil {
+		response.Bid = &pb.Bid{
+			Id:        winningInfo.Bid.Id,
+			UserId:    winningInfo.Bid.UserId,
+			AuctionId: winningInfo.Bid.AuctionId,
+			Amount:    winningInfo.Bid.Amount,
+			Timestamp: timestamppb.New(winningInfo.Bid.Timestamp),
+		}
+	}
+
+	return response, nil
+}
+
+func (as *AuctionService) WatchAuction(
+	request *pb.WatchAuctionRequest, stream pb.AuctionService_WatchAuctionServer) error {
+
+	if err := uuid.Validate(request.GetAuctionId()); err != nil {
+		return status.Error(codes.InvalidArgument, "Invalid auction ID")
+	}
+
+	if _, err := as.auctionFindUseCase.FindAuctionById(stream.Context(), request.GetAuctionId()); err != nil {
+		return ConvertError(err)
+	}
+
+	events, unsubscribe := as.eventDispatcher.Subscribe(64)
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return status.Error(codes.Unavailable, "Event stream closed")
+			}
+
+			if event.AuctionId != request.GetAuctionId() {
+				continue
+			}
+
+			if err := stream.Send(toAuctionEventMessage(event)); err != nil {
+				return err
+			}
+
+			if event.Type == event_entity.AuctionClosed {
+				return nil
+			}
+		}
+	}
+}
+
+func toAuctionMessage(auction auction_usecase.AuctionOutputDTO) *pb.Auction {
+	return &pb.Auction{
+		Id:          auction.Id,
+		ProductName: auction.ProductName,
+		Category:    auction.Category,
+		Description: auction.Description,
+		Condition:   pb.ProductCondition(auction.Condition),
+		Status:      toAuctionStatusMessage(auction.Status),
+		Timestamp:   timestamppb.New(auction.Timestamp),
+		EndTime:     timestamppb.New(auction.EndTime),
+	}
+}
+
+func toAuctionEventMessage(event event_entity.Event) *pb.AuctionEvent {
+	message := &pb.AuctionEvent{
+		Id:        event.Id,
+		Type:      string(event.Type),
+		AuctionId: event.AuctionId,
+		Timestamp: timestamppb.New(event.Timestamp),
+	}
+
+	switch data := event.Data.(type) {
+	case event_entity.BidData:
+		message.Payload = &pb.AuctionEvent_Bid{Bid: &pb.Bid{
+			Id:        data.Id,
+			UserId:    data.UserId,
+			AuctionId: data.AuctionId,
+			Amount:    data.Amount,
+			Timestamp: timestamppb.New(data.Timestamp),
+		}}
+	case event_entity.AuctionData:
+		message.Payload = &pb.AuctionEvent_Auction{Auction: &pb.AuctionSummary{
+			Id:          data.Id,
+			ProductName: data.ProductName,
+			Category:    data.Category,
+			Status:      toAuctionStatusMessage(auction_entity.AuctionStatus(data.Status)),
+			EndTime:     timestamppb.New(data.EndTime),
+		}}
+	}
+
+	return message
+}
+
+func toAuctionStatusMessage(auctionStatus auction_entity.AuctionStatus) pb.AuctionStatus {
+	switch auctionStatus {
+	case auction_entity.Active:
+		return pb.AuctionStatus_AUCTION_STATUS_ACTIVE
+	case auction_entity.Completed:
+		return pb.AuctionStatus_AUCTION_STATUS_COMPLETED
+	default:
+		return pb.AuctionStatus_AUCTION_STATUS_UNSPECIFIED
+	}
+}
+
+func toAuctionStatus(auctionStatus pb.AuctionStatus) auction_entity.AuctionStatus {
+	if auctionStatus == pb.AuctionStatus_AUCTION_STATUS_COMPLETED {
+		return auction_entity.Completed
+	}
+
+	return auction_entity.Active
+}
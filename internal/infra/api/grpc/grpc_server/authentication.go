@@ -0,0 +1,62 @@
+package grpc_server
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/auth"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// authenticateContext mirrors the HTTP middleware.Authenticate:
+// anonymous calls pass through and the usecases decide whether they need a
+// caller, while invalid credentials are always rejected.
+func authenticateContext(ctx context.Context, authenticator *auth.Authenticator) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return ctx, nil
+	}
+
+	identity, err := authenticator.Authenticate(ctx, values[0])
+	if err != nil {
+		return nil, ConvertError(err)
+	}
+
+	return auth.WithIdentity(ctx, identity), nil
+}
+
+func unaryAuthInterceptor(authenticator *auth.Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+
+		ctx, err := authenticateContext(ctx, authenticator)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func streamAuthInterceptor(authenticator *auth.Authenticator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, _ *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+
+		ctx, err := authenticateContext(stream.Context(), authenticator)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (as *authenticatedStream) Context() context.Context {
+	return as.ctx
+}
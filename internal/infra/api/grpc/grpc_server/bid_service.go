@@ -0,0 +1,95 @@
+package grpc_server
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/infra/api/grpc/pb"
+	"fullcycle-auction_go/internal/infra/rate_limiter"
+	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"math"
+	"net"
+	"strconv"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type BidService struct {
+	pb.UnimplementedBidServiceServer
+
+	bidUseCase   bid_usecase.BidUseCaseInterface
+	bidRateLimit rate_limiter.RouteLimit
+}
+
+func (bs *BidService) CreateBid(
+	ctx context.Context, request *pb.CreateBidRequest) (*pb.CreateBidResponse, error) {
+
+	if err := bs.checkRateLimit(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := bs.bidUseCase.CreateBid(ctx, bid_usecase.BidInputDTO{
+		UserId:    request.GetUserId(),
+		AuctionId: request.GetAuctionId(),
+		Amount:    request.GetAmount(),
+	}); err != nil {
+		return nil, ConvertError(err)
+	}
+
+	return &pb.CreateBidResponse{}, nil
+}
+
+// checkRateLimit applies the REST bid route's limit to the peer address and
+// the authenticated caller, sending a retry-after header when it refuses.
+func (bs *BidService) checkRateLimit(ctx context.Context) error {
+	var clientIP, userId string
+	if p, ok := peer.FromContext(ctx); ok {
+		clientIP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(clientIP); err == nil {
+			clientIP = host
+		}
+	}
+	if identity, ok := auth.IdentityFromContext(ctx); ok {
+		userId = identity.UserId
+	}
+
+	decision := bs.bidRateLimit.Allow(ctx, clientIP, userId)
+	if decision.Allowed {
+		return nil
+	}
+
+	grpc.SetHeader(ctx, metadata.Pairs(
+		"retry-after", strconv.Itoa(int(math.Ceil(decision.RetryAfter.Seconds())))))
+	return status.Error(codes.ResourceExhausted, "Rate limit exceeded, retry later")
+}
+
+func (bs *BidService) ListBids(
+	ctx context.Context, request *pb.ListBidsRequest) (*pb.ListBidsResponse, error) {
+
+	if err := uuid.Validate(request.GetAuctionId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid auction ID")
+	}
+
+	bids, err := bs.bidUseCase.FindBidByAuctionId(ctx, request.GetAuctionId())
+	if err != nil {
+		return nil, ConvertError(err)
+	}
+
+	response := &pb.ListBidsResponse{}
+	for _, bid := range bids {
+		response.Bids = append(response.Bids, &pb.Bid{
+			Id:        bid.Id,
+			UserId:    bid.UserId,
+			AuctionId: bid.AuctionId,
+			Amount:    bid.Amount,
+			Timestamp: timestamppb.New(bid.Timestamp),
+		})
+	}
+
+	return response, nil
+}
@@ -0,0 +1,60 @@
+package grpc_server
+
+import (
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/infra/api/grpc/pb"
+	"fullcycle-auction_go/internal/infra/rate_limiter"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"fullcycle-auction_go/internal/usecase/user_usecase"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func NewGRPCServer(
+	auctionUseCase auction_usecase.AuctionUseCaseInterface,
+	auctionFindUseCase auction_usecase.AuctionFindUseCaseInterface,
+	bidUseCase bid_usecase.BidUseCaseInterface,
+	userUseCase user_usecase.UserUseCaseInterface,
+	eventDispatcher event_entity.EventDispatcherInterface,
+	authenticator *auth.Authenticator,
+	bidRateLimit rate_limiter.RouteLimit) *grpc.Server {
+
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(unaryAuthInterceptor(authenticator)),
+		grpc.StreamInterceptor(streamAuthInterceptor(authenticator)),
+	)
+
+	pb.RegisterAuctionServiceServer(server, &AuctionService{
+		auctionUseCase:     auctionUseCase,
+		auctionFindUseCase: auctionFindUseCase,
+		eventDispatcher:    eventDispatcher,
+	})
+	pb.RegisterBidServiceServer(server, &BidService{bidUseCase: bidUseCase, bidRateLimit: bidRateLimit})
+	pb.RegisterUserServiceServer(server, &UserService{userUseCase: userUseCase})
+
+	return server
+}
+
+func ConvertError(internalError *internal_error.InternalError) error {
+	switch internalError.Err {
+	case "bad_request":
+		return status.Error(codes.InvalidArgument, internalError.Error())
+	case "not_found":
+		return status.Error(codes.NotFound, internalError.Error())
+	case "unauthorized":
+		return status.Error(codes.Unauthenticated, internalError.Error())
+	case "forbidden":
+		return status.Error(codes.PermissionDenied, internalError.Error())
+	case "conflict":
+		return status.Error(codes.Aborted, internalError.Error())
+	case "service_unavailable":
+		return status.Error(codes.Unavailable, internalError.Error())
+	default:
+		return status.Error(codes.Internal, internalError.Error())
+	}
+}
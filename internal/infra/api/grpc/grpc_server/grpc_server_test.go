@@ -0,0 +1,222 @@
+package grpc_server_test
+
+import (
+	"context"
+	"errors"
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/infra/api/grpc/grpc_server"
+	"fullcycle-auction_go/internal/infra/api/grpc/pb"
+	"fullcycle-auction_go/internal/infra/event"
+	"fullcycle-auction_go/internal/infra/rate_limiter"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const auctionId = "2c8a3f1e-5d47-4b8b-9a0e-7f3b6c1d2e4f"
+
+var auctionEndTime = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+type auctionFindUseCaseStub struct{}
+
+func (auctionFindUseCaseStub) FindAuctionById(
+	ctx context.Context, id string) (*auction_usecase.AuctionOutputDTO, *internal_error.InternalError) {
+	if id != auctionId {
+		return nil, internal_error.NewNotFoundError("Auction not found")
+	}
+	return &auction_usecase.AuctionOutputDTO{
+		Id: id, ProductName: "Product", Status: auction_entity.Active, EndTime: auctionEndTime}, nil
+}
+
+func (auctionFindUseCaseStub) FindAuctions(
+	ctx context.Context,
+	status auction_entity.AuctionStatus,
+	category, productName string,
+	includeSubcategories bool) ([]auction_usecase.AuctionOutputDTO, *internal_error.InternalError) {
+	return nil, internal_error.NewInternalServerError("Error finding auctions")
+}
+
+func (auctionFindUseCaseStub) SearchAuctions(
+	ctx context.Context,
+	query auction_entity.SearchQuery) (*auction_usecase.SearchOutputDTO, *internal_error.InternalError) {
+	return &auction_usecase.SearchOutputDTO{}, nil
+}
+
+func (auctionFindUseCaseStub) FindWinningBidByAuctionId(
+	ctx context.Context, auctionId string) (*auction_usecase.WinningInfoOutputDTO, *internal_error.InternalError) {
+	return nil, internal_error.NewBadRequestError("Auction is not completed yet")
+}
+
+type tokenVerifierStub struct{}
+
+func (tokenVerifierStub) Verify(token string) (*auth.Identity, error) {
+	if token != "valid" {
+		return nil, errors.New("invalid token")
+	}
+	return &auth.Identity{UserId: "6b0d6f1c-0b5e-4d6b-8f8e-2b9d1a3c4e5f"}, nil
+}
+
+func dialServer(t *testing.T, dispatcher *event.EventDispatcher) pb.AuctionServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc_server.NewGRPCServer(nil, auctionFindUseCaseStub{}, nil, nil, dispatcher,
+		auth.NewAuthenticator(tokenVerifierStub{}, nil), rate_limiter.NewBidRouteLimit(rate_limiter.NewMemoryRateLimiter()))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewAuctionServiceClient(conn)
+}
+
+func TestAuctionServiceMapsInternalErrors(t *testing.T) {
+	client := dialServer(t, event.NewEventDispatcher())
+	ctx := context.Background()
+
+	auction, err := client.GetAuction(ctx, &pb.GetAuctionRequest{AuctionId: auctionId})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.AuctionStatus_AUCTION_STATUS_ACTIVE, auction.GetStatus())
+	assert.True(t, auctionEndTime.Equal(auction.GetEndTime().AsTime()))
+
+	_, err = client.GetAuction(ctx, &pb.GetAuctionRequest{AuctionId: "6b0d6f1c-0b5e-4d6b-8f8e-2b9d1a3c4e5f"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.GetAuction(ctx, &pb.GetAuctionRequest{AuctionId: "invalid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.GetWinningBid(ctx, &pb.GetWinningBidRequest{AuctionId: auctionId})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.ListAuctions(ctx, &pb.ListAuctionsRequest{})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestConvertErrorMapsEveryKind(t *testing.T) {
+	cases := []struct {
+		err  *internal_error.InternalError
+		code codes.Code
+	}{
+		{internal_error.NewBadRequestError("bad"), codes.InvalidArgument},
+		{internal_error.NewNotFoundError("missing"), codes.NotFound},
+		{internal_error.NewUnauthorizedError("who"), codes.Unauthenticated},
+		{internal_error.NewForbiddenError("no"), codes.PermissionDenied},
+		{internal_error.NewConflictError("taken"), codes.Aborted},
+		{internal_error.NewServiceUnavailableError("later"), codes.Unavailable},
+		{internal_error.NewInternalServerError("boom"), codes.Internal},
+	}
+
+	for _, c := range cases {
+		err := grpc_server.ConvertError(c.err)
+		assert.Equal(t, c.code, status.Code(err), c.err.Err)
+		assert.Equal(t, c.err.Error(), status.Convert(err).Message())
+	}
+}
+
+func TestServerRejectsInvalidBearerTokens(t *testing.T) {
+	client := dialServer(t, event.NewEventDispatcher())
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer valid")
+	_, err := client.GetAuction(ctx, &pb.GetAuctionRequest{AuctionId: auctionId})
+	assert.NoError(t, err)
+
+	ctx = metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer forged")
+	_, err = client.GetAuction(ctx, &pb.GetAuctionRequest{AuctionId: auctionId})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	stream, err := client.WatchAuction(ctx, &pb.WatchAuctionRequest{AuctionId: auctionId})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestAuctionServiceStreamsEventsUntilClosed(t *testing.T) {
+	dispatcher := event.NewEventDispatcher()
+	client := dialServer(t, dispatcher)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.WatchAuction(ctx, &pb.WatchAuctionRequest{AuctionId: auctionId})
+	assert.NoError(t, err)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		dispatcher.Publish(event_entity.Event{Type: event_entity.BidCreated, AuctionId: "other"})
+		dispatcher.Publish(event_entity.Event{
+			Type: event_entity.BidCreated, AuctionId: auctionId, Data: event_entity.BidData{Amount: 42}})
+		dispatcher.Publish(event_entity.Event{
+			Type: event_entity.AuctionClosed, AuctionId: auctionId,
+			Data: event_entity.AuctionData{Id: auctionId, Status: int(auction_entity.Completed)}})
+	}()
+
+	bidEvent, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, string(event_entity.BidCreated), bidEvent.GetType())
+	assert.Equal(t, 42.0, bidEvent.GetBid().GetAmount())
+
+	closedEvent, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, pb.AuctionStatus_AUCTION_STATUS_COMPLETED, closedEvent.GetAuction().GetStatus())
+
+	_, err = stream.Recv()
+	assert.Error(t, err)
+}
+
+type bidUseCaseStub struct {
+	bid_usecase.BidUseCaseInterface
+}
+
+func (bidUseCaseStub) CreateBid(ctx context.Context, input bid_usecase.BidInputDTO) *internal_error.InternalError {
+	return nil
+}
+
+func TestBidServiceAppliesTheBidRateLimit(t *testing.T) {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc_server.NewGRPCServer(nil, nil, bidUseCaseStub{}, nil, event.NewEventDispatcher(),
+		auth.NewAuthenticator(tokenVerifierStub{}, nil), rate_limiter.RouteLimit{
+			Limiter: rate_limiter.NewMemoryRateLimiter(),
+			Route:   "bid",
+			PerUser: rate_limiter.Quota{Limit: 1, Period: 30 * time.Second},
+			PerIP:   rate_limiter.Quota{Limit: 5, Period: time.Minute},
+		})
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	client := pb.NewBidServiceClient(conn)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer valid")
+	request := &pb.CreateBidRequest{AuctionId: auctionId, Amount: 10}
+
+	_, err = client.CreateBid(ctx, request)
+	assert.NoError(t, err)
+
+	var header metadata.MD
+	_, err = client.CreateBid(ctx, request, grpc.Header(&header))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, []string{"30"}, header.Get("retry-after"))
+}
@@ -0,0 +1,35 @@
+package grpc_server
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/infra/api/grpc/pb"
+	"fullcycle-auction_go/internal/usecase/user_usecase"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type UserService struct {
+	pb.UnimplementedUserServiceServer
+
+	userUseCase user_usecase.UserUseCaseInterface
+}
+
+func (us *UserService) GetUser(
+	ctx context.Context, request *pb.GetUserRequest) (*pb.User, error) {
+
+	if err := uuid.Validate(request.GetUserId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+
+	user, err := us.userUseCase.FindUserById(ctx, request.GetUserId())
+	if err != nil {
+		return nil, ConvertError(err)
+	}
+
+	return &pb.User{
+		Id:   user.Id,
+		Name: user.Name,
+	}, nil
+}
@@ -0,0 +1,72 @@
+package api_key_controller
+
+import (
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/usecase/api_key_usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ApiKeyController struct {
+	apiKeyUseCase api_key_usecase.ApiKeyUseCaseInterface
+}
+
+func NewApiKeyController(apiKeyUseCase api_key_usecase.ApiKeyUseCaseInterface) *ApiKeyController {
+	return &ApiKeyController{
+		apiKeyUseCase: apiKeyUseCase,
+	}
+}
+
+type CreateApiKeyRequest struct {
+	Name   string   `json:"name" binding:"required"`
+	Scopes []string `json:"scopes" binding:"required,min=1" enums:"read-only,bid,manage-auctions"`
+}
+
+func (ac *ApiKeyController) CreateApiKey(c *gin.Context) {
+	var request CreateApiKeyRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		restErr := validation.ValidateErr(err)
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	scopes := make([]auth.Scope, 0, len(request.Scopes))
+	for _, value := range request.Scopes {
+		scopes = append(scopes, auth.Scope(value))
+	}
+
+	apiKey, err := ac.apiKeyUseCase.CreateApiKey(c.Request.Context(), api_key_usecase.ApiKeyInputDTO{
+		Name:   request.Name,
+		Scopes: scopes,
+	})
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, apiKey)
+}
+
+func (ac *ApiKeyController) RevokeApiKey(c *gin.Context) {
+	apiKeyId := c.Param("apiKeyId")
+
+	if err := uuid.Validate(apiKeyId); err != nil {
+		restErr := rest_err.NewBadRequestError("Invalid api key ID")
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	if err := ac.apiKeyUseCase.RevokeApiKey(c.Request.Context(), apiKeyId); err != nil {
+		restErr := rest_err.ConvertError(err)
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
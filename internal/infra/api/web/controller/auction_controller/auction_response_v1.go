@@ -0,0 +1,163 @@
+package auction_controller
+
+import (
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"strings"
+	"time"
+)
+
+type AuctionResponse struct {
+	Id          string    `json:"id"`
+	ProductName string    `json:"product_name"`
+	Category    string    `json:"category"`
+	Description string    `json:"description"`
+	Condition   string    `json:"condition" enums:"new,used,refurbished"`
+	Status      string    `json:"status" enums:"active,completed"`
+	Timestamp   time.Time `json:"timestamp"`
+	EndTime     time.Time `json:"end_time"`
+}
+
+type BidResponse struct {
+	Id        string    `json:"id"`
+	UserId    string    `json:"user_id"`
+	AuctionId string    `json:"auction_id"`
+	Amount    float64   `json:"amount"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type WinningInfoResponse struct {
+	Auction AuctionResponse `json:"auction"`
+	Bid     *BidResponse    `json:"bid"`
+}
+
+type SearchResponse struct {
+	Total  int                  `json:"total"`
+	Hits   []SearchHitResponse  `json:"hits"`
+	Facets SearchFacetsResponse `json:"facets"`
+}
+
+type SearchHitResponse struct {
+	Auction AuctionResponse `json:"auction"`
+	Score   float64         `json:"score"`
+}
+
+// SearchFacetsResponse counts the matches per category and condition. Each
+// facet ignores its own filter, so it lists the alternatives to the one
+// picked.
+type SearchFacetsResponse struct {
+	Category  []FacetCountResponse `json:"category"`
+	Condition []FacetCountResponse `json:"condition"`
+}
+
+// FacetCountResponse counts the matches with Value. Category facets carry
+// the category slug as Value and its Name.
+type FacetCountResponse struct {
+	Value string `json:"value"`
+	Name  string `json:"name,omitempty"`
+	Count int    `json:"count"`
+}
+
+func NewAuctionResponse(auction auction_usecase.AuctionOutputDTO) AuctionResponse {
+	return AuctionResponse{
+		Id:          auction.Id,
+		ProductName: auction.ProductName,
+		Category:    auction.Category,
+		Description: auction.Description,
+		Condition:   ConditionName(auction.Condition),
+		Status:      StatusName(auction.Status),
+		Timestamp:   auction.Timestamp,
+		EndTime:     auction.EndTime,
+	}
+}
+
+func NewWinningInfoResponse(winningInfo auction_usecase.WinningInfoOutputDTO) WinningInfoResponse {
+	response := WinningInfoResponse{Auction: NewAuctionResponse(winningInfo.Auction)}
+
+	if winningInfo.Bid != nil {
+		response.Bid = &BidResponse{
+			Id:        winningInfo.Bid.Id,
+			UserId:    winningInfo.Bid.UserId,
+			AuctionId: winningInfo.Bid.AuctionId,
+			Amount:    winningInfo.Bid.Amount,
+			Timestamp: winningInfo.Bid.Timestamp,
+		}
+	}
+
+	return response
+}
+
+func NewSearchResponse(result auction_usecase.SearchOutputDTO) SearchResponse {
+	response := SearchResponse{
+		Total: result.Total,
+		Hits:  make([]SearchHitResponse, 0, len(result.Hits)),
+		Facets: SearchFacetsResponse{
+			Category:  make([]FacetCountResponse, 0, len(result.Categories)),
+			Condition: make([]FacetCountResponse, 0, len(result.Conditions)),
+		},
+	}
+
+	for _, hit := range result.Hits {
+		response.Hits = append(response.Hits, SearchHitResponse{
+			Auction: NewAuctionResponse(hit.Auction),
+			Score:   hit.Score,
+		})
+	}
+
+	for _, count := range result.Categories {
+		response.Facets.Category = append(response.Facets.Category,
+			FacetCountResponse{Value: count.Category, Name: count.Name, Count: count.Count})
+	}
+
+	for _, count := range result.Conditions {
+		response.Facets.Condition = append(response.Facets.Condition,
+			FacetCountResponse{Value: ConditionName(count.Condition), Count: count.Count})
+	}
+
+	return response
+}
+
+func ConditionName(condition auction_entity.ProductCondition) string {
+	switch condition {
+	case auction_entity.New:
+		return "new"
+	case auction_entity.Used:
+		return "used"
+	case auction_entity.Refurbished:
+		return "refurbished"
+	default:
+		return ""
+	}
+}
+
+func StatusName(status auction_entity.AuctionStatus) string {
+	if status == auction_entity.Completed {
+		return "completed"
+	}
+
+	return "active"
+}
+
+func ParseCondition(value string) (auction_entity.ProductCondition, bool) {
+	switch strings.ToLower(value) {
+	case "new":
+		return auction_entity.New, true
+	case "used":
+		return auction_entity.Used, true
+	case "refurbished":
+		return auction_entity.Refurbished, true
+	default:
+		return 0, false
+	}
+}
+
+func ParseStatus(value string) (auction_entity.AuctionStatus, bool) {
+	switch strings.ToLower(value) {
+	case "", "active":
+		return auction_entity.Active, true
+	case "completed":
+		return auction_entity.Completed, true
+	default:
+		return 0, false
+	}
+}
@@ -1,29 +1,30 @@
 package auction_controller
 
 import (
-	"context"
 	"fullcycle-auction_go/configuration/rest_err"
-	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
 	"fullcycle-auction_go/internal/infra/api/web/validation"
 	"fullcycle-auction_go/internal/usecase/auction_usecase"
 	"net/http"
-	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
 type AuctionController struct {
-	createUseCase auction_usecase.AuctionUseCaseInterface
-	findUseCase   auction_usecase.AuctionFindUseCaseInterface
+	createUseCase   auction_usecase.AuctionUseCaseInterface
+	findUseCase     auction_usecase.AuctionFindUseCaseInterface
+	eventDispatcher event_entity.EventDispatcherInterface
 }
 
 func NewAuctionController(
 	createUseCase auction_usecase.AuctionUseCaseInterface,
-	findUseCase auction_usecase.AuctionFindUseCaseInterface) *AuctionController {
+	findUseCase auction_usecase.AuctionFindUseCaseInterface,
+	eventDispatcher event_entity.EventDispatcherInterface) *AuctionController {
 
 	return &AuctionController{
-		createUseCase: createUseCase,
-		findUseCase:   findUseCase,
+		createUseCase:   createUseCase,
+		findUseCase:     findUseCase,
+		eventDispatcher: eventDispatcher,
 	}
 }
 
@@ -35,26 +36,37 @@ type CreateAuctionRequest struct {
 }
 
 func (u *AuctionController) CreateAuction(c *gin.Context) {
+	auction, ok := u.createAuction(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusCreated, auction)
+}
+
+func (u *AuctionController) CreateAuctionV1(c *gin.Context) {
+	auction, ok := u.createAuction(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusCreated, NewAuctionResponse(*auction))
+}
+
+func (u *AuctionController) createAuction(c *gin.Context) (*auction_usecase.AuctionOutputDTO, bool) {
 	var request CreateAuctionRequest
 
 	if err := c.ShouldBindJSON(&request); err != nil {
 		restErr := validation.ValidateErr(err)
 		c.JSON(restErr.Code, restErr)
-		return
+		return nil, false
 	}
 
-	var condition auction_entity.ProductCondition
-	switch strings.ToLower(request.Condition) {
-	case "new":
-		condition = auction_entity.New
-	case "used":
-		condition = auction_entity.Used
-	case "refurbished":
-		condition = auction_entity.Refurbished
-	default:
+	condition, ok := ParseCondition(request.Condition)
+	if !ok {
 		restErr := rest_err.NewBadRequestError("Invalid condition value. Must be 'new', 'used' or 'refurbished'")
 		c.JSON(restErr.Code, restErr)
-		return
+		return nil, false
 	}
 
 	auctionInputDTO := auction_usecase.AuctionInputDTO{
@@ -64,12 +76,12 @@ func (u *AuctionController) CreateAuction(c *gin.Context) {
 		Condition:   condition,
 	}
 
-	auction, err := u.createUseCase.CreateAuction(context.Background(), auctionInputDTO)
+	auction, err := u.createUseCase.CreateAuction(c.Request.Context(), auctionInputDTO)
 	if err != nil {
 		restErr := rest_err.ConvertError(err)
 		c.JSON(restErr.Code, restErr)
-		return
+		return nil, false
 	}
 
-	c.JSON(http.StatusCreated, auction)
+	return auction, true
 }
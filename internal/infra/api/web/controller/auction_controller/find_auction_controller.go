@@ -1,9 +1,9 @@
 package auction_controller
 
 import (
-	"context"
 	"fullcycle-auction_go/configuration/rest_err"
 	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
 	"net/http"
 	"strconv"
 
@@ -27,10 +27,11 @@ func (u *AuctionController) FindAuctions(c *gin.Context) {
 	}
 
 	auctions, err := u.findUseCase.FindAuctions(
-		context.Background(),
+		c.Request.Context(),
 		status,
 		category,
 		productName,
+		false,
 	)
 	if err != nil {
 		restErr := rest_err.ConvertError(err)
@@ -41,6 +42,46 @@ func (u *AuctionController) FindAuctions(c *gin.Context) {
 	c.JSON(http.StatusOK, auctions)
 }
 
+func (u *AuctionController) FindAuctionsV1(c *gin.Context) {
+	status, ok := ParseStatus(c.Query("status"))
+	if !ok {
+		restErr := rest_err.NewBadRequestError("Invalid status value. Must be 'active' or 'completed'")
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	includeSubcategories := false
+	if value := c.Query("include_subcategories"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			restErr := rest_err.NewBadRequestError("Invalid include_subcategories value. Must be true or false")
+			c.JSON(restErr.Code, restErr)
+			return
+		}
+		includeSubcategories = parsed
+	}
+
+	auctions, err := u.findUseCase.FindAuctions(
+		c.Request.Context(),
+		status,
+		c.Query("category"),
+		c.Query("product_name"),
+		includeSubcategories,
+	)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	response := make([]AuctionResponse, 0, len(auctions))
+	for _, auction := range auctions {
+		response = append(response, NewAuctionResponse(auction))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 func (u *AuctionController) FindAuctionById(c *gin.Context) {
 	auctionId := c.Param("auctionId")
 
@@ -50,7 +91,7 @@ func (u *AuctionController) FindAuctionById(c *gin.Context) {
 		return
 	}
 
-	auctionData, err := u.findUseCase.FindAuctionById(context.Background(), auctionId)
+	auctionData, err := u.findUseCase.FindAuctionById(c.Request.Context(), auctionId)
 	if err != nil {
 		restErr := rest_err.ConvertError(err)
 		if restErr.Code == http.StatusNotFound {
@@ -65,7 +106,7 @@ func (u *AuctionController) FindAuctionById(c *gin.Context) {
 	c.JSON(http.StatusOK, auctionData)
 }
 
-func (u *AuctionController) FindWinningBidByAuctionId(c *gin.Context) {
+func (u *AuctionController) FindAuctionByIdV1(c *gin.Context) {
 	auctionId := c.Param("auctionId")
 
 	if err := uuid.Validate(auctionId); err != nil {
@@ -74,12 +115,50 @@ func (u *AuctionController) FindWinningBidByAuctionId(c *gin.Context) {
 		return
 	}
 
-	bid, err := u.findUseCase.FindWinningBidByAuctionId(context.Background(), auctionId)
+	auctionData, err := u.findUseCase.FindAuctionById(c.Request.Context(), auctionId)
 	if err != nil {
 		restErr := rest_err.ConvertError(err)
 		c.JSON(restErr.Code, restErr)
 		return
 	}
 
-	c.JSON(http.StatusOK, bid)
+	c.JSON(http.StatusOK, NewAuctionResponse(*auctionData))
+}
+
+func (u *AuctionController) FindWinningBidByAuctionId(c *gin.Context) {
+	winningInfo, ok := u.findWinningBidByAuctionId(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, winningInfo)
+}
+
+func (u *AuctionController) FindWinningBidByAuctionIdV1(c *gin.Context) {
+	winningInfo, ok := u.findWinningBidByAuctionId(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, NewWinningInfoResponse(*winningInfo))
+}
+
+func (u *AuctionController) findWinningBidByAuctionId(
+	c *gin.Context) (*auction_usecase.WinningInfoOutputDTO, bool) {
+	auctionId := c.Param("auctionId")
+
+	if err := uuid.Validate(auctionId); err != nil {
+		restErr := rest_err.NewBadRequestError("Invalid auction ID")
+		c.JSON(restErr.Code, restErr)
+		return nil, false
+	}
+
+	winningInfo, err := u.findUseCase.FindWinningBidByAuctionId(c.Request.Context(), auctionId)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+		c.JSON(restErr.Code, restErr)
+		return nil, false
+	}
+
+	return winningInfo, true
 }
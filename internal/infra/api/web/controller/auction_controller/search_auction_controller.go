@@ -0,0 +1,56 @@
+package auction_controller
+
+import (
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (u *AuctionController) SearchAuctionsV1(c *gin.Context) {
+	query := auction_entity.SearchQuery{
+		Text:     c.Query("q"),
+		Category: c.Query("category"),
+	}
+
+	if value := c.Query("status"); value != "" {
+		status, ok := ParseStatus(value)
+		if !ok {
+			restErr := rest_err.NewBadRequestError("Invalid status value. Must be 'active' or 'completed'")
+			c.JSON(restErr.Code, restErr)
+			return
+		}
+		query.Status = &status
+	}
+
+	if value := c.Query("condition"); value != "" {
+		condition, ok := ParseCondition(value)
+		if !ok {
+			restErr := rest_err.NewBadRequestError("Invalid condition value. Must be 'new', 'used' or 'refurbished'")
+			c.JSON(restErr.Code, restErr)
+			return
+		}
+		query.Condition = condition
+	}
+
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			restErr := rest_err.NewBadRequestError("Invalid limit value. Must be a positive integer")
+			c.JSON(restErr.Code, restErr)
+			return
+		}
+		query.Limit = limit
+	}
+
+	result, err := u.findUseCase.SearchAuctions(c.Request.Context(), query)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, NewSearchResponse(*result))
+}
@@ -0,0 +1,105 @@
+package auction_controller
+
+import (
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const streamHeartbeatInterval = 15 * time.Second
+
+func (u *AuctionController) StreamAuctionEvents(c *gin.Context) {
+	auctionId := c.Param("auctionId")
+
+	if err := uuid.Validate(auctionId); err != nil {
+		restErr := rest_err.NewBadRequestError("Invalid auction ID")
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	lastEventIdStr := c.GetHeader("Last-Event-ID")
+	if lastEventIdStr == "" {
+		lastEventIdStr = c.Query("lastEventId")
+	}
+
+	resume := lastEventIdStr != ""
+	var lastEventId uint64
+	if resume {
+		value, err := strconv.ParseUint(lastEventIdStr, 10, 64)
+		if err != nil {
+			restErr := rest_err.NewBadRequestError("Invalid Last-Event-ID")
+			c.JSON(restErr.Code, restErr)
+			return
+		}
+		lastEventId = value
+	}
+
+	if _, err := u.findUseCase.FindAuctionById(c.Request.Context(), auctionId); err != nil {
+		restErr := rest_err.ConvertError(err)
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	events, unsubscribe := u.eventDispatcher.Subscribe(64)
+	defer unsubscribe()
+
+	// The headers are flushed before the first event is rendered, so the
+	// content type has to be set here for EventSource to accept the stream.
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	if resume {
+		for _, event := range u.eventDispatcher.EventsSince(auctionId, lastEventId) {
+			renderEvent(c, event)
+			lastEventId = event.Id
+			if event.Type == event_entity.AuctionClosed {
+				return
+			}
+		}
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": heartbeat\n\n")
+			return err == nil
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+
+			if event.AuctionId != auctionId || event.Id <= lastEventId {
+				return true
+			}
+
+			renderEvent(c, event)
+			lastEventId = event.Id
+
+			return event.Type != event_entity.AuctionClosed
+		}
+	})
+}
+
+func renderEvent(c *gin.Context, event event_entity.Event) {
+	c.Render(-1, sse.Event{
+		Id:    strconv.FormatUint(event.Id, 10),
+		Event: string(event.Type),
+		Data:  event,
+	})
+}
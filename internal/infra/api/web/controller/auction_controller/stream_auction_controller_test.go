@@ -0,0 +1,108 @@
+package auction_controller_test
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/clock"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/infra/api/web/controller/auction_controller"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"fullcycle-auction_go/internal/infra/database/bid"
+	"fullcycle-auction_go/internal/infra/database/category"
+	"fullcycle-auction_go/internal/infra/event"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStreamServer serves the stream of a newly created auction.
+func newStreamServer(t *testing.T) (*httptest.Server, *event.EventDispatcher, string) {
+	gin.SetMode(gin.TestMode)
+
+	fakeClock := clock.NewFakeClock(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	dispatcher := event.NewEventDispatcher()
+	auctionRepository := auction.NewMemoryAuctionRepository(dispatcher, fakeClock)
+
+	auctionEntity, err := auction_entity.CreateAuction(
+		"Guitar", "Instruments", "Electric guitar with case", auction_entity.Used, fakeClock.Now())
+	require.Nil(t, err)
+	require.Nil(t, auctionRepository.CreateAuction(context.Background(), auctionEntity))
+
+	findUseCase := auction_usecase.NewAuctionFindUseCase(
+		auctionRepository,
+		bid.NewMemoryBidRepository(auctionRepository, dispatcher, fakeClock),
+		category.NewMemoryCategoryRepository())
+
+	router := gin.New()
+	router.GET("/auction/:auctionId/stream",
+		auction_controller.NewAuctionController(nil, findUseCase, dispatcher).StreamAuctionEvents)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	return server, dispatcher, auctionEntity.Id
+}
+
+func openStream(t *testing.T, url, lastEventId string) *http.Response {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if lastEventId != "" {
+		request.Header.Set("Last-Event-ID", lastEventId)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	t.Cleanup(func() { response.Body.Close() })
+
+	return response
+}
+
+func TestStreamAuctionEvents(t *testing.T) {
+	server, dispatcher, auctionId := newStreamServer(t)
+
+	response := openStream(t, server.URL+"/auction/"+auctionId+"/stream", "")
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+	assert.Equal(t, "no-cache", response.Header.Get("Cache-Control"))
+
+	dispatcher.Publish(event_entity.Event{Type: event_entity.BidCreated, AuctionId: "another-auction"})
+	dispatcher.Publish(event_entity.Event{Type: event_entity.BidCreated, AuctionId: auctionId})
+	dispatcher.Publish(event_entity.Event{Type: event_entity.AuctionClosed, AuctionId: auctionId})
+
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.NotContains(t, string(body), "id:2\n")
+	assert.Contains(t, string(body), "id:3\nevent:"+string(event_entity.BidCreated)+"\n")
+	assert.Contains(t, string(body), "id:4\nevent:"+string(event_entity.AuctionClosed)+"\n")
+}
+
+func TestStreamAuctionEventsResumesAfterLastEventId(t *testing.T) {
+	server, dispatcher, auctionId := newStreamServer(t)
+
+	// Event 1 is the creation of the auction.
+	dispatcher.Publish(event_entity.Event{Type: event_entity.BidCreated, AuctionId: auctionId})
+	dispatcher.Publish(event_entity.Event{Type: event_entity.BidCreated, AuctionId: auctionId})
+	dispatcher.Publish(event_entity.Event{Type: event_entity.AuctionClosed, AuctionId: auctionId})
+
+	response := openStream(t, server.URL+"/auction/"+auctionId+"/stream", "2")
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.NotContains(t, string(body), "id:2\n")
+	assert.Contains(t, string(body), "id:3\n")
+	assert.Contains(t, string(body), "id:4\nevent:"+string(event_entity.AuctionClosed)+"\n")
+
+	response = openStream(t, server.URL+"/auction/"+auctionId+"/stream", "not-a-number")
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+}
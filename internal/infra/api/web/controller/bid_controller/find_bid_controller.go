@@ -1,7 +1,6 @@
 package bid_controller
 
 import (
-	"context"
 	"fullcycle-auction_go/configuration/rest_err"
 	"net/http"
 
@@ -18,7 +17,7 @@ func (u *BidController) FindBidByAuctionId(c *gin.Context) {
 		return
 	}
 
-	bidOutputList, err := u.bidUseCase.FindBidByAuctionId(context.Background(), auctionId)
+	bidOutputList, err := u.bidUseCase.FindBidByAuctionId(c.Request.Context(), auctionId)
 	if err != nil {
 		restErr := rest_err.ConvertError(err)
 		c.JSON(restErr.Code, restErr)
@@ -0,0 +1,148 @@
+package category_controller
+
+import (
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/usecase/category_usecase"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CategoryController struct {
+	categoryUseCase category_usecase.CategoryUseCaseInterface
+}
+
+func NewCategoryController(categoryUseCase category_usecase.CategoryUseCaseInterface) *CategoryController {
+	return &CategoryController{
+		categoryUseCase: categoryUseCase,
+	}
+}
+
+// CreateCategoryRequest is the body of both create and update. An empty
+// slug is derived from the name; parent_id takes a category id or slug.
+type CreateCategoryRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Slug     string `json:"slug"`
+	ParentId string `json:"parent_id"`
+}
+
+type CategoryResponse struct {
+	Id        string             `json:"id"`
+	Name      string             `json:"name"`
+	Slug      string             `json:"slug"`
+	ParentId  *string            `json:"parent_id"`
+	Timestamp time.Time          `json:"timestamp"`
+	Children  []CategoryResponse `json:"children"`
+}
+
+func NewCategoryResponse(category category_usecase.CategoryOutputDTO) CategoryResponse {
+	var parentId *string
+	if category.ParentId != "" {
+		parentId = &category.ParentId
+	}
+
+	children := make([]CategoryResponse, 0, len(category.Children))
+	for _, child := range category.Children {
+		children = append(children, NewCategoryResponse(child))
+	}
+
+	return CategoryResponse{
+		Id:        category.Id,
+		Name:      category.Name,
+		Slug:      category.Slug,
+		ParentId:  parentId,
+		Timestamp: category.Timestamp,
+		Children:  children,
+	}
+}
+
+func (cc *CategoryController) CreateCategory(c *gin.Context) {
+	request, ok := bindCategoryRequest(c)
+	if !ok {
+		return
+	}
+
+	category, err := cc.categoryUseCase.CreateCategory(c.Request.Context(), request)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, NewCategoryResponse(*category))
+}
+
+func (cc *CategoryController) UpdateCategory(c *gin.Context) {
+	request, ok := bindCategoryRequest(c)
+	if !ok {
+		return
+	}
+
+	category, err := cc.categoryUseCase.UpdateCategory(
+		c.Request.Context(), c.Param("category"), request)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, NewCategoryResponse(*category))
+}
+
+func (cc *CategoryController) DeleteCategory(c *gin.Context) {
+	if err := cc.categoryUseCase.DeleteCategory(c.Request.Context(), c.Param("category")); err != nil {
+		restErr := rest_err.ConvertError(err)
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// FindCategory takes a category id or slug and returns the category with
+// its subcategories.
+func (cc *CategoryController) FindCategory(c *gin.Context) {
+	category, err := cc.categoryUseCase.FindCategory(c.Request.Context(), c.Param("category"))
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, NewCategoryResponse(*category))
+}
+
+// FindCategories returns the whole taxonomy as a list of root categories.
+func (cc *CategoryController) FindCategories(c *gin.Context) {
+	categories, err := cc.categoryUseCase.FindCategoryTree(c.Request.Context())
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	response := make([]CategoryResponse, 0, len(categories))
+	for _, category := range categories {
+		response = append(response, NewCategoryResponse(category))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func bindCategoryRequest(c *gin.Context) (category_usecase.CategoryInputDTO, bool) {
+	var request CreateCategoryRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		restErr := validation.ValidateErr(err)
+		c.JSON(restErr.Code, restErr)
+		return category_usecase.CategoryInputDTO{}, false
+	}
+
+	return category_usecase.CategoryInputDTO{
+		Name:     request.Name,
+		Slug:     request.Slug,
+		ParentId: request.ParentId,
+	}, true
+}
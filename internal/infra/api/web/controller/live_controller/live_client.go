@@ -0,0 +1,248 @@
+package live_controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/infra/rate_limiter"
+	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+const (
+	writeWait      = 10 * time.Second
+	pongWait       = 60 * time.Second
+	pingPeriod     = (pongWait * 9) / 10
+	maxMessageSize = 4096
+	sendBufferSize = 64
+	bidTimeout     = 10 * time.Second
+)
+
+type liveClient struct {
+	// ctx is the upgrade request's context; it carries the caller identity
+	// and is cancelled when the connection's handler returns.
+	ctx          context.Context
+	clientIP     string
+	conn         *websocket.Conn
+	bidUseCase   bid_usecase.BidUseCaseInterface
+	bidRateLimit rate_limiter.RouteLimit
+	events       <-chan event_entity.Event
+	unsubscribe  func()
+
+	send        chan OutgoingMessage
+	done        chan struct{}
+	closeOnce   sync.Once
+	closeCode   int
+	closeReason string
+
+	subscriptionsMutex sync.RWMutex
+	subscriptions      map[string]bool
+}
+
+func newLiveClient(
+	ctx context.Context,
+	clientIP string,
+	conn *websocket.Conn,
+	bidUseCase bid_usecase.BidUseCaseInterface,
+	bidRateLimit rate_limiter.RouteLimit,
+	events <-chan event_entity.Event,
+	unsubscribe func()) *liveClient {
+
+	return &liveClient{
+		ctx:           ctx,
+		clientIP:      clientIP,
+		conn:          conn,
+		bidUseCase:    bidUseCase,
+		bidRateLimit:  bidRateLimit,
+		events:        events,
+		unsubscribe:   unsubscribe,
+		send:          make(chan OutgoingMessage, sendBufferSize),
+		done:          make(chan struct{}),
+		subscriptions: make(map[string]bool),
+	}
+}
+
+func (lc *liveClient) close(code int, reason string) {
+	lc.closeOnce.Do(func() {
+		lc.closeCode = code
+		lc.closeReason = reason
+		close(lc.done)
+		lc.unsubscribe()
+	})
+}
+
+func (lc *liveClient) enqueue(message OutgoingMessage) {
+	select {
+	case lc.send <- message:
+	case <-lc.done:
+	default:
+		logger.Info("Closing slow websocket consumer",
+			zap.String("remote_addr", lc.conn.RemoteAddr().String()))
+		lc.close(websocket.ClosePolicyViolation, "slow consumer")
+	}
+}
+
+func (lc *liveClient) forwardEvents() {
+	for event := range lc.events {
+		if lc.isSubscribed(event.AuctionId) {
+			event := event
+			lc.enqueue(OutgoingMessage{Type: EventMessage, Event: &event})
+		}
+	}
+}
+
+func (lc *liveClient) readPump() {
+	defer lc.close(websocket.CloseNormalClosure, "")
+
+	lc.conn.SetReadLimit(maxMessageSize)
+	lc.conn.SetReadDeadline(time.Now().Add(pongWait))
+	lc.conn.SetPongHandler(func(string) error {
+		return lc.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		var message IncomingMessage
+		_, payload, err := lc.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				logger.Error("Unexpected websocket close", err)
+			}
+			return
+		}
+
+		if err := json.Unmarshal(payload, &message); err != nil {
+			lc.enqueue(OutgoingMessage{
+				Type:  ErrorMessage,
+				Error: rest_err.NewBadRequestError("Invalid message format"),
+			})
+			continue
+		}
+
+		lc.handle(message)
+	}
+}
+
+func (lc *liveClient) handle(message IncomingMessage) {
+	switch message.Type {
+	case SubscribeMessage:
+		lc.subscriptionsMutex.Lock()
+		for _, auctionId := range message.AuctionIds {
+			lc.subscriptions[auctionId] = true
+		}
+		lc.subscriptionsMutex.Unlock()
+
+		lc.enqueue(OutgoingMessage{
+			Type:       SubscribedMessage,
+			RequestId:  message.RequestId,
+			AuctionIds: lc.subscribedAuctionIds(),
+		})
+	case UnsubscribeMessage:
+		lc.subscriptionsMutex.Lock()
+		for _, auctionId := range message.AuctionIds {
+			delete(lc.subscriptions, auctionId)
+		}
+		lc.subscriptionsMutex.Unlock()
+
+		lc.enqueue(OutgoingMessage{
+			Type:       SubscribedMessage,
+			RequestId:  message.RequestId,
+			AuctionIds: lc.subscribedAuctionIds(),
+		})
+	case BidMessage:
+		var userId string
+		if identity, ok := auth.IdentityFromContext(lc.ctx); ok {
+			userId = identity.UserId
+		}
+		if decision := lc.bidRateLimit.Allow(lc.ctx, lc.clientIP, userId); !decision.Allowed {
+			lc.enqueue(OutgoingMessage{
+				Type:      ErrorMessage,
+				RequestId: message.RequestId,
+				Error: rest_err.NewTooManyRequestsError(fmt.Sprintf(
+					"Rate limit exceeded, retry in %d seconds", int(math.Ceil(decision.RetryAfter.Seconds())))),
+			})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(lc.ctx, bidTimeout)
+		err := lc.bidUseCase.CreateBid(ctx, bid_usecase.BidInputDTO{
+			UserId:    message.UserId,
+			AuctionId: message.AuctionId,
+			Amount:    message.Amount,
+		})
+		cancel()
+		if err != nil {
+			lc.enqueue(OutgoingMessage{
+				Type:      ErrorMessage,
+				RequestId: message.RequestId,
+				Error:     rest_err.ConvertError(err),
+			})
+			return
+		}
+
+		lc.enqueue(OutgoingMessage{Type: AckMessage, RequestId: message.RequestId})
+	default:
+		lc.enqueue(OutgoingMessage{
+			Type:      ErrorMessage,
+			RequestId: message.RequestId,
+			Error:     rest_err.NewBadRequestError("Unknown message type " + message.Type),
+		})
+	}
+}
+
+func (lc *liveClient) subscribedAuctionIds() []string {
+	lc.subscriptionsMutex.RLock()
+	defer lc.subscriptionsMutex.RUnlock()
+
+	auctionIds := make([]string, 0, len(lc.subscriptions))
+	for auctionId := range lc.subscriptions {
+		auctionIds = append(auctionIds, auctionId)
+	}
+
+	return auctionIds
+}
+
+func (lc *liveClient) isSubscribed(auctionId string) bool {
+	lc.subscriptionsMutex.RLock()
+	defer lc.subscriptionsMutex.RUnlock()
+
+	return lc.subscriptions[auctionId]
+}
+
+func (lc *liveClient) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		lc.conn.Close()
+	}()
+
+	for {
+		select {
+		case <-lc.done:
+			lc.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			lc.conn.WriteMessage(websocket.CloseMessage,
+				websocket.FormatCloseMessage(lc.closeCode, lc.closeReason))
+			return
+		case message := <-lc.send:
+			lc.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := lc.conn.WriteJSON(message); err != nil {
+				lc.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		case <-ticker.C:
+			lc.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := lc.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				lc.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		}
+	}
+}
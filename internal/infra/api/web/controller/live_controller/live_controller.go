@@ -0,0 +1,78 @@
+package live_controller
+
+import (
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/infra/rate_limiter"
+	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+type LiveController struct {
+	bidUseCase      bid_usecase.BidUseCaseInterface
+	eventDispatcher event_entity.EventDispatcherInterface
+	bidRateLimit    rate_limiter.RouteLimit
+	upgrader        websocket.Upgrader
+}
+
+// NewLiveController checks every bid sent over the connection against
+// bidRateLimit, as the REST bid route does.
+func NewLiveController(
+	bidUseCase bid_usecase.BidUseCaseInterface,
+	eventDispatcher event_entity.EventDispatcherInterface,
+	bidRateLimit rate_limiter.RouteLimit) *LiveController {
+
+	return &LiveController{
+		bidUseCase:      bidUseCase,
+		eventDispatcher: eventDispatcher,
+		bidRateLimit:    bidRateLimit,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			CheckOrigin:     newOriginChecker(os.Getenv("WEBSOCKET_ALLOWED_ORIGINS")),
+		},
+	}
+}
+
+func (lc *LiveController) ServeWebSocket(c *gin.Context) {
+	conn, err := lc.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		logger.Error("Error trying to upgrade websocket connection", err)
+		return
+	}
+
+	events, unsubscribe := lc.eventDispatcher.Subscribe(sendBufferSize)
+	client := newLiveClient(
+		c.Request.Context(), c.ClientIP(), conn, lc.bidUseCase, lc.bidRateLimit, events, unsubscribe)
+
+	go client.forwardEvents()
+	go client.writePump()
+	client.readPump()
+}
+
+func newOriginChecker(allowedOrigins string) func(r *http.Request) bool {
+	if allowedOrigins == "*" {
+		return func(r *http.Request) bool { return true }
+	}
+
+	allowed := map[string]bool{}
+	for _, origin := range strings.Split(allowedOrigins, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			allowed[origin] = true
+		}
+	}
+
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" || allowed[origin] {
+			return true
+		}
+
+		return strings.EqualFold(strings.TrimPrefix(strings.TrimPrefix(origin, "http://"), "https://"), r.Host)
+	}
+}
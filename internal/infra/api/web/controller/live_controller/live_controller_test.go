@@ -0,0 +1,132 @@
+package live_controller_test
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/infra/api/web/controller/live_controller"
+	"fullcycle-auction_go/internal/infra/event"
+	"fullcycle-auction_go/internal/infra/rate_limiter"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+type bidUseCaseStub struct {
+	bids chan bid_usecase.BidInputDTO
+}
+
+func (bs *bidUseCaseStub) CreateBid(
+	ctx context.Context, bidInputDTO bid_usecase.BidInputDTO) *internal_error.InternalError {
+	if bidInputDTO.Amount <= 0 {
+		return internal_error.NewBadRequestError("Amount is not a valid value")
+	}
+	bs.bids <- bidInputDTO
+	return nil
+}
+
+func (bs *bidUseCaseStub) FindWinningBidByAuctionId(
+	ctx context.Context, auctionId string) (*bid_usecase.BidOutputDTO, *internal_error.InternalError) {
+	return nil, nil
+}
+
+func (bs *bidUseCaseStub) FindBidByAuctionId(
+	ctx context.Context, auctionId string) ([]bid_usecase.BidOutputDTO, *internal_error.InternalError) {
+	return nil, nil
+}
+
+func (bs *bidUseCaseStub) FindBidsByAuctionIds(
+	ctx context.Context, auctionIds []string) ([]bid_usecase.BidOutputDTO, *internal_error.InternalError) {
+	return nil, nil
+}
+
+func TestLiveChannelSubscribesBidsAndBroadcasts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	dispatcher := event.NewEventDispatcher()
+	bidUseCase := &bidUseCaseStub{bids: make(chan bid_usecase.BidInputDTO, 1)}
+
+	router := gin.New()
+	router.GET("/live", live_controller.NewLiveController(
+		bidUseCase, dispatcher, rate_limiter.NewBidRouteLimit(rate_limiter.NewMemoryRateLimiter())).ServeWebSocket)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/live", nil)
+	assert.NoError(t, err)
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	var response live_controller.OutgoingMessage
+
+	assert.NoError(t, conn.WriteJSON(live_controller.IncomingMessage{
+		Type: live_controller.SubscribeMessage, RequestId: "1", AuctionIds: []string{"auction-a", "auction-b"}}))
+	assert.NoError(t, conn.ReadJSON(&response))
+	assert.Equal(t, live_controller.SubscribedMessage, response.Type)
+	assert.ElementsMatch(t, []string{"auction-a", "auction-b"}, response.AuctionIds)
+
+	assert.NoError(t, conn.WriteJSON(live_controller.IncomingMessage{
+		Type: live_controller.BidMessage, RequestId: "2", AuctionId: "auction-a", UserId: "user", Amount: 10}))
+	assert.NoError(t, conn.ReadJSON(&response))
+	assert.Equal(t, live_controller.AckMessage, response.Type)
+	assert.Equal(t, "2", response.RequestId)
+	assert.Equal(t, 10.0, (<-bidUseCase.bids).Amount)
+
+	assert.NoError(t, conn.WriteJSON(live_controller.IncomingMessage{
+		Type: live_controller.BidMessage, RequestId: "3", AuctionId: "auction-a", UserId: "user", Amount: -1}))
+	assert.NoError(t, conn.ReadJSON(&response))
+	assert.Equal(t, live_controller.ErrorMessage, response.Type)
+	assert.Equal(t, "3", response.RequestId)
+	assert.Equal(t, 400, response.Error.Code)
+
+	dispatcher.Publish(event_entity.Event{Type: event_entity.BidCreated, AuctionId: "auction-c"})
+	dispatcher.Publish(event_entity.Event{Type: event_entity.BidCreated, AuctionId: "auction-b"})
+
+	response = live_controller.OutgoingMessage{}
+	assert.NoError(t, conn.ReadJSON(&response))
+	assert.Equal(t, live_controller.EventMessage, response.Type)
+	assert.Equal(t, "auction-b", response.Event.AuctionId)
+}
+
+func TestLiveChannelAppliesTheBidRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	bidUseCase := &bidUseCaseStub{bids: make(chan bid_usecase.BidInputDTO, 2)}
+	limit := rate_limiter.RouteLimit{
+		Limiter: rate_limiter.NewMemoryRateLimiter(),
+		Route:   "bid",
+		PerUser: rate_limiter.Quota{Limit: 5, Period: time.Minute},
+		PerIP:   rate_limiter.Quota{Limit: 1, Period: 30 * time.Second},
+	}
+
+	router := gin.New()
+	router.GET("/live", live_controller.NewLiveController(bidUseCase, event.NewEventDispatcher(), limit).ServeWebSocket)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/live", nil)
+	assert.NoError(t, err)
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	var response live_controller.OutgoingMessage
+	assert.NoError(t, conn.WriteJSON(live_controller.IncomingMessage{
+		Type: live_controller.BidMessage, RequestId: "1", AuctionId: "auction-a", Amount: 10}))
+	assert.NoError(t, conn.ReadJSON(&response))
+	assert.Equal(t, live_controller.AckMessage, response.Type)
+
+	response = live_controller.OutgoingMessage{}
+	assert.NoError(t, conn.WriteJSON(live_controller.IncomingMessage{
+		Type: live_controller.BidMessage, RequestId: "2", AuctionId: "auction-a", Amount: 11}))
+	assert.NoError(t, conn.ReadJSON(&response))
+	assert.Equal(t, live_controller.ErrorMessage, response.Type)
+	assert.Equal(t, "2", response.RequestId)
+	assert.Equal(t, 429, response.Error.Code)
+	assert.Len(t, bidUseCase.bids, 1)
+}
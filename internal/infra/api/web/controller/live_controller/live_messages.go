@@ -0,0 +1,34 @@
+package live_controller
+
+import (
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/entity/event_entity"
+)
+
+const (
+	SubscribeMessage   = "subscribe"
+	UnsubscribeMessage = "unsubscribe"
+	BidMessage         = "bid"
+
+	SubscribedMessage = "subscribed"
+	AckMessage        = "ack"
+	ErrorMessage      = "error"
+	EventMessage      = "event"
+)
+
+type IncomingMessage struct {
+	Type       string   `json:"type"`
+	RequestId  string   `json:"request_id"`
+	AuctionIds []string `json:"auction_ids"`
+	AuctionId  string   `json:"auction_id"`
+	UserId     string   `json:"user_id"`
+	Amount     float64  `json:"amount"`
+}
+
+type OutgoingMessage struct {
+	Type       string              `json:"type"`
+	RequestId  string              `json:"request_id,omitempty"`
+	AuctionIds []string            `json:"auction_ids,omitempty"`
+	Event      *event_entity.Event `json:"event,omitempty"`
+	Error      *rest_err.RestErr   `json:"error,omitempty"`
+}
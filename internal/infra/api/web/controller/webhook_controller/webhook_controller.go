@@ -0,0 +1,75 @@
+package webhook_controller
+
+import (
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/usecase/webhook_usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type WebhookController struct {
+	webhookUseCase webhook_usecase.WebhookUseCaseInterface
+}
+
+func NewWebhookController(webhookUseCase webhook_usecase.WebhookUseCaseInterface) *WebhookController {
+	return &WebhookController{
+		webhookUseCase: webhookUseCase,
+	}
+}
+
+type CreateWebhookRequest struct {
+	Url    string   `json:"url" binding:"required,url"`
+	Events []string `json:"events" binding:"required,min=1"`
+	Secret string   `json:"secret"`
+}
+
+func (wc *WebhookController) CreateWebhook(c *gin.Context) {
+	var request CreateWebhookRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		restErr := validation.ValidateErr(err)
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	events := make([]event_entity.EventType, 0, len(request.Events))
+	for _, value := range request.Events {
+		events = append(events, event_entity.EventType(value))
+	}
+
+	webhook, err := wc.webhookUseCase.CreateWebhook(c.Request.Context(), webhook_usecase.WebhookInputDTO{
+		Url:    request.Url,
+		Events: events,
+		Secret: request.Secret,
+	})
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, webhook)
+}
+
+func (wc *WebhookController) FindDeliveriesByWebhookId(c *gin.Context) {
+	webhookId := c.Param("webhookId")
+
+	if err := uuid.Validate(webhookId); err != nil {
+		restErr := rest_err.NewBadRequestError("Invalid webhook ID")
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	deliveries, err := wc.webhookUseCase.FindDeliveriesByWebhookId(c.Request.Context(), webhookId)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/auth"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Authenticate stores the caller identity in the request context for the
+// usecases. Anonymous requests pass through so public routes stay public,
+// but invalid credentials are rejected instead of being ignored.
+func Authenticate(authenticator *auth.Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authorization := authorizationHeader(c)
+		if authorization == "" {
+			c.Next()
+			return
+		}
+
+		identity, err := authenticator.Authenticate(c.Request.Context(), authorization)
+		if err != nil {
+			abort(c, rest_err.ConvertError(err))
+			return
+		}
+
+		c.Request = c.Request.WithContext(auth.WithIdentity(c.Request.Context(), identity))
+		c.Next()
+	}
+}
+
+// RequireAuthentication rejects anonymous requests. It must run after
+// Authenticate.
+func RequireAuthentication() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := auth.IdentityFromContext(c.Request.Context()); !ok {
+			abort(c, rest_err.NewUnauthorizedError("Missing credentials"))
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireScope rejects anonymous requests and API keys lacking scope. Callers
+// authenticated with a JWT are only limited by their roles.
+func RequireScope(scope auth.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identity, ok := auth.IdentityFromContext(c.Request.Context())
+		if !ok {
+			abort(c, rest_err.NewUnauthorizedError("Missing credentials"))
+			return
+		}
+
+		if !identity.HasScope(scope) {
+			abort(c, rest_err.NewForbiddenError("API key lacks the "+string(scope)+" scope"))
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequirePermission rejects callers the policy does not allow to perform
+// action, for routes that have no usecase to check it. It must run after
+// Authenticate.
+func RequirePermission(action auth.Action) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := auth.Authorize(c.Request.Context(), action, ""); err != nil {
+			abort(c, rest_err.ConvertError(err))
+			return
+		}
+		c.Next()
+	}
+}
+
+// Browsers cannot set headers on a WebSocket handshake, so a bearer token may
+// be sent as the access_token query parameter there.
+func authorizationHeader(c *gin.Context) string {
+	if header := c.GetHeader("Authorization"); header != "" {
+		return header
+	}
+
+	if c.IsWebsocket() {
+		if token := c.Query("access_token"); token != "" {
+			return auth.BearerScheme + " " + token
+		}
+	}
+
+	return ""
+}
+
+func abort(c *gin.Context, restErr *rest_err.RestErr) {
+	if restErr.Code == http.StatusUnauthorized {
+		c.Header("WWW-Authenticate", auth.BearerScheme+", "+auth.ApiKeyScheme)
+	}
+	c.AbortWithStatusJSON(restErr.Code, restErr)
+}
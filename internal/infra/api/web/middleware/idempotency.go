@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/infra/idempotency"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+	// maxIdempotentBodySize bounds the body read to fingerprint a request.
+	maxIdempotentBodySize = 1 << 20
+	// idempotencyStoreTimeout bounds storing the outcome of a request, which
+	// runs detached from the request context.
+	idempotencyStoreTimeout = 5 * time.Second
+)
+
+// Idempotency makes retries of a request carrying an Idempotency-Key header
+// return the original response instead of running the handler again. Keys
+// are scoped to the caller and route; reusing one with a different body is
+// rejected with 422. Requests without the header are not affected.
+func Idempotency(store idempotency.IdempotencyStoreInterface) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		idempotencyKey := c.GetHeader(IdempotencyKeyHeader)
+		if idempotencyKey == "" {
+			c.Next()
+			return
+		}
+
+		if len(idempotencyKey) > maxIdempotencyKeyLength {
+			abort(c, rest_err.NewBadRequestError("Idempotency-Key is too long"))
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxIdempotentBodySize))
+		if err != nil {
+			// MaxBytesReader fails once the limit has been read.
+			if len(body) >= maxIdempotentBodySize {
+				abort(c, rest_err.NewRequestEntityTooLargeError("Request body is too large"))
+				return
+			}
+			abort(c, rest_err.NewBadRequestError("Error trying to read request body"))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		callerId := "anonymous"
+		if identity, ok := auth.IdentityFromContext(c.Request.Context()); ok {
+			callerId = identity.UserId
+		}
+		key := c.Request.Method + " " + c.FullPath() + " " + callerId + " " + idempotencyKey
+		fingerprint := fingerprintOf(c.Request.Method, c.FullPath(), body)
+
+		existing, reserved, err := store.Reserve(c.Request.Context(), key, fingerprint)
+		if err != nil {
+			logger.Error("Error reserving idempotency key", err)
+			abort(c, rest_err.NewInternalServerError("Error trying to check Idempotency-Key"))
+			return
+		}
+
+		if !reserved {
+			replay(c, existing, fingerprint)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// The request context is cancelled when the client disconnects or
+		// the request times out. The outcome still has to be stored, or the
+		// key would stay in progress and reject every retry.
+		ctx, cancel := context.WithTimeout(context.Background(), idempotencyStoreTimeout)
+		defer cancel()
+
+		// Server errors are not stored so the client can retry them.
+		if recorder.Status() >= http.StatusInternalServerError {
+			if err := store.Release(ctx, key); err != nil {
+				logger.Error("Error releasing idempotency key", err)
+			}
+			return
+		}
+
+		if err := store.Complete(ctx, key, recorder.Status(),
+			recorder.Header().Get("Content-Type"), recorder.body.Bytes()); err != nil {
+			logger.Error("Error storing idempotent response", err, zap.String("key", idempotencyKey))
+		}
+	}
+}
+
+func replay(c *gin.Context, record *idempotency.Record, fingerprint string) {
+	if record.Fingerprint != fingerprint {
+		abort(c, rest_err.NewUnprocessableEntityError("Idempotency-Key was already used with a different request"))
+		return
+	}
+
+	if record.State != idempotency.Completed {
+		abort(c, rest_err.NewConflictError("A request with this Idempotency-Key is still being processed"))
+		return
+	}
+
+	c.Header(IdempotentReplayedHeader, "true")
+	if len(record.Body) == 0 {
+		c.AbortWithStatus(record.StatusCode)
+		return
+	}
+	c.Abort()
+	c.Data(record.StatusCode, record.ContentType, record.Body)
+}
+
+func fingerprintOf(method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (rr *responseRecorder) Write(data []byte) (int, error) {
+	rr.body.Write(data)
+	return rr.ResponseWriter.Write(data)
+}
+
+func (rr *responseRecorder) WriteString(data string) (int, error) {
+	rr.body.WriteString(data)
+	return rr.ResponseWriter.WriteString(data)
+}
@@ -0,0 +1,152 @@
+package middleware_test
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/idempotency"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyReplaysOriginalResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	calls := 0
+	router := gin.New()
+	router.POST("/auction", middleware.Idempotency(idempotency.NewMemoryIdempotencyStore(time.Hour)),
+		func(c *gin.Context) {
+			calls++
+			if strings.Contains(c.GetHeader("X-Fail"), "yes") {
+				c.Status(http.StatusInternalServerError)
+				return
+			}
+			c.JSON(http.StatusCreated, gin.H{"id": calls})
+		})
+
+	send := func(key, body, fail string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/auction", strings.NewReader(body))
+		if key != "" {
+			request.Header.Set(middleware.IdempotencyKeyHeader, key)
+		}
+		request.Header.Set("X-Fail", fail)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	first := send("key-1", `{"product_name":"a"}`, "")
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.JSONEq(t, `{"id":1}`, first.Body.String())
+
+	repeat := send("key-1", `{"product_name":"a"}`, "")
+	assert.Equal(t, http.StatusCreated, repeat.Code)
+	assert.JSONEq(t, `{"id":1}`, repeat.Body.String())
+	assert.Equal(t, "true", repeat.Header().Get(middleware.IdempotentReplayedHeader))
+	assert.Equal(t, 1, calls)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, send("key-1", `{"product_name":"b"}`, "").Code)
+	assert.Equal(t, 1, calls)
+
+	assert.Equal(t, http.StatusInternalServerError, send("key-2", `{}`, "yes").Code)
+	assert.Equal(t, http.StatusCreated, send("key-2", `{}`, "").Code, "failed requests can be retried")
+	assert.Equal(t, 3, calls)
+
+	send("", `{}`, "")
+	send("", `{}`, "")
+	assert.Equal(t, 5, calls, "requests without a key are never deduplicated")
+}
+
+func TestIdempotencyRejectsConcurrentRepeats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	router := gin.New()
+	router.POST("/bid", middleware.Idempotency(idempotency.NewMemoryIdempotencyStore(time.Hour)),
+		func(c *gin.Context) {
+			close(started)
+			<-release
+			c.Status(http.StatusCreated)
+		})
+
+	send := func() *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/bid", strings.NewReader(`{"amount":10}`))
+		request.Header.Set(middleware.IdempotencyKeyHeader, "key-1")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- send() }()
+	<-started
+
+	assert.Equal(t, http.StatusConflict, send().Code)
+
+	close(release)
+	assert.Equal(t, http.StatusCreated, (<-done).Code)
+	assert.Equal(t, http.StatusCreated, send().Code)
+}
+
+// contextRecordingStore records whether the context it completes keys with
+// is already done.
+type contextRecordingStore struct {
+	idempotency.IdempotencyStoreInterface
+	completedWithDoneContext chan bool
+}
+
+func (cs *contextRecordingStore) Complete(
+	ctx context.Context, key string, statusCode int, contentType string, body []byte) error {
+	cs.completedWithDoneContext <- ctx.Err() != nil
+	return cs.IdempotencyStoreInterface.Complete(ctx, key, statusCode, contentType, body)
+}
+
+func TestIdempotencyCompletesAfterClientDisconnects(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := &contextRecordingStore{
+		IdempotencyStoreInterface: idempotency.NewMemoryIdempotencyStore(time.Hour),
+		completedWithDoneContext:  make(chan bool, 1),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	router := gin.New()
+	router.POST("/bid", middleware.Idempotency(store), func(c *gin.Context) {
+		cancel()
+		c.Status(http.StatusCreated)
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/bid", strings.NewReader(`{"amount":10}`)).WithContext(ctx)
+	request.Header.Set(middleware.IdempotencyKeyHeader, "key-1")
+	router.ServeHTTP(httptest.NewRecorder(), request)
+
+	assert.False(t, <-store.completedWithDoneContext)
+
+	request = httptest.NewRequest(http.MethodPost, "/bid", strings.NewReader(`{"amount":10}`))
+	request.Header.Set(middleware.IdempotencyKeyHeader, "key-1")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Equal(t, "true", recorder.Header().Get(middleware.IdempotentReplayedHeader))
+}
+
+func TestIdempotencyRejectsLargeBodies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	calls := 0
+	router := gin.New()
+	router.POST("/auction", middleware.Idempotency(idempotency.NewMemoryIdempotencyStore(time.Hour)),
+		func(c *gin.Context) {
+			calls++
+			c.Status(http.StatusCreated)
+		})
+
+	request := httptest.NewRequest(http.MethodPost, "/auction", strings.NewReader(strings.Repeat("a", 2<<20)))
+	request.Header.Set(middleware.IdempotencyKeyHeader, "key-1")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+	assert.Equal(t, 0, calls)
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/infra/rate_limiter"
+	"math"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit applies limit to the client IP and, for authenticated requests,
+// the caller; see rate_limiter.RouteLimit. It must run after Authenticate.
+func RateLimit(limit rate_limiter.RouteLimit) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var userId string
+		if identity, ok := auth.IdentityFromContext(c.Request.Context()); ok {
+			userId = identity.UserId
+		}
+
+		decision := limit.Allow(c.Request.Context(), c.ClientIP(), userId)
+		if !decision.Allowed {
+			restErr := rest_err.NewTooManyRequestsError("Rate limit exceeded, retry later")
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(decision.RetryAfter.Seconds()))))
+			c.AbortWithStatusJSON(restErr.Code, restErr)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,42 @@
+package middleware_test
+
+import (
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/rate_limiter"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitRespondsWithRetryAfter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/bid", middleware.RateLimit(rate_limiter.RouteLimit{
+		Limiter: rate_limiter.NewMemoryRateLimiter(),
+		Route:   "bid",
+		PerUser: rate_limiter.Quota{Limit: 5, Period: time.Minute},
+		PerIP:   rate_limiter.Quota{Limit: 1, Period: 30 * time.Second},
+	}), func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+
+	send := func(remoteAddr string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/bid", nil)
+		request.RemoteAddr = remoteAddr
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	assert.Equal(t, http.StatusCreated, send("10.0.0.1:1234").Code)
+
+	limited := send("10.0.0.1:1234")
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "30", limited.Header().Get("Retry-After"))
+
+	assert.Equal(t, http.StatusCreated, send("10.0.0.2:1234").Code)
+}
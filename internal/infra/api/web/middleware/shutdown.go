@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CancelOnShutdown cancels the request context once shutdown is done, so
+// long-lived streams return and http.Server.Shutdown does not wait on them
+// until its deadline.
+func CancelOnShutdown(shutdown context.Context) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if shutdown == nil {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithCancel(c.Request.Context())
+		defer cancel()
+
+		go func() {
+			select {
+			case <-shutdown.Done():
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fullcycle-auction_go/configuration/rest_err"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Timeout bounds the request context, and so every query made on its
+// behalf, to duration. Requests that run out of time without writing a
+// response get 504.
+func Timeout(duration time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), duration)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			restErr := rest_err.NewGatewayTimeoutError("The request took longer than " + duration.String())
+			c.AbortWithStatusJSON(restErr.Code, restErr)
+		}
+	}
+}
+
+// SkipForEventStreams runs handler only for requests that do not accept
+// text/event-stream, so a route that also serves subscriptions can bound its
+// single responses without ending its streams.
+func SkipForEventStreams(handler gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if strings.Contains(c.GetHeader("Accept"), "text/event-stream") {
+			c.Next()
+			return
+		}
+
+		handler(c)
+	}
+}
+
+// TimeoutFromEnv reads the duration of Timeout from the environment variable
+// name, falling back when it is unset or invalid.
+func TimeoutFromEnv(name string, fallback time.Duration) gin.HandlerFunc {
+	duration, err := time.ParseDuration(os.Getenv(name))
+	if err != nil || duration <= 0 {
+		duration = fallback
+	}
+
+	return Timeout(duration)
+}
@@ -0,0 +1,53 @@
+package middleware_test
+
+import (
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeoutCancelsTheRequestContext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/slow", middleware.Timeout(20*time.Millisecond), func(c *gin.Context) {
+		<-c.Request.Context().Done()
+	})
+	router.GET("/fast", middleware.Timeout(time.Second), func(c *gin.Context) {
+		_, hasDeadline := c.Request.Context().Deadline()
+		assert.True(t, hasDeadline)
+		c.Status(http.StatusOK)
+	})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/slow", nil))
+	assert.Equal(t, http.StatusGatewayTimeout, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "gateway_timeout")
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/fast", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestSkipForEventStreamsLeavesStreamsWithoutDeadline(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/graphql", middleware.SkipForEventStreams(middleware.Timeout(time.Second)), func(c *gin.Context) {
+		_, hasDeadline := c.Request.Context().Deadline()
+		c.JSON(http.StatusOK, gin.H{"deadline": hasDeadline})
+	})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/graphql", nil))
+	assert.JSONEq(t, `{"deadline": true}`, recorder.Body.String())
+
+	request := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+	request.Header.Set("Accept", "text/event-stream")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.JSONEq(t, `{"deadline": false}`, recorder.Body.String())
+}
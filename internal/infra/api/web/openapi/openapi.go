@@ -0,0 +1,199 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+const title = "Auction API"
+
+const (
+	BearerAuth = "bearerAuth"
+	ApiKeyAuth = "apiKeyAuth"
+)
+
+var securitySchemes = map[string]*SecurityScheme{
+	BearerAuth: {
+		Type:         "http",
+		Scheme:       "bearer",
+		BearerFormat: "JWT",
+		Description:  "HS256 or RS256 token whose subject is the caller's user id",
+	},
+	ApiKeyAuth: {
+		Type:        "apiKey",
+		In:          "header",
+		Name:        "Authorization",
+		Description: "API key sent as \"ApiKey <key>\", limited to the scopes it was issued with",
+	},
+}
+
+func Generate(routes gin.RoutesInfo, operations []Operation, version string) (*Document, error) {
+	registry := &schemaRegistry{schemas: map[string]*Schema{}}
+	document := &Document{
+		OpenAPI:    "3.0.3",
+		Info:       Info{Title: title, Version: version},
+		Paths:      map[string]PathItem{},
+		Components: Components{Schemas: registry.schemas, SecuritySchemes: securitySchemes},
+	}
+
+	byRoute := map[string]Operation{}
+	for _, operation := range operations {
+		byRoute[operation.Method+" "+operation.Path] = operation
+	}
+
+	var missing []string
+	routed := map[string]bool{}
+	for _, route := range routes {
+		key := route.Method + " " + route.Path
+		operation, ok := byRoute[key]
+		if !ok {
+			missing = append(missing, key)
+			continue
+		}
+		routed[key] = true
+
+		openAPIPath, pathParams := convertPath(route.Path)
+		if document.Paths[openAPIPath] == nil {
+			document.Paths[openAPIPath] = PathItem{}
+		}
+		document.Paths[openAPIPath][strings.ToLower(route.Method)] = buildOperation(registry, operation, pathParams)
+	}
+
+	var unrouted []string
+	for key := range byRoute {
+		if !routed[key] {
+			unrouted = append(unrouted, key)
+		}
+	}
+
+	if len(missing) > 0 || len(unrouted) > 0 {
+		sort.Strings(missing)
+		sort.Strings(unrouted)
+		return nil, fmt.Errorf("openapi spec and routes diverge: routes without spec %v, spec without routes %v",
+			missing, unrouted)
+	}
+
+	return document, nil
+}
+
+func Handler(router *gin.Engine) gin.HandlerFunc {
+	var (
+		once     sync.Once
+		document *Document
+		err      error
+	)
+
+	return func(c *gin.Context) {
+		once.Do(func() {
+			document, err = Generate(router.Routes(), Operations, "1.0.0")
+		})
+
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, document)
+	}
+}
+
+func convertPath(ginPath string) (string, []string) {
+	var pathParams []string
+	segments := strings.Split(ginPath, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			name := segment[1:]
+			pathParams = append(pathParams, name)
+			segments[i] = "{" + name + "}"
+		}
+	}
+
+	return strings.Join(segments, "/"), pathParams
+}
+
+func buildOperation(registry *schemaRegistry, operation Operation, pathParams []string) *OperationObject {
+	operationObject := &OperationObject{
+		Summary:    operation.Summary,
+		Deprecated: operation.Deprecated,
+		Responses:  map[string]ResponseObject{},
+	}
+
+	if operation.Tag != "" {
+		operationObject.Tags = []string{operation.Tag}
+	}
+
+	for _, scheme := range operation.Security {
+		operationObject.Security = append(operationObject.Security, map[string][]string{scheme: {}})
+	}
+
+	for _, name := range pathParams {
+		// Parameters named after an id take a uuid; others, such as a
+		// category id or slug, any string.
+		schema := &Schema{Type: "string"}
+		if strings.HasSuffix(name, "Id") {
+			schema.Format = "uuid"
+		}
+		operationObject.Parameters = append(operationObject.Parameters, ParameterObject{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   schema,
+		})
+	}
+
+	for _, queryParam := range operation.QueryParams {
+		operationObject.Parameters = append(operationObject.Parameters, ParameterObject{
+			Name:        queryParam.Name,
+			In:          "query",
+			Description: queryParam.Description,
+			Schema:      &Schema{Type: queryParam.Type},
+		})
+	}
+
+	if operation.Idempotent {
+		operationObject.Parameters = append(operationObject.Parameters, ParameterObject{
+			Name:        "Idempotency-Key",
+			In:          "header",
+			Description: "Repeating a request with the same key returns the original response",
+			Schema:      &Schema{Type: "string", MaxLength: 255},
+		})
+		responses := make([]Response, 0, len(operation.Responses)+3)
+		operation.Responses = append(append(responses, operation.Responses...), conflict, bodyTooLarge, keyReused)
+	}
+
+	if operation.Request != nil {
+		operationObject.RequestBody = &RequestBodyObject{
+			Required: true,
+			Content: map[string]MediaType{
+				jsonContentType: {Schema: registry.schemaFor(operation.Request)},
+			},
+		}
+	}
+
+	for _, response := range operation.Responses {
+		responseObject := ResponseObject{Description: response.Description}
+		if response.Body != nil {
+			contentType := response.ContentType
+			if contentType == "" {
+				contentType = jsonContentType
+			}
+			responseObject.Content = map[string]MediaType{
+				contentType: {Schema: registry.schemaFor(response.Body)},
+			}
+		}
+		// Statuses with several causes, such as a 409 from both the
+		// operation and the idempotency layer, list all of them.
+		if existing, ok := operationObject.Responses[strconv.Itoa(response.Status)]; ok {
+			responseObject.Description = existing.Description + "; " + response.Description
+		}
+		operationObject.Responses[strconv.Itoa(response.Status)] = responseObject
+	}
+
+	return operationObject
+}
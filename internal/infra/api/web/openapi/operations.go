@@ -0,0 +1,549 @@
+package openapi
+
+import (
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/graphql/graphql_server"
+	"fullcycle-auction_go/internal/infra/api/web/controller/api_key_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/auction_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/category_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/webhook_controller"
+	"fullcycle-auction_go/internal/usecase/api_key_usecase"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"fullcycle-auction_go/internal/usecase/user_usecase"
+	"fullcycle-auction_go/internal/usecase/webhook_usecase"
+	"net/http"
+)
+
+const (
+	jsonContentType        = "application/json"
+	eventStreamContentType = "text/event-stream"
+)
+
+type Operation struct {
+	Method     string
+	Path       string
+	Summary    string
+	Tag        string
+	Deprecated bool
+	Security   []string
+	// Idempotent operations accept the Idempotency-Key header.
+	Idempotent  bool
+	QueryParams []QueryParam
+	Request     interface{}
+	Responses   []Response
+}
+
+type QueryParam struct {
+	Name        string
+	Description string
+	Type        string
+}
+
+type Response struct {
+	Status      int
+	Description string
+	ContentType string
+	Body        interface{}
+}
+
+var (
+	badRequest      = Response{Status: http.StatusBadRequest, Description: "Invalid request", Body: rest_err.RestErr{}}
+	notFound        = Response{Status: http.StatusNotFound, Description: "Resource not found", Body: rest_err.RestErr{}}
+	internalError   = Response{Status: http.StatusInternalServerError, Description: "Internal error", Body: rest_err.RestErr{}}
+	unauthorized    = Response{Status: http.StatusUnauthorized, Description: "Missing or invalid credentials", Body: rest_err.RestErr{}}
+	conflict        = Response{Status: http.StatusConflict, Description: "Same Idempotency-Key still in progress", Body: rest_err.RestErr{}}
+	bodyTooLarge    = Response{Status: http.StatusRequestEntityTooLarge, Description: "Body over 1 MiB sent with an Idempotency-Key", Body: rest_err.RestErr{}}
+	keyReused       = Response{Status: http.StatusUnprocessableEntity, Description: "Idempotency-Key reused with a different body", Body: rest_err.RestErr{}}
+	tooManyRequests = Response{Status: http.StatusTooManyRequests, Description: "Rate limit exceeded; see Retry-After", Body: rest_err.RestErr{}}
+	forbidden       = Response{Status: http.StatusForbidden, Description: "Caller is not allowed to do this", Body: rest_err.RestErr{}}
+	slugInUse       = Response{Status: http.StatusConflict, Description: "Slug already in use", Body: rest_err.RestErr{}}
+)
+
+var Operations = append(append(
+	unversionedOperations,
+	v1Operations...),
+	legacyOperations...)
+
+var unversionedOperations = []Operation{
+	{
+		Method:  http.MethodGet,
+		Path:    "/live",
+		Summary: "WebSocket channel for live bidding and auction events",
+		Tag:     "live",
+		Responses: []Response{
+			{Status: http.StatusSwitchingProtocols, Description: "WebSocket upgrade"},
+			{Status: http.StatusBadRequest, Description: "Not a WebSocket handshake"},
+		},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/graphql",
+		Summary:  "GraphQL queries and subscriptions",
+		Tag:      "graphql",
+		Security: []string{BearerAuth, ApiKeyAuth},
+		Request:  graphql_server.GraphQLRequest{},
+		Responses: []Response{
+			{Status: http.StatusOK, Description: "GraphQL response", Body: map[string]interface{}{}},
+			badRequest,
+			unauthorized,
+		},
+	},
+	{
+		Method:  http.MethodGet,
+		Path:    "/health",
+		Summary: "Health check",
+		Tag:     "health",
+		Responses: []Response{
+			{Status: http.StatusOK, Description: "Application is healthy", Body: map[string]string{}},
+		},
+	},
+	{
+		Method:  http.MethodGet,
+		Path:    "/openapi.json",
+		Summary: "This OpenAPI document",
+		Tag:     "health",
+		Responses: []Response{
+			{Status: http.StatusOK, Description: "OpenAPI document", Body: map[string]interface{}{}},
+		},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/debug/vars",
+		Summary:  "Runtime and application metrics, such as bid_batcher and auction_state_cache",
+		Tag:      "health",
+		Security: []string{BearerAuth, ApiKeyAuth},
+		Responses: []Response{
+			{Status: http.StatusOK, Description: "expvar variables", Body: map[string]interface{}{}},
+			unauthorized,
+			forbidden,
+		},
+	},
+}
+
+var v1Operations = []Operation{
+	{
+		Method:  http.MethodGet,
+		Path:    "/v1/auction",
+		Summary: "List auctions",
+		Tag:     "auction",
+		QueryParams: []QueryParam{
+			{Name: "status", Description: "active (default) or completed", Type: "string"},
+			{Name: "category", Description: "Category id or slug", Type: "string"},
+			{Name: "include_subcategories", Description: "Also list auctions in subcategories of category", Type: "boolean"},
+			{Name: "product_name", Description: "Partial product name", Type: "string"},
+		},
+		Responses: []Response{
+			{Status: http.StatusOK, Description: "Auctions", Body: []auction_controller.AuctionResponse{}},
+			badRequest,
+			internalError,
+		},
+	},
+	{
+		Method:  http.MethodGet,
+		Path:    "/v1/auction/search",
+		Summary: "Search auctions by product name, category and description, best match first",
+		Tag:     "auction",
+		QueryParams: []QueryParam{
+			{Name: "q", Description: "Words to search for (required)", Type: "string"},
+			{Name: "status", Description: "active or completed; any when omitted", Type: "string"},
+			{Name: "category", Description: "Category id or slug", Type: "string"},
+			{Name: "condition", Description: "new, used or refurbished", Type: "string"},
+			{Name: "limit", Description: "Maximum hits, 20 by default and at most 100", Type: "integer"},
+		},
+		Responses: []Response{
+			{Status: http.StatusOK, Description: "Hits, total matches and facets", Body: auction_controller.SearchResponse{}},
+			badRequest,
+			internalError,
+		},
+	},
+	{
+		Method:     http.MethodPost,
+		Path:       "/v1/auction",
+		Idempotent: true,
+		Summary:    "Create an auction",
+		Tag:        "auction",
+		Request:    auction_controller.CreateAuctionRequest{},
+		Security:   []string{BearerAuth, ApiKeyAuth},
+		Responses: []Response{
+			{Status: http.StatusCreated, Description: "Auction created", Body: auction_controller.AuctionResponse{}},
+			badRequest,
+			unauthorized,
+			forbidden,
+			internalError,
+		},
+	},
+	{
+		Method:  http.MethodGet,
+		Path:    "/v1/auction/:auctionId",
+		Summary: "Find an auction by id",
+		Tag:     "auction",
+		Responses: []Response{
+			{Status: http.StatusOK, Description: "Auction", Body: auction_controller.AuctionResponse{}},
+			badRequest,
+			notFound,
+			internalError,
+		},
+	},
+	{
+		Method:  http.MethodGet,
+		Path:    "/v1/auction/winner/:auctionId",
+		Summary: "Find the winning bid of a completed auction",
+		Tag:     "auction",
+		Responses: []Response{
+			{Status: http.StatusOK, Description: "Winning bid", Body: auction_controller.WinningInfoResponse{}},
+			badRequest,
+			notFound,
+			internalError,
+		},
+	},
+	{
+		Method:  http.MethodGet,
+		Path:    "/v1/auction/:auctionId/stream",
+		Summary: "Stream auction events (Server-Sent Events, resumable with Last-Event-ID)",
+		Tag:     "auction",
+		Responses: []Response{
+			{Status: http.StatusOK, Description: "Event stream", ContentType: eventStreamContentType, Body: ""},
+			badRequest,
+			notFound,
+		},
+	},
+	{
+		Method:     http.MethodPost,
+		Path:       "/v1/bid",
+		Idempotent: true,
+		Summary:    "Place a bid",
+		Tag:        "bid",
+		Request:    bid_usecase.BidInputDTO{},
+		Security:   []string{BearerAuth, ApiKeyAuth},
+		Responses: []Response{
+			{Status: http.StatusCreated, Description: "Bid accepted for processing"},
+			badRequest,
+			unauthorized,
+			forbidden,
+			tooManyRequests,
+		},
+	},
+	{
+		Method:  http.MethodGet,
+		Path:    "/v1/bid/:auctionId",
+		Summary: "List the bids of an auction",
+		Tag:     "bid",
+		Responses: []Response{
+			{Status: http.StatusOK, Description: "Bids", Body: []bid_usecase.BidOutputDTO{}},
+			badRequest,
+			internalError,
+		},
+	},
+	{
+		Method:  http.MethodGet,
+		Path:    "/v1/user/:userId",
+		Summary: "Find a user by id",
+		Tag:     "user",
+		Responses: []Response{
+			{Status: http.StatusOK, Description: "User", Body: user_usecase.UserOutputDTO{}},
+			badRequest,
+			notFound,
+			internalError,
+		},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/v1/webhooks",
+		Summary:  "Register a webhook owned by the caller",
+		Tag:      "webhook",
+		Request:  webhook_controller.CreateWebhookRequest{},
+		Security: []string{BearerAuth, ApiKeyAuth},
+		Responses: []Response{
+			{Status: http.StatusCreated, Description: "Webhook registered", Body: webhook_usecase.WebhookOutputDTO{}},
+			badRequest,
+			unauthorized,
+			forbidden,
+			internalError,
+		},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/v1/webhooks/:webhookId/deliveries",
+		Summary:  "List the delivery attempts of a webhook owned by the caller",
+		Tag:      "webhook",
+		Security: []string{BearerAuth, ApiKeyAuth},
+		Responses: []Response{
+			{Status: http.StatusOK, Description: "Deliveries", Body: []webhook_usecase.DeliveryOutputDTO{}},
+			badRequest,
+			unauthorized,
+			forbidden,
+			notFound,
+			internalError,
+		},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/v1/api-keys",
+		Summary:  "Issue an API key for the caller",
+		Tag:      "api-key",
+		Request:  api_key_controller.CreateApiKeyRequest{},
+		Security: []string{BearerAuth},
+		Responses: []Response{
+			{Status: http.StatusCreated, Description: "API key; the key itself is only returned here", Body: api_key_usecase.ApiKeyOutputDTO{}},
+			badRequest,
+			unauthorized,
+			forbidden,
+			internalError,
+		},
+	},
+	{
+		Method:   http.MethodDelete,
+		Path:     "/v1/api-keys/:apiKeyId",
+		Summary:  "Revoke an API key",
+		Tag:      "api-key",
+		Security: []string{BearerAuth, ApiKeyAuth},
+		Responses: []Response{
+			{Status: http.StatusNoContent, Description: "API key revoked"},
+			badRequest,
+			unauthorized,
+			forbidden,
+			notFound,
+			internalError,
+		},
+	},
+	{
+		Method:     http.MethodPost,
+		Path:       "/v1/categories",
+		Idempotent: true,
+		Summary:    "Create a category",
+		Tag:        "category",
+		Request:    category_controller.CreateCategoryRequest{},
+		Security:   []string{BearerAuth, ApiKeyAuth},
+		Responses: []Response{
+			{Status: http.StatusCreated, Description: "Category created", Body: category_controller.CategoryResponse{}},
+			badRequest,
+			unauthorized,
+			forbidden,
+			slugInUse,
+			internalError,
+		},
+	},
+	{
+		Method:  http.MethodGet,
+		Path:    "/v1/categories",
+		Summary: "List the category tree, root categories first",
+		Tag:     "category",
+		Responses: []Response{
+			{Status: http.StatusOK, Description: "Root categories with their subcategories", Body: []category_controller.CategoryResponse{}},
+			internalError,
+		},
+	},
+	{
+		Method:  http.MethodGet,
+		Path:    "/v1/categories/:category",
+		Summary: "Find a category by id or slug, with its subcategories",
+		Tag:     "category",
+		Responses: []Response{
+			{Status: http.StatusOK, Description: "Category", Body: category_controller.CategoryResponse{}},
+			notFound,
+			internalError,
+		},
+	},
+	{
+		Method:   http.MethodPut,
+		Path:     "/v1/categories/:category",
+		Summary:  "Rename or move a category",
+		Tag:      "category",
+		Request:  category_controller.CreateCategoryRequest{},
+		Security: []string{BearerAuth, ApiKeyAuth},
+		Responses: []Response{
+			{Status: http.StatusOK, Description: "Category updated", Body: category_controller.CategoryResponse{}},
+			badRequest,
+			unauthorized,
+			forbidden,
+			notFound,
+			slugInUse,
+			internalError,
+		},
+	},
+	{
+		Method:   http.MethodDelete,
+		Path:     "/v1/categories/:category",
+		Summary:  "Delete a category without subcategories or auctions",
+		Tag:      "category",
+		Security: []string{BearerAuth, ApiKeyAuth},
+		Responses: []Response{
+			{Status: http.StatusNoContent, Description: "Category deleted"},
+			unauthorized,
+			forbidden,
+			notFound,
+			{Status: http.StatusConflict, Description: "Category still has subcategories or auctions", Body: rest_err.RestErr{}},
+			internalError,
+		},
+	},
+}
+
+// Unprefixed routes predate /v1 and are kept as a deprecated compatibility layer.
+var legacyOperations = []Operation{
+	{
+		Method:     http.MethodGet,
+		Path:       "/auction",
+		Summary:    "List auctions",
+		Deprecated: true,
+		Tag:        "auction",
+		QueryParams: []QueryParam{
+			{Name: "status", Description: "0 for active (default), 1 for completed", Type: "integer"},
+			{Name: "category", Description: "Category id or slug", Type: "string"},
+			{Name: "productName", Description: "Partial product name", Type: "string"},
+		},
+		Responses: []Response{
+			{Status: http.StatusOK, Description: "Auctions", Body: []auction_usecase.AuctionOutputDTO{}},
+			{Status: http.StatusBadRequest, Description: "Invalid status", Body: map[string]string{}},
+			internalError,
+		},
+	},
+	{
+		Method:     http.MethodGet,
+		Path:       "/auction/search",
+		Summary:    "Search auctions; same parameters and response as /v1/auction/search",
+		Deprecated: true,
+		Tag:        "auction",
+		QueryParams: []QueryParam{
+			{Name: "q", Description: "Words to search for (required)", Type: "string"},
+			{Name: "status", Description: "active or completed; any when omitted", Type: "string"},
+			{Name: "category", Description: "Category id or slug", Type: "string"},
+			{Name: "condition", Description: "new, used or refurbished", Type: "string"},
+			{Name: "limit", Description: "Maximum hits, 20 by default and at most 100", Type: "integer"},
+		},
+		Responses: []Response{
+			{Status: http.StatusOK, Description: "Hits, total matches and facets", Body: auction_controller.SearchResponse{}},
+			badRequest,
+			internalError,
+		},
+	},
+	{
+		Method:     http.MethodPost,
+		Path:       "/auction",
+		Idempotent: true,
+		Summary:    "Create an auction",
+		Deprecated: true,
+		Tag:        "auction",
+		Request:    auction_controller.CreateAuctionRequest{},
+		Security:   []string{BearerAuth},
+		Responses: []Response{
+			{Status: http.StatusCreated, Description: "Auction created", Body: auction_usecase.AuctionOutputDTO{}},
+			badRequest,
+			unauthorized,
+			forbidden,
+			internalError,
+		},
+	},
+	{
+		Method:     http.MethodGet,
+		Path:       "/auction/:auctionId",
+		Summary:    "Find an auction by id",
+		Deprecated: true,
+		Tag:        "auction",
+		Responses: []Response{
+			{Status: http.StatusOK, Description: "Auction", Body: auction_usecase.AuctionOutputDTO{}},
+			badRequest,
+			{Status: http.StatusNotFound, Description: "Auction not found", Body: map[string]string{}},
+			internalError,
+		},
+	},
+	{
+		Method:     http.MethodGet,
+		Path:       "/auction/:auctionId/stream",
+		Summary:    "Stream auction events (Server-Sent Events, resumable with Last-Event-ID)",
+		Deprecated: true,
+		Tag:        "auction",
+		Responses: []Response{
+			{Status: http.StatusOK, Description: "Event stream", ContentType: eventStreamContentType, Body: ""},
+			badRequest,
+			notFound,
+		},
+	},
+	{
+		Method:     http.MethodGet,
+		Path:       "/auction/winner/:auctionId",
+		Summary:    "Find the winning bid of a completed auction",
+		Deprecated: true,
+		Tag:        "auction",
+		Responses: []Response{
+			{Status: http.StatusOK, Description: "Winning bid", Body: auction_usecase.WinningInfoOutputDTO{}},
+			badRequest,
+			notFound,
+			internalError,
+		},
+	},
+	{
+		Method:     http.MethodPost,
+		Path:       "/bid",
+		Idempotent: true,
+		Summary:    "Place a bid",
+		Deprecated: true,
+		Tag:        "bid",
+		Request:    bid_usecase.BidInputDTO{},
+		Security:   []string{BearerAuth},
+		Responses: []Response{
+			{Status: http.StatusCreated, Description: "Bid accepted for processing"},
+			badRequest,
+			unauthorized,
+			forbidden,
+			tooManyRequests,
+		},
+	},
+	{
+		Method:     http.MethodGet,
+		Path:       "/bid/:auctionId",
+		Summary:    "List the bids of an auction",
+		Deprecated: true,
+		Tag:        "bid",
+		Responses: []Response{
+			{Status: http.StatusOK, Description: "Bids", Body: []bid_usecase.BidOutputDTO{}},
+			badRequest,
+			internalError,
+		},
+	},
+	{
+		Method:     http.MethodGet,
+		Path:       "/user/:userId",
+		Summary:    "Find a user by id",
+		Deprecated: true,
+		Tag:        "user",
+		Responses: []Response{
+			{Status: http.StatusOK, Description: "User", Body: user_usecase.UserOutputDTO{}},
+			badRequest,
+			notFound,
+			internalError,
+		},
+	},
+	{
+		Method:     http.MethodPost,
+		Path:       "/webhooks",
+		Summary:    "Register a webhook owned by the caller",
+		Deprecated: true,
+		Tag:        "webhook",
+		Request:    webhook_controller.CreateWebhookRequest{},
+		Security:   []string{BearerAuth, ApiKeyAuth},
+		Responses: []Response{
+			{Status: http.StatusCreated, Description: "Webhook registered", Body: webhook_usecase.WebhookOutputDTO{}},
+			badRequest,
+			unauthorized,
+			forbidden,
+			internalError,
+		},
+	},
+	{
+		Method:     http.MethodGet,
+		Path:       "/webhooks/:webhookId/deliveries",
+		Summary:    "List the delivery attempts of a webhook owned by the caller",
+		Deprecated: true,
+		Tag:        "webhook",
+		Security:   []string{BearerAuth, ApiKeyAuth},
+		Responses: []Response{
+			{Status: http.StatusOK, Description: "Deliveries", Body: []webhook_usecase.DeliveryOutputDTO{}},
+			badRequest,
+			unauthorized,
+			forbidden,
+			notFound,
+			internalError,
+		},
+	},
+}
@@ -0,0 +1,129 @@
+package openapi
+
+import (
+	"path"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+type schemaRegistry struct {
+	schemas map[string]*Schema
+}
+
+func (sr *schemaRegistry) schemaFor(value interface{}) *Schema {
+	return sr.schemaForType(reflect.TypeOf(value))
+}
+
+func (sr *schemaRegistry) schemaForType(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := sr.schemaForType(t.Elem())
+		if schema.Ref != "" {
+			return schema
+		}
+		schema.Nullable = true
+		return schema
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: sr.schemaForType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: sr.schemaForType(t.Elem())}
+	case reflect.Interface:
+		return &Schema{}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Struct:
+		if t == timeType {
+			return &Schema{Type: "string", Format: "date-time"}
+		}
+		return sr.structRef(t)
+	default:
+		return &Schema{}
+	}
+}
+
+func (sr *schemaRegistry) structRef(t reflect.Type) *Schema {
+	name := path.Base(t.PkgPath()) + "." + t.Name()
+	ref := &Schema{Ref: "#/components/schemas/" + name}
+
+	if _, ok := sr.schemas[name]; ok {
+		return ref
+	}
+
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	sr.schemas[name] = schema
+
+	usesBinding := false
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("binding") != "" {
+			usesBinding = true
+		}
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, omitEmpty := jsonName(field)
+		if name == "-" {
+			continue
+		}
+
+		property := sr.schemaForType(field.Type)
+		if enums := field.Tag.Get("enums"); enums != "" {
+			enumSchema := property
+			if property.Items != nil {
+				enumSchema = property.Items
+			}
+			for _, value := range strings.Split(enums, ",") {
+				enumSchema.Enum = append(enumSchema.Enum, value)
+			}
+		}
+		schema.Properties[name] = property
+
+		// Request types declare required fields through gin's binding tag;
+		// every other non-optional field is always present in the JSON.
+		if usesBinding {
+			if strings.Contains(field.Tag.Get("binding"), "required") {
+				schema.Required = append(schema.Required, name)
+			}
+		} else if !omitEmpty && field.Type.Kind() != reflect.Ptr {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return ref
+}
+
+func jsonName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "" {
+		return field.Name, false
+	}
+
+	parts := strings.Split(tag, ",")
+	name := parts[0]
+	if name == "" {
+		name = field.Name
+	}
+
+	omitEmpty := false
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			omitEmpty = true
+		}
+	}
+
+	return name, omitEmpty
+}
@@ -0,0 +1,95 @@
+package routes
+
+import (
+	"context"
+	"expvar"
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/infra/api/graphql/graphql_server"
+	"fullcycle-auction_go/internal/infra/api/web/controller/api_key_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/auction_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/bid_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/category_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/live_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/user_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/webhook_controller"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/api/web/openapi"
+	"fullcycle-auction_go/internal/infra/idempotency"
+	"fullcycle-auction_go/internal/infra/rate_limiter"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Controllers struct {
+	UserController     *user_controller.UserController
+	BidController      *bid_controller.BidController
+	AuctionController  *auction_controller.AuctionController
+	WebhookController  *webhook_controller.WebhookController
+	LiveController     *live_controller.LiveController
+	GraphQLServer      *graphql_server.GraphQLServer
+	ApiKeyController   *api_key_controller.ApiKeyController
+	CategoryController *category_controller.CategoryController
+}
+
+type Middlewares struct {
+	Authenticator    *auth.Authenticator
+	RateLimiter      rate_limiter.RateLimiterInterface
+	IdempotencyStore idempotency.IdempotencyStoreInterface
+	// Shutdown is done once the server starts shutting down; streaming
+	// routes end their streams when it is.
+	Shutdown context.Context
+}
+
+func Register(router *gin.Engine, controllers Controllers, middlewares Middlewares) {
+	authenticate := middleware.Authenticate(middlewares.Authenticator)
+	manageAuctions := middleware.RequireScope(auth.ScopeManageAuctions)
+	idempotent := middleware.Idempotency(middlewares.IdempotencyStore)
+	streaming := middleware.CancelOnShutdown(middlewares.Shutdown)
+	read := middleware.TimeoutFromEnv("REQUEST_TIMEOUT_READ", 5*time.Second)
+	write := middleware.TimeoutFromEnv("REQUEST_TIMEOUT_WRITE", 10*time.Second)
+	bidTimeout := middleware.TimeoutFromEnv("REQUEST_TIMEOUT_BID", 5*time.Second)
+	bid := middleware.RequireScope(auth.ScopeBid)
+	bidRateLimit := middleware.RateLimit(rate_limiter.NewBidRouteLimit(middlewares.RateLimiter))
+
+	legacy := router.Group("", middleware.Deprecated("/v1"), authenticate)
+	legacy.GET("/auction", read, controllers.AuctionController.FindAuctions)
+	legacy.GET("/auction/search", read, controllers.AuctionController.SearchAuctionsV1)
+	legacy.GET("/auction/:auctionId", read, controllers.AuctionController.FindAuctionById)
+	legacy.GET("/auction/:auctionId/stream", streaming, controllers.AuctionController.StreamAuctionEvents)
+	legacy.POST("/auction", write, manageAuctions, idempotent, controllers.AuctionController.CreateAuction)
+	legacy.GET("/auction/winner/:auctionId", read, controllers.AuctionController.FindWinningBidByAuctionId)
+	legacy.POST("/bid", bidTimeout, bid, bidRateLimit, idempotent, controllers.BidController.CreateBid)
+	legacy.GET("/bid/:auctionId", read, controllers.BidController.FindBidByAuctionId)
+	legacy.GET("/user/:userId", read, controllers.UserController.FindUserById)
+	legacy.POST("/webhooks", write, manageAuctions, controllers.WebhookController.CreateWebhook)
+	legacy.GET("/webhooks/:webhookId/deliveries", read, manageAuctions, controllers.WebhookController.FindDeliveriesByWebhookId)
+
+	v1 := router.Group("/v1", authenticate)
+	v1.GET("/auction", read, controllers.AuctionController.FindAuctionsV1)
+	v1.GET("/auction/search", read, controllers.AuctionController.SearchAuctionsV1)
+	v1.GET("/auction/:auctionId", read, controllers.AuctionController.FindAuctionByIdV1)
+	v1.GET("/auction/:auctionId/stream", streaming, controllers.AuctionController.StreamAuctionEvents)
+	v1.POST("/auction", write, manageAuctions, idempotent, controllers.AuctionController.CreateAuctionV1)
+	v1.GET("/auction/winner/:auctionId", read, controllers.AuctionController.FindWinningBidByAuctionIdV1)
+	v1.POST("/bid", bidTimeout, bid, bidRateLimit, idempotent, controllers.BidController.CreateBid)
+	v1.GET("/bid/:auctionId", read, controllers.BidController.FindBidByAuctionId)
+	v1.GET("/user/:userId", read, controllers.UserController.FindUserById)
+	v1.POST("/webhooks", write, manageAuctions, controllers.WebhookController.CreateWebhook)
+	v1.GET("/webhooks/:webhookId/deliveries", read, manageAuctions, controllers.WebhookController.FindDeliveriesByWebhookId)
+	v1.POST("/api-keys", write, middleware.RequireAuthentication(), controllers.ApiKeyController.CreateApiKey)
+	v1.DELETE("/api-keys/:apiKeyId", write, middleware.RequireAuthentication(), controllers.ApiKeyController.RevokeApiKey)
+	v1.POST("/categories", write, manageAuctions, idempotent, controllers.CategoryController.CreateCategory)
+	v1.GET("/categories", read, controllers.CategoryController.FindCategories)
+	v1.GET("/categories/:category", read, controllers.CategoryController.FindCategory)
+	v1.PUT("/categories/:category", write, manageAuctions, controllers.CategoryController.UpdateCategory)
+	v1.DELETE("/categories/:category", write, manageAuctions, controllers.CategoryController.DeleteCategory)
+
+	router.GET("/live", authenticate, controllers.LiveController.ServeWebSocket)
+	router.POST("/graphql", authenticate, middleware.SkipForEventStreams(read), streaming, controllers.GraphQLServer.Handle)
+	router.GET("/health", func(c *gin.Context) {
+		c.JSON(200, gin.H{"status": "ok"})
+	})
+	router.GET("/openapi.json", openapi.Handler(router))
+	router.GET("/debug/vars", authenticate, middleware.RequirePermission(auth.ViewMetrics), gin.WrapH(expvar.Handler()))
+}
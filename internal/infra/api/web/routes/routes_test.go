@@ -0,0 +1,175 @@
+package routes_test
+
+import (
+	"context"
+	"encoding/json"
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/infra/api/web/openapi"
+	"fullcycle-auction_go/internal/infra/api/web/routes"
+	"fullcycle-auction_go/internal/infra/rate_limiter"
+	"fullcycle-auction_go/internal/internal_error"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAPISpecMatchesRegisteredRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	routes.Register(router, routes.Controllers{}, routes.Middlewares{})
+
+	document, err := openapi.Generate(router.Routes(), openapi.Operations, "test")
+	assert.NoError(t, err)
+
+	operations := 0
+	for _, pathItem := range document.Paths {
+		operations += len(pathItem)
+	}
+	assert.Equal(t, len(router.Routes()), operations)
+}
+
+func TestOpenAPISpecDetectsUndocumentedRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	routes.Register(router, routes.Controllers{}, routes.Middlewares{})
+	router.DELETE("/auction/:auctionId", func(c *gin.Context) {})
+
+	_, err := openapi.Generate(router.Routes(), openapi.Operations, "test")
+	assert.ErrorContains(t, err, "DELETE /auction/:auctionId")
+}
+
+func TestOpenAPISpecIsServed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	routes.Register(router, routes.Controllers{}, routes.Middlewares{})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var document openapi.Document
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &document))
+	assert.Equal(t, "3.0.3", document.OpenAPI)
+
+	createAuction := document.Paths["/auction"]["post"]
+	assert.Equal(t, "#/components/schemas/auction_controller.CreateAuctionRequest",
+		createAuction.RequestBody.Content["application/json"].Schema.Ref)
+	assert.ElementsMatch(t, []string{"product_name", "category", "description", "condition"},
+		document.Components.Schemas["auction_controller.CreateAuctionRequest"].Required)
+	assert.Contains(t, document.Components.Schemas["auction_usecase.AuctionOutputDTO"].Properties, "ProductName")
+	assert.Contains(t, document.Components.Schemas, "rest_err.RestErr")
+	assert.Equal(t, []string{"auctionId"}, []string{document.Paths["/bid/{auctionId}"]["get"].Parameters[0].Name})
+}
+
+func TestLegacyRoutesAreDeprecated(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	routes.Register(router, routes.Controllers{}, routes.Middlewares{})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	var document openapi.Document
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &document))
+	assert.True(t, document.Paths["/auction"]["get"].Deprecated)
+	assert.False(t, document.Paths["/v1/auction"]["get"].Deprecated)
+	assert.Equal(t, []interface{}{"new", "used", "refurbished"},
+		document.Components.Schemas["auction_controller.AuctionResponse"].Properties["condition"].Enum)
+}
+
+type apiKeyVerifierStub struct{}
+
+func (apiKeyVerifierStub) VerifyApiKey(ctx context.Context, key string) (*auth.Identity, *internal_error.InternalError) {
+	roles := map[string]auth.Role{"read-only-key": auth.RoleBidder, "admin-read-only-key": auth.RoleAdmin}
+	role, ok := roles[key]
+	if !ok {
+		return nil, internal_error.NewUnauthorizedError("Invalid api key")
+	}
+	return &auth.Identity{
+		UserId:   "6b0d6f1c-0b5e-4d6b-8f8e-2b9d1a3c4e5f",
+		Roles:    []auth.Role{role},
+		Scopes:   []auth.Scope{auth.ScopeReadOnly},
+		ApiKeyId: "2c8a3f1e-5d47-4b8b-9a0e-7f3b6c1d2e4f",
+	}, nil
+}
+
+func TestWriteRoutesEnforceApiKeyScopes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	routes.Register(router, routes.Controllers{}, routes.Middlewares{
+		Authenticator: auth.NewAuthenticator(nil, apiKeyVerifierStub{}),
+		RateLimiter:   rate_limiter.NewMemoryRateLimiter(),
+	})
+
+	testCases := []struct {
+		authorization string
+		status        int
+	}{
+		{"", http.StatusUnauthorized},
+		{"ApiKey unknown", http.StatusUnauthorized},
+		{"Basic dXNlcjpwYXNz", http.StatusUnauthorized},
+		{"ApiKey read-only-key", http.StatusForbidden},
+	}
+
+	for _, path := range []string{"/v1/bid", "/v1/auction", "/bid"} {
+		for _, testCase := range testCases {
+			request := httptest.NewRequest(http.MethodPost, path, strings.NewReader("{}"))
+			if testCase.authorization != "" {
+				request.Header.Set("Authorization", testCase.authorization)
+			}
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+			assert.Equal(t, testCase.status, recorder.Code, "%s with %q", path, testCase.authorization)
+		}
+	}
+}
+
+func TestMetricsRequireAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	routes.Register(router, routes.Controllers{}, routes.Middlewares{
+		Authenticator: auth.NewAuthenticator(nil, apiKeyVerifierStub{}),
+		RateLimiter:   rate_limiter.NewMemoryRateLimiter(),
+	})
+
+	testCases := []struct {
+		authorization string
+		status        int
+	}{
+		{"", http.StatusUnauthorized},
+		{"ApiKey read-only-key", http.StatusForbidden},
+		{"ApiKey admin-read-only-key", http.StatusOK},
+	}
+
+	for _, testCase := range testCases {
+		request := httptest.NewRequest(http.MethodGet, "/debug/vars", nil)
+		if testCase.authorization != "" {
+			request.Header.Set("Authorization", testCase.authorization)
+		}
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, testCase.status, recorder.Code, "%q", testCase.authorization)
+	}
+}
+
+func TestGraphQLRejectsInvalidCredentials(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	routes.Register(router, routes.Controllers{}, routes.Middlewares{
+		Authenticator: auth.NewAuthenticator(nil, apiKeyVerifierStub{}),
+		RateLimiter:   rate_limiter.NewMemoryRateLimiter(),
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query": "{ __typename }"}`))
+	request.Header.Set("Authorization", "ApiKey unknown")
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
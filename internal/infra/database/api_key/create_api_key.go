@@ -0,0 +1,116 @@
+package api_key
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/entity/api_key_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ApiKeyEntityMongo struct {
+	Id        string   `bson:"_id"`
+	UserId    string   `bson:"user_id"`
+	Name      string   `bson:"name"`
+	Prefix    string   `bson:"prefix"`
+	Hash      string   `bson:"hash"`
+	Scopes    []string `bson:"scopes"`
+	Roles     []string `bson:"roles"`
+	Timestamp int64    `bson:"timestamp"`
+	RevokedAt int64    `bson:"revoked_at,omitempty"`
+}
+
+type ApiKeyRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewApiKeyRepository(database *mongo.Database) *ApiKeyRepository {
+	return &ApiKeyRepository{
+		Collection: database.Collection("api_keys"),
+	}
+}
+
+func (ar *ApiKeyRepository) CreateApiKey(
+	ctx context.Context,
+	apiKeyEntity *api_key_entity.ApiKey) *internal_error.InternalError {
+
+	scopes := make([]string, 0, len(apiKeyEntity.Scopes))
+	for _, scope := range apiKeyEntity.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	roles := make([]string, 0, len(apiKeyEntity.Roles))
+	for _, role := range apiKeyEntity.Roles {
+		roles = append(roles, string(role))
+	}
+
+	apiKeyEntityMongo := &ApiKeyEntityMongo{
+		Id:        apiKeyEntity.Id,
+		UserId:    apiKeyEntity.UserId,
+		Name:      apiKeyEntity.Name,
+		Prefix:    apiKeyEntity.Prefix,
+		Hash:      apiKeyEntity.Hash,
+		Scopes:    scopes,
+		Roles:     roles,
+		Timestamp: apiKeyEntity.Timestamp.Unix(),
+	}
+
+	if _, err := ar.Collection.InsertOne(ctx, apiKeyEntityMongo); err != nil {
+		logger.Error("Error inserting api key", err)
+		return internal_error.NewInternalServerError("Error inserting api key")
+	}
+
+	return nil
+}
+
+func (ar *ApiKeyRepository) RevokeApiKey(
+	ctx context.Context, id string, revokedAt time.Time) *internal_error.InternalError {
+
+	result, err := ar.Collection.UpdateOne(ctx,
+		bson.M{"_id": id, "revoked_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"revoked_at": revokedAt.Unix()}})
+	if err != nil {
+		logger.Error("Error revoking api key", err)
+		return internal_error.NewInternalServerError("Error revoking api key")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError("Api key not found or already revoked")
+	}
+
+	return nil
+}
+
+func toEntity(value ApiKeyEntityMongo) *api_key_entity.ApiKey {
+	scopes := make([]auth.Scope, 0, len(value.Scopes))
+	for _, scope := range value.Scopes {
+		scopes = append(scopes, auth.Scope(scope))
+	}
+
+	roles := make([]auth.Role, 0, len(value.Roles))
+	for _, role := range value.Roles {
+		roles = append(roles, auth.Role(role))
+	}
+
+	apiKey := &api_key_entity.ApiKey{
+		Id:        value.Id,
+		UserId:    value.UserId,
+		Name:      value.Name,
+		Prefix:    value.Prefix,
+		Hash:      value.Hash,
+		Scopes:    scopes,
+		Roles:     roles,
+		Timestamp: time.Unix(value.Timestamp, 0),
+	}
+
+	if value.RevokedAt != 0 {
+		revokedAt := time.Unix(value.RevokedAt, 0)
+		apiKey.RevokedAt = &revokedAt
+	}
+
+	return apiKey
+}
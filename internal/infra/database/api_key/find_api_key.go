@@ -0,0 +1,48 @@
+package api_key
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/api_key_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func (ar *ApiKeyRepository) FindApiKeyById(
+	ctx context.Context, id string) (*api_key_entity.ApiKey, *internal_error.InternalError) {
+	filter := bson.M{"_id": id}
+
+	var apiKeyEntityMongo ApiKeyEntityMongo
+	if err := ar.Collection.FindOne(ctx, filter).Decode(&apiKeyEntityMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Api key not found with this id = %s", id))
+		}
+
+		logger.Error("Error trying to find api key by id", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find api key by id")
+	}
+
+	return toEntity(apiKeyEntityMongo), nil
+}
+
+func (ar *ApiKeyRepository) FindApiKeyByHash(
+	ctx context.Context, hash string) (*api_key_entity.ApiKey, *internal_error.InternalError) {
+	filter := bson.M{"hash": hash}
+
+	var apiKeyEntityMongo ApiKeyEntityMongo
+	if err := ar.Collection.FindOne(ctx, filter).Decode(&apiKeyEntityMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, internal_error.NewNotFoundError("Api key not found")
+		}
+
+		logger.Error("Error trying to find api key by hash", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find api key")
+	}
+
+	return toEntity(apiKeyEntityMongo), nil
+}
@@ -0,0 +1,105 @@
+package api_key
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/entity/api_key_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sync"
+	"time"
+)
+
+// MemoryApiKeyRepository keeps API keys in process memory with the same
+// behavior as ApiKeyRepository, including the unique hash index.
+type MemoryApiKeyRepository struct {
+	mutex   sync.RWMutex
+	apiKeys map[string]*api_key_entity.ApiKey
+}
+
+func NewMemoryApiKeyRepository() *MemoryApiKeyRepository {
+	return &MemoryApiKeyRepository{apiKeys: make(map[string]*api_key_entity.ApiKey)}
+}
+
+func (mr *MemoryApiKeyRepository) CreateApiKey(
+	ctx context.Context,
+	apiKeyEntity *api_key_entity.ApiKey) *internal_error.InternalError {
+
+	mr.mutex.Lock()
+	defer mr.mutex.Unlock()
+
+	for _, apiKey := range mr.apiKeys {
+		if apiKey.Id == apiKeyEntity.Id || apiKey.Hash == apiKeyEntity.Hash {
+			logger.Error("Error inserting api key", fmt.Errorf("duplicate api key %s", apiKeyEntity.Id))
+			return internal_error.NewInternalServerError("Error inserting api key")
+		}
+	}
+
+	apiKey := *apiKeyEntity
+	apiKey.Scopes = append([]auth.Scope{}, apiKeyEntity.Scopes...)
+	apiKey.Roles = append([]auth.Role{}, apiKeyEntity.Roles...)
+	apiKey.Timestamp = time.Unix(apiKeyEntity.Timestamp.Unix(), 0)
+	apiKey.RevokedAt = nil
+	mr.apiKeys[apiKey.Id] = &apiKey
+
+	return nil
+}
+
+func (mr *MemoryApiKeyRepository) RevokeApiKey(
+	ctx context.Context, id string, revokedAt time.Time) *internal_error.InternalError {
+
+	mr.mutex.Lock()
+	defer mr.mutex.Unlock()
+
+	apiKey, ok := mr.apiKeys[id]
+	if !ok || apiKey.RevokedAt != nil {
+		return internal_error.NewNotFoundError("Api key not found or already revoked")
+	}
+
+	revokedAt = time.Unix(revokedAt.Unix(), 0)
+	apiKey.RevokedAt = &revokedAt
+
+	return nil
+}
+
+func (mr *MemoryApiKeyRepository) FindApiKeyById(
+	ctx context.Context, id string) (*api_key_entity.ApiKey, *internal_error.InternalError) {
+
+	mr.mutex.RLock()
+	defer mr.mutex.RUnlock()
+
+	apiKey, ok := mr.apiKeys[id]
+	if !ok {
+		return nil, internal_error.NewNotFoundError(fmt.Sprintf("Api key not found with this id = %s", id))
+	}
+
+	return copyApiKey(apiKey), nil
+}
+
+func (mr *MemoryApiKeyRepository) FindApiKeyByHash(
+	ctx context.Context, hash string) (*api_key_entity.ApiKey, *internal_error.InternalError) {
+
+	mr.mutex.RLock()
+	defer mr.mutex.RUnlock()
+
+	for _, apiKey := range mr.apiKeys {
+		if apiKey.Hash == hash {
+			return copyApiKey(apiKey), nil
+		}
+	}
+
+	return nil, internal_error.NewNotFoundError("Api key not found")
+}
+
+func copyApiKey(apiKey *api_key_entity.ApiKey) *api_key_entity.ApiKey {
+	apiKeyCopy := *apiKey
+	apiKeyCopy.Scopes = append([]auth.Scope{}, apiKey.Scopes...)
+	apiKeyCopy.Roles = append([]auth.Role{}, apiKey.Roles...)
+	if apiKey.RevokedAt != nil {
+		revokedAt := *apiKey.RevokedAt
+		apiKeyCopy.RevokedAt = &revokedAt
+	}
+
+	return &apiKeyCopy
+}
@@ -0,0 +1,154 @@
+package api_key
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/entity/api_key_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"strings"
+	"time"
+)
+
+const apiKeyColumns = "id, user_id, name, prefix, hash, scopes, roles, timestamp, revoked_at"
+
+// SQLApiKeyRepository stores API keys in the api_keys table of a SQL
+// database, created by the sqldb migrations. Scopes and roles are stored
+// comma-separated.
+type SQLApiKeyRepository struct {
+	DB *sql.DB
+}
+
+func NewSQLApiKeyRepository(db *sql.DB) *SQLApiKeyRepository {
+	return &SQLApiKeyRepository{DB: db}
+}
+
+func (sr *SQLApiKeyRepository) CreateApiKey(
+	ctx context.Context,
+	apiKeyEntity *api_key_entity.ApiKey) *internal_error.InternalError {
+
+	scopes := make([]string, 0, len(apiKeyEntity.Scopes))
+	for _, scope := range apiKeyEntity.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	roles := make([]string, 0, len(apiKeyEntity.Roles))
+	for _, role := range apiKeyEntity.Roles {
+		roles = append(roles, string(role))
+	}
+
+	if _, err := sr.DB.ExecContext(ctx,
+		"INSERT INTO api_keys ("+apiKeyColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULL)",
+		apiKeyEntity.Id,
+		apiKeyEntity.UserId,
+		apiKeyEntity.Name,
+		apiKeyEntity.Prefix,
+		apiKeyEntity.Hash,
+		strings.Join(scopes, ","),
+		strings.Join(roles, ","),
+		apiKeyEntity.Timestamp.Unix()); err != nil {
+		logger.Error("Error inserting api key", err)
+		return internal_error.NewInternalServerError("Error inserting api key")
+	}
+
+	return nil
+}
+
+func (sr *SQLApiKeyRepository) RevokeApiKey(
+	ctx context.Context, id string, revokedAt time.Time) *internal_error.InternalError {
+
+	result, err := sr.DB.ExecContext(ctx,
+		"UPDATE api_keys SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL",
+		revokedAt.Unix(), id)
+	if err != nil {
+		logger.Error("Error revoking api key", err)
+		return internal_error.NewInternalServerError("Error revoking api key")
+	}
+
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return internal_error.NewNotFoundError("Api key not found or already revoked")
+	}
+
+	return nil
+}
+
+func (sr *SQLApiKeyRepository) FindApiKeyById(
+	ctx context.Context, id string) (*api_key_entity.ApiKey, *internal_error.InternalError) {
+
+	apiKey, err := scanApiKey(sr.DB.QueryRowContext(ctx,
+		"SELECT "+apiKeyColumns+" FROM api_keys WHERE id = $1", id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Api key not found with this id = %s", id))
+		}
+		logger.Error("Error trying to find api key by id", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find api key by id")
+	}
+
+	return apiKey, nil
+}
+
+func (sr *SQLApiKeyRepository) FindApiKeyByHash(
+	ctx context.Context, hash string) (*api_key_entity.ApiKey, *internal_error.InternalError) {
+
+	apiKey, err := scanApiKey(sr.DB.QueryRowContext(ctx,
+		"SELECT "+apiKeyColumns+" FROM api_keys WHERE hash = $1", hash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, internal_error.NewNotFoundError("Api key not found")
+		}
+		logger.Error("Error trying to find api key by hash", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find api key")
+	}
+
+	return apiKey, nil
+}
+
+func scanApiKey(row *sql.Row) (*api_key_entity.ApiKey, error) {
+	var apiKey api_key_entity.ApiKey
+	var scopes, roles string
+	var timestamp int64
+	var revokedAt sql.NullInt64
+	if err := row.Scan(
+		&apiKey.Id,
+		&apiKey.UserId,
+		&apiKey.Name,
+		&apiKey.Prefix,
+		&apiKey.Hash,
+		&scopes,
+		&roles,
+		&timestamp,
+		&revokedAt); err != nil {
+		return nil, err
+	}
+
+	apiKey.Scopes = make([]auth.Scope, 0)
+	for _, scope := range splitList(scopes) {
+		apiKey.Scopes = append(apiKey.Scopes, auth.Scope(scope))
+	}
+
+	apiKey.Roles = make([]auth.Role, 0)
+	for _, role := range splitList(roles) {
+		apiKey.Roles = append(apiKey.Roles, auth.Role(role))
+	}
+
+	apiKey.Timestamp = time.Unix(timestamp, 0)
+	if revokedAt.Valid {
+		value := time.Unix(revokedAt.Int64, 0)
+		apiKey.RevokedAt = &value
+	}
+
+	return &apiKey, nil
+}
+
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	return strings.Split(value, ",")
+}
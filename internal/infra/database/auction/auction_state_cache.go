@@ -0,0 +1,152 @@
+package auction
+
+import (
+	"container/list"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// AuctionState is what bid validation needs to know about an auction.
+type AuctionState struct {
+	Status  auction_entity.AuctionStatus
+	EndTime time.Time
+}
+
+// IsOpen reports whether the auction still accepts bids at now.
+func (as AuctionState) IsOpen(now time.Time) bool {
+	return as.Status == auction_entity.Active && now.Before(as.EndTime)
+}
+
+type AuctionStateCacheStats struct {
+	Hits          int64 `json:"hits"`
+	Misses        int64 `json:"misses"`
+	Evictions     int64 `json:"evictions"`
+	Invalidations int64 `json:"invalidations"`
+	Size          int   `json:"size"`
+}
+
+// AuctionStateCache keeps the state of up to maxEntries auctions for ttl,
+// evicting the least recently used one when full. The closer invalidates an
+// auction as soon as it completes it; the ttl bounds how long a change made
+// elsewhere, such as by another instance, can go unnoticed.
+type AuctionStateCache struct {
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mutex   sync.Mutex
+	entries map[string]*list.Element
+	// recency holds *auctionStateEntry, most recently used first.
+	recency *list.List
+	stats   AuctionStateCacheStats
+}
+
+type auctionStateEntry struct {
+	auctionId string
+	state     AuctionState
+	expiresAt time.Time
+}
+
+func NewAuctionStateCache(ttl time.Duration, maxEntries int) *AuctionStateCache {
+	if maxEntries < 1 {
+		maxEntries = 1
+	}
+
+	return &AuctionStateCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		entries:    make(map[string]*list.Element),
+		recency:    list.New(),
+	}
+}
+
+// NewAuctionStateCacheFromEnv reads AUCTION_STATE_CACHE_TTL (default 30s) and
+// AUCTION_STATE_CACHE_SIZE (default 10000).
+func NewAuctionStateCacheFromEnv() *AuctionStateCache {
+	ttl, err := time.ParseDuration(os.Getenv("AUCTION_STATE_CACHE_TTL"))
+	if err != nil || ttl <= 0 {
+		ttl = 30 * time.Second
+	}
+
+	maxEntries, err := strconv.Atoi(os.Getenv("AUCTION_STATE_CACHE_SIZE"))
+	if err != nil || maxEntries <= 0 {
+		maxEntries = 10000
+	}
+
+	return NewAuctionStateCache(ttl, maxEntries)
+}
+
+func (asc *AuctionStateCache) Get(auctionId string) (AuctionState, bool) {
+	asc.mutex.Lock()
+	defer asc.mutex.Unlock()
+
+	element, ok := asc.entries[auctionId]
+	if !ok {
+		asc.stats.Misses++
+		return AuctionState{}, false
+	}
+
+	entry := element.Value.(*auctionStateEntry)
+	if !asc.now().Before(entry.expiresAt) {
+		asc.remove(element)
+		asc.stats.Misses++
+		return AuctionState{}, false
+	}
+
+	asc.recency.MoveToFront(element)
+	asc.stats.Hits++
+	return entry.state, true
+}
+
+func (asc *AuctionStateCache) Set(auctionId string, state AuctionState) {
+	asc.mutex.Lock()
+	defer asc.mutex.Unlock()
+
+	expiresAt := asc.now().Add(asc.ttl)
+	if element, ok := asc.entries[auctionId]; ok {
+		entry := element.Value.(*auctionStateEntry)
+		entry.state = state
+		entry.expiresAt = expiresAt
+		asc.recency.MoveToFront(element)
+		return
+	}
+
+	if asc.recency.Len() >= asc.maxEntries {
+		asc.remove(asc.recency.Back())
+		asc.stats.Evictions++
+	}
+
+	asc.entries[auctionId] = asc.recency.PushFront(&auctionStateEntry{
+		auctionId: auctionId,
+		state:     state,
+		expiresAt: expiresAt,
+	})
+}
+
+func (asc *AuctionStateCache) Invalidate(auctionId string) {
+	asc.mutex.Lock()
+	defer asc.mutex.Unlock()
+
+	if element, ok := asc.entries[auctionId]; ok {
+		asc.remove(element)
+		asc.stats.Invalidations++
+	}
+}
+
+func (asc *AuctionStateCache) Stats() AuctionStateCacheStats {
+	asc.mutex.Lock()
+	defer asc.mutex.Unlock()
+
+	stats := asc.stats
+	stats.Size = asc.recency.Len()
+	return stats
+}
+
+func (asc *AuctionStateCache) remove(element *list.Element) {
+	asc.recency.Remove(element)
+	delete(asc.entries, element.Value.(*auctionStateEntry).auctionId)
+}
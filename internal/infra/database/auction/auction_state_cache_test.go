@@ -0,0 +1,70 @@
+package auction
+
+import (
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuctionStateCacheExpiresEntries(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	cache := NewAuctionStateCache(time.Minute, 10)
+	cache.now = func() time.Time { return now }
+
+	state := AuctionState{Status: auction_entity.Active, EndTime: now.Add(time.Hour)}
+	cache.Set("a", state)
+
+	cached, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, state, cached)
+
+	now = now.Add(time.Minute)
+	_, ok = cache.Get("a")
+	assert.False(t, ok)
+
+	assert.Equal(t, AuctionStateCacheStats{Hits: 1, Misses: 1}, cache.Stats())
+}
+
+func TestAuctionStateCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewAuctionStateCache(time.Minute, 2)
+	cache.Set("a", AuctionState{})
+	cache.Set("b", AuctionState{})
+
+	_, ok := cache.Get("a")
+	assert.True(t, ok)
+
+	cache.Set("c", AuctionState{})
+
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+	_, ok = cache.Get("a")
+	assert.True(t, ok)
+	_, ok = cache.Get("c")
+	assert.True(t, ok)
+
+	stats := cache.Stats()
+	assert.Equal(t, int64(1), stats.Evictions)
+	assert.Equal(t, 2, stats.Size)
+}
+
+func TestAuctionStateCacheInvalidate(t *testing.T) {
+	cache := NewAuctionStateCache(time.Minute, 10)
+	cache.Set("a", AuctionState{Status: auction_entity.Active})
+
+	cache.Invalidate("a")
+	cache.Invalidate("unknown")
+
+	_, ok := cache.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, int64(1), cache.Stats().Invalidations)
+}
+
+func TestAuctionStateIsOpen(t *testing.T) {
+	now := time.Now()
+
+	assert.True(t, AuctionState{Status: auction_entity.Active, EndTime: now.Add(time.Second)}.IsOpen(now))
+	assert.False(t, AuctionState{Status: auction_entity.Active, EndTime: now}.IsOpen(now))
+	assert.False(t, AuctionState{Status: auction_entity.Completed, EndTime: now.Add(time.Hour)}.IsOpen(now))
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/clock"
 	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
 	"fullcycle-auction_go/internal/internal_error"
 	"os"
 	"sync"
@@ -17,19 +19,23 @@ import (
 )
 
 type AuctionEntityMongo struct {
-	Id          string                          `bson:"_id"`
-	ProductName string                          `bson:"product_name"`
-	Category    string                          `bson:"category"`
-	Description string                          `bson:"description"`
-	Condition   auction_entity.ProductCondition `bson:"condition"`
-	Status      auction_entity.AuctionStatus    `bson:"status"`
-	Timestamp   int64                           `bson:"timestamp"`
-	EndTime     int64                           `bson:"end_time"`
+	Id           string                          `bson:"_id"`
+	ProductName  string                          `bson:"product_name"`
+	Category     string                          `bson:"category"`
+	CategoryName string                          `bson:"category_name"`
+	Description  string                          `bson:"description"`
+	Condition    auction_entity.ProductCondition `bson:"condition"`
+	Status       auction_entity.AuctionStatus    `bson:"status"`
+	Timestamp    int64                           `bson:"timestamp"`
+	EndTime      int64                           `bson:"end_time"`
 }
 
 type AuctionRepository struct {
-	Collection *mongo.Collection
-	closeMutex sync.Mutex
+	Collection      *mongo.Collection
+	EventDispatcher event_entity.EventDispatcherInterface
+	StateCache      *AuctionStateCache
+	Clock           clock.Clock
+	closeMutex      sync.Mutex
 }
 
 func (ar *AuctionRepository) FindAuctionById(
@@ -51,24 +57,53 @@ func (ar *AuctionRepository) FindAuctionById(
 	}
 
 	return &auction_entity.Auction{
-		Id:          auctionEntityMongo.Id,
-		ProductName: auctionEntityMongo.ProductName,
-		Category:    auctionEntityMongo.Category,
-		Description: auctionEntityMongo.Description,
-		Condition:   auctionEntityMongo.Condition,
-		Status:      auctionEntityMongo.Status,
-		Timestamp:   time.Unix(auctionEntityMongo.Timestamp, 0),
+		Id:           auctionEntityMongo.Id,
+		ProductName:  auctionEntityMongo.ProductName,
+		Category:     auctionEntityMongo.Category,
+		CategoryName: auctionEntityMongo.CategoryName,
+		Description:  auctionEntityMongo.Description,
+		Condition:    auctionEntityMongo.Condition,
+		Status:       auctionEntityMongo.Status,
+		Timestamp:    time.Unix(auctionEntityMongo.Timestamp, 0),
+		EndTime:      time.Unix(auctionEntityMongo.EndTime, 0),
 	}, nil
 }
 
-func NewAuctionRepository(database *mongo.Database) *AuctionRepository {
-	repo := &AuctionRepository{
-		Collection: database.Collection("auctions"),
+// FindAuctionState returns the auction's status and stored end time, from
+// StateCache when possible. A lookup racing the closer may cache the auction
+// as still active after the closer invalidated it, but the closer only
+// completes auctions past their end time, which AuctionState.IsOpen checks.
+func (ar *AuctionRepository) FindAuctionState(
+	ctx context.Context, id string) (AuctionState, *internal_error.InternalError) {
+
+	if state, ok := ar.StateCache.Get(id); ok {
+		return state, nil
 	}
 
-	go repo.StartAuctionCloser(context.Background())
+	auctionEntity, err := ar.FindAuctionById(ctx, id)
+	if err != nil {
+		return AuctionState{}, err
+	}
 
-	return repo
+	state := AuctionState{Status: auctionEntity.Status, EndTime: auctionEntity.EndTime}
+	ar.StateCache.Set(id, state)
+
+	return state, nil
+}
+
+func NewAuctionRepository(
+	database *mongo.Database,
+	eventDispatcher event_entity.EventDispatcherInterface,
+	clock clock.Clock) *AuctionRepository {
+	stateCache := NewAuctionStateCacheFromEnv()
+	stateCache.now = clock.Now
+
+	return &AuctionRepository{
+		Collection:      database.Collection("auctions"),
+		EventDispatcher: eventDispatcher,
+		StateCache:      stateCache,
+		Clock:           clock,
+	}
 }
 
 func (ar *AuctionRepository) CreateAuction(
@@ -76,17 +111,18 @@ func (ar *AuctionRepository) CreateAuction(
 	auctionEntity *auction_entity.Auction) *internal_error.InternalError {
 
 	duration := getAuctionDuration()
-	endTime := time.Now().Add(duration).Unix()
+	endTime := ar.Clock.Now().Add(duration).Unix()
 
 	auctionEntityMongo := &AuctionEntityMongo{
-		Id:          auctionEntity.Id,
-		ProductName: auctionEntity.ProductName,
-		Category:    auctionEntity.Category,
-		Description: auctionEntity.Description,
-		Condition:   auctionEntity.Condition,
-		Status:      auctionEntity.Status,
-		Timestamp:   auctionEntity.Timestamp.Unix(),
-		EndTime:     endTime,
+		Id:           auctionEntity.Id,
+		ProductName:  auctionEntity.ProductName,
+		Category:     auctionEntity.Category,
+		CategoryName: auctionEntity.CategoryName,
+		Description:  auctionEntity.Description,
+		Condition:    auctionEntity.Condition,
+		Status:       auctionEntity.Status,
+		Timestamp:    auctionEntity.Timestamp.Unix(),
+		EndTime:      endTime,
 	}
 
 	_, err := ar.Collection.InsertOne(ctx, auctionEntityMongo)
@@ -94,15 +130,49 @@ func (ar *AuctionRepository) CreateAuction(
 		logger.Error("Error inserting auction", err)
 		return internal_error.NewInternalServerError("Error inserting auction")
 	}
+	auctionEntity.EndTime = time.Unix(endTime, 0)
 
 	logger.Info("Auction created",
 		zap.String("id", auctionEntity.Id),
 		zap.Int64("end_time", endTime),
 		zap.String("duration", duration.String()))
 
+	publishAuctionEvent(ar.EventDispatcher, event_entity.AuctionCreated, auctionEntity)
+
 	return nil
 }
 
+func (ar *AuctionRepository) UpdateCategoryName(
+	ctx context.Context, categoryId, name string) *internal_error.InternalError {
+
+	if _, err := ar.Collection.UpdateMany(ctx,
+		bson.M{"category": categoryId},
+		bson.M{"$set": bson.M{"category_name": name}}); err != nil {
+		logger.Error("Error updating the category name of auctions", err, zap.String("category_id", categoryId))
+		return internal_error.NewInternalServerError("Error updating the category name of auctions")
+	}
+
+	return nil
+}
+
+func publishAuctionEvent(
+	eventDispatcher event_entity.EventDispatcherInterface,
+	eventType event_entity.EventType,
+	auctionEntity *auction_entity.Auction) {
+
+	eventDispatcher.Publish(event_entity.Event{
+		Type:      eventType,
+		AuctionId: auctionEntity.Id,
+		Data: event_entity.AuctionData{
+			Id:          auctionEntity.Id,
+			ProductName: auctionEntity.ProductName,
+			Category:    auctionEntity.Category,
+			Status:      int(auctionEntity.Status),
+			EndTime:     auctionEntity.EndTime,
+		},
+	})
+}
+
 func getAuctionDuration() time.Duration {
 	durationStr := os.Getenv("AUCTION_DURATION")
 	if durationStr == "" {
@@ -117,18 +187,28 @@ func getAuctionDuration() time.Duration {
 	return duration
 }
 
+// StartAuctionCloser closes expired auctions every 10 seconds and returns once
+// ctx is cancelled.
 func (ar *AuctionRepository) StartAuctionCloser(ctx context.Context) {
-	ar.CloseExpiredAuctions(ctx)
+	runAuctionCloser(ctx, ar.Clock, ar.CloseExpiredAuctions)
+}
+
+func runAuctionCloser(
+	ctx context.Context,
+	clock clock.Clock,
+	closeExpiredAuctions func(ctx context.Context)) {
 
-	ticker := time.NewTicker(10 * time.Second)
+	closeExpiredAuctions(ctx)
+
+	ticker := clock.NewTicker(10 * time.Second)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case <-ticker.C:
-			ar.CloseExpiredAuctions(ctx)
+		case <-ticker.C():
+			closeExpiredAuctions(ctx)
 		}
 	}
 }
@@ -137,7 +217,7 @@ func (ar *AuctionRepository) CloseExpiredAuctions(ctx context.Context) {
 	ar.closeMutex.Lock()
 	defer ar.closeMutex.Unlock()
 
-	now := time.Now().Unix()
+	now := ar.Clock.Now().Unix()
 	logger.Info("Checking for expired auctions", zap.Int64("now", now))
 
 	filter := bson.M{
@@ -145,19 +225,48 @@ func (ar *AuctionRepository) CloseExpiredAuctions(ctx context.Context) {
 		"end_time": bson.M{"$lte": now},
 	}
 
-	update := bson.M{"$set": bson.M{"status": auction_entity.Completed}}
-
-	result, err := ar.Collection.UpdateMany(ctx, filter, update)
+	cursor, err := ar.Collection.Find(ctx, filter)
 	if err != nil {
-		logger.Error("Error closing auctions", err)
+		logger.Error("Error finding expired auctions", err)
 		return
 	}
 
-	if result.ModifiedCount > 0 {
-		logger.Info("Closed auctions",
-			zap.Int64("count", result.ModifiedCount),
-			zap.Any("filter", filter))
-	} else {
+	var expiredAuctions []AuctionEntityMongo
+	if err := cursor.All(ctx, &expiredAuctions); err != nil {
+		logger.Error("Error decoding expired auctions", err)
+		return
+	}
+
+	if len(expiredAuctions) == 0 {
 		logger.Info("No auctions to close")
+		return
+	}
+
+	for _, expiredAuction := range expiredAuctions {
+		result, err := ar.Collection.UpdateOne(ctx,
+			bson.M{"_id": expiredAuction.Id, "status": auction_entity.Active},
+			bson.M{"$set": bson.M{"status": auction_entity.Completed}})
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error closing auction id = %s", expiredAuction.Id), err)
+			continue
+		}
+
+		ar.StateCache.Invalidate(expiredAuction.Id)
+
+		if result.ModifiedCount == 0 {
+			continue
+		}
+
+		logger.Info("Closed auction",
+			zap.String("id", expiredAuction.Id),
+			zap.Int64("end_time", expiredAuction.EndTime))
+
+		publishAuctionEvent(ar.EventDispatcher, event_entity.AuctionClosed, &auction_entity.Auction{
+			Id:          expiredAuction.Id,
+			ProductName: expiredAuction.ProductName,
+			Category:    expiredAuction.Category,
+			Status:      auction_entity.Completed,
+			EndTime:     time.Unix(expiredAuction.EndTime, 0),
+		})
 	}
 }
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/clock"
 	"fullcycle-auction_go/internal/entity/auction_entity"
 	"fullcycle-auction_go/internal/infra/database/auction"
+	"fullcycle-auction_go/internal/infra/event"
 	"os"
 	"testing"
 	"time"
@@ -18,23 +20,23 @@ import (
 )
 
 func TestAuctionAutoClose(t *testing.T) {
+	// Este teste precisa de um MongoDB real; sem MONGODB_TEST_URL ele é
+	// ignorado. O comportamento equivalente em memória é coberto por
+	// TestMemoryAuctionAutoClose.
+	mongoURL := os.Getenv("MONGODB_TEST_URL")
+	if mongoURL == "" {
+		t.Skip("MONGODB_TEST_URL not set")
+	}
+
 	// 1. Configurar ambiente de teste seguro
 	testDBName := "test_auction_auto_close_" + time.Now().Format("20060102150405")
-	os.Setenv("AUCTION_DURATION", "2s")
-	defer os.Unsetenv("AUCTION_DURATION")
+	t.Setenv("AUCTION_DURATION", "2s")
 
-	// 2. Configurar conexão com autenticação
+	// 2. Configurar conexão; as credenciais vêm da própria URL
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	credential := options.Credential{
-		Username: "admin",
-		Password: "admin",
-	}
-
-	client, err := mongo.Connect(ctx, options.Client().
-		ApplyURI("mongodb://localhost:27017").
-		SetAuth(credential))
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURL))
 
 	assert.NoError(t, err)
 	defer func() {
@@ -46,7 +48,8 @@ func TestAuctionAutoClose(t *testing.T) {
 	database := client.Database(testDBName)
 	defer database.Drop(ctx) // Garante limpeza após o teste
 
-	repo := auction.NewAuctionRepository(database)
+	fakeClock := clock.NewFakeClock(time.Now())
+	repo := auction.NewAuctionRepository(database, event.NewEventDispatcher(), fakeClock)
 
 	// 3. Criar leilão de teste
 	auctionEntity, internalErr := auction_entity.CreateAuction(
@@ -54,6 +57,7 @@ func TestAuctionAutoClose(t *testing.T) {
 		"Category",
 		"Description",
 		auction_entity.New,
+		fakeClock.Now(),
 	)
 	assert.Nil(t, internalErr)
 
@@ -67,9 +71,9 @@ func TestAuctionAutoClose(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, auction_entity.Active, auctionDB.Status)
 
-	// 6. Aguardar expiração com margem de segurança
-	logger.Info("Waiting for auction to expire...")
-	time.Sleep(3 * time.Second) // AUCTION_DURATION (2s) + 1s buffer
+	// 6. Avançar o relógio além da expiração
+	logger.Info("Advancing clock past the auction end...")
+	fakeClock.Advance(3 * time.Second) // AUCTION_DURATION (2s) + 1s de margem
 
 	// 7. Executar fechamento
 	repo.CloseExpiredAuctions(ctx)
@@ -5,6 +5,7 @@ import (
 	"fullcycle-auction_go/configuration/logger"
 	"fullcycle-auction_go/internal/entity/auction_entity"
 	"fullcycle-auction_go/internal/internal_error"
+	"regexp"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -14,7 +15,8 @@ import (
 func (ar *AuctionRepository) FindAuctions(
 	ctx context.Context,
 	status auction_entity.AuctionStatus,
-	category, productName string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	categories []string,
+	productName string) ([]auction_entity.Auction, *internal_error.InternalError) {
 
 	result := []auction_entity.Auction{}
 
@@ -24,12 +26,12 @@ func (ar *AuctionRepository) FindAuctions(
 		filter["status"] = status
 	}
 
-	if category != "" {
-		filter["category"] = category
+	if len(categories) > 0 {
+		filter["category"] = bson.M{"$in": categories}
 	}
 
 	if productName != "" {
-		filter["product_name"] = primitive.Regex{Pattern: productName, Options: "i"}
+		filter["product_name"] = primitive.Regex{Pattern: regexp.QuoteMeta(productName), Options: "i"}
 	}
 
 	cursor, err := ar.Collection.Find(ctx, filter)
@@ -47,13 +49,15 @@ func (ar *AuctionRepository) FindAuctions(
 
 	for _, value := range auctionsMongo {
 		result = append(result, auction_entity.Auction{
-			Id:          value.Id,
-			ProductName: value.ProductName,
-			Category:    value.Category,
-			Description: value.Description,
-			Condition:   value.Condition,
-			Status:      value.Status,
-			Timestamp:   time.Unix(value.Timestamp, 0),
+			Id:           value.Id,
+			ProductName:  value.ProductName,
+			Category:     value.Category,
+			CategoryName: value.CategoryName,
+			Description:  value.Description,
+			Condition:    value.Condition,
+			Status:       value.Status,
+			Timestamp:    time.Unix(value.Timestamp, 0),
+			EndTime:      time.Unix(value.EndTime, 0),
 		})
 	}
 
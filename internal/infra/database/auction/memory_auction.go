@@ -0,0 +1,202 @@
+package auction
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/clock"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// MemoryAuctionRepository keeps auctions in process memory with the same
+// behavior as AuctionRepository, including timestamps stored with second
+// precision. Data is lost when the process stops.
+type MemoryAuctionRepository struct {
+	EventDispatcher event_entity.EventDispatcherInterface
+	Clock           clock.Clock
+
+	mutex sync.RWMutex
+	// auctions is in insertion order, which is the order Mongo returns
+	// unsorted queries in.
+	auctions []*auction_entity.Auction
+	byId     map[string]*auction_entity.Auction
+}
+
+func NewMemoryAuctionRepository(
+	eventDispatcher event_entity.EventDispatcherInterface,
+	clock clock.Clock) *MemoryAuctionRepository {
+	return &MemoryAuctionRepository{
+		EventDispatcher: eventDispatcher,
+		Clock:           clock,
+		byId:            make(map[string]*auction_entity.Auction),
+	}
+}
+
+func (mr *MemoryAuctionRepository) CreateAuction(
+	ctx context.Context,
+	auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+
+	duration := getAuctionDuration()
+	endTime := time.Unix(mr.Clock.Now().Add(duration).Unix(), 0)
+
+	mr.mutex.Lock()
+	if _, ok := mr.byId[auctionEntity.Id]; ok {
+		mr.mutex.Unlock()
+		logger.Error("Error inserting auction", fmt.Errorf("duplicate auction id %s", auctionEntity.Id))
+		return internal_error.NewInternalServerError("Error inserting auction")
+	}
+
+	stored := *auctionEntity
+	stored.Timestamp = time.Unix(auctionEntity.Timestamp.Unix(), 0)
+	stored.EndTime = endTime
+	mr.auctions = append(mr.auctions, &stored)
+	mr.byId[stored.Id] = &stored
+	mr.mutex.Unlock()
+
+	auctionEntity.EndTime = endTime
+
+	logger.Info("Auction created",
+		zap.String("id", auctionEntity.Id),
+		zap.Int64("end_time", endTime.Unix()),
+		zap.String("duration", duration.String()))
+
+	publishAuctionEvent(mr.EventDispatcher, event_entity.AuctionCreated, auctionEntity)
+
+	return nil
+}
+
+func (mr *MemoryAuctionRepository) FindAuctions(
+	ctx context.Context,
+	status auction_entity.AuctionStatus,
+	categories []string,
+	productName string) ([]auction_entity.Auction, *internal_error.InternalError) {
+
+	result := []auction_entity.Auction{}
+
+	// Mongo matches product_name as a case-insensitive substring.
+	var productNamePattern *regexp.Regexp
+	if productName != "" {
+		productNamePattern = regexp.MustCompile("(?i)" + regexp.QuoteMeta(productName))
+	}
+
+	wanted := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		wanted[category] = true
+	}
+
+	mr.mutex.RLock()
+	defer mr.mutex.RUnlock()
+
+	for _, value := range mr.auctions {
+		if status != 0 && value.Status != status {
+			continue
+		}
+		if len(wanted) > 0 && !wanted[value.Category] {
+			continue
+		}
+		if productNamePattern != nil && !productNamePattern.MatchString(value.ProductName) {
+			continue
+		}
+
+		result = append(result, *value)
+	}
+
+	return result, nil
+}
+
+func (mr *MemoryAuctionRepository) SearchAuctions(
+	ctx context.Context,
+	query auction_entity.SearchQuery) (*auction_entity.SearchResult, *internal_error.InternalError) {
+
+	mr.mutex.RLock()
+	candidates := make([]auction_entity.Auction, 0, len(mr.auctions))
+	for _, value := range mr.auctions {
+		if query.Status == nil || value.Status == *query.Status {
+			candidates = append(candidates, *value)
+		}
+	}
+	mr.mutex.RUnlock()
+
+	return searchAuctions(candidates, query), nil
+}
+
+func (mr *MemoryAuctionRepository) UpdateCategoryName(
+	ctx context.Context, categoryId, name string) *internal_error.InternalError {
+
+	mr.mutex.Lock()
+	defer mr.mutex.Unlock()
+
+	for _, value := range mr.auctions {
+		if value.Category == categoryId {
+			value.CategoryName = name
+		}
+	}
+
+	return nil
+}
+
+func (mr *MemoryAuctionRepository) FindAuctionById(
+	ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError) {
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, internal_error.NewBadRequestError("Invalid auction ID format")
+	}
+
+	mr.mutex.RLock()
+	defer mr.mutex.RUnlock()
+
+	value, ok := mr.byId[id]
+	if !ok {
+		return nil, internal_error.NewNotFoundError("Auction not found")
+	}
+
+	auctionEntity := *value
+	return &auctionEntity, nil
+}
+
+func (mr *MemoryAuctionRepository) FindAuctionState(
+	ctx context.Context, id string) (AuctionState, *internal_error.InternalError) {
+
+	auctionEntity, err := mr.FindAuctionById(ctx, id)
+	if err != nil {
+		return AuctionState{}, err
+	}
+
+	return AuctionState{Status: auctionEntity.Status, EndTime: auctionEntity.EndTime}, nil
+}
+
+// StartAuctionCloser closes expired auctions every 10 seconds and returns once
+// ctx is cancelled.
+func (mr *MemoryAuctionRepository) StartAuctionCloser(ctx context.Context) {
+	runAuctionCloser(ctx, mr.Clock, mr.CloseExpiredAuctions)
+}
+
+func (mr *MemoryAuctionRepository) CloseExpiredAuctions(ctx context.Context) {
+	now := mr.Clock.Now().Unix()
+
+	var closed []auction_entity.Auction
+	mr.mutex.Lock()
+	for _, value := range mr.auctions {
+		if value.Status == auction_entity.Active && value.EndTime.Unix() <= now {
+			value.Status = auction_entity.Completed
+			closed = append(closed, *value)
+		}
+	}
+	mr.mutex.Unlock()
+
+	for i := range closed {
+		logger.Info("Closed auction",
+			zap.String("id", closed[i].Id),
+			zap.Int64("end_time", closed[i].EndTime.Unix()))
+
+		publishAuctionEvent(mr.EventDispatcher, event_entity.AuctionClosed, &closed[i])
+	}
+}
@@ -0,0 +1,130 @@
+package auction_test
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/clock"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"fullcycle-auction_go/internal/infra/event"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryAuctionAutoClose(t *testing.T) {
+	t.Setenv("AUCTION_DURATION", "1m")
+
+	ctx := context.Background()
+	eventDispatcher := event.NewEventDispatcher()
+	events, unsubscribe := eventDispatcher.Subscribe(10)
+	defer unsubscribe()
+
+	fakeClock := clock.NewFakeClock(time.Now())
+	repo := auction.NewMemoryAuctionRepository(eventDispatcher, fakeClock)
+
+	auctionEntity, internalErr := auction_entity.CreateAuction(
+		"Product Test", "Category", "Description", auction_entity.New, fakeClock.Now())
+	assert.Nil(t, internalErr)
+	assert.Nil(t, repo.CreateAuction(ctx, auctionEntity))
+	assert.Equal(t, event_entity.AuctionCreated, (<-events).Type)
+
+	// Not expired yet.
+	repo.CloseExpiredAuctions(ctx)
+	found, internalErr := repo.FindAuctionById(ctx, auctionEntity.Id)
+	assert.Nil(t, internalErr)
+	assert.Equal(t, auction_entity.Active, found.Status)
+
+	fakeClock.Advance(time.Minute)
+	repo.CloseExpiredAuctions(ctx)
+
+	found, internalErr = repo.FindAuctionById(ctx, auctionEntity.Id)
+	assert.Nil(t, internalErr)
+	assert.Equal(t, auction_entity.Completed, found.Status)
+
+	closed := <-events
+	assert.Equal(t, event_entity.AuctionClosed, closed.Type)
+	assert.Equal(t, auctionEntity.Id, closed.AuctionId)
+
+	// Closing again publishes nothing.
+	repo.CloseExpiredAuctions(ctx)
+	select {
+	case extra := <-events:
+		t.Fatalf("unexpected event %s", extra.Type)
+	default:
+	}
+}
+
+func TestAuctionCloserRunsOnEveryTick(t *testing.T) {
+	t.Setenv("AUCTION_DURATION", "15s")
+
+	eventDispatcher := event.NewEventDispatcher()
+	events, unsubscribe := eventDispatcher.Subscribe(10)
+	defer unsubscribe()
+
+	fakeClock := clock.NewFakeClock(time.Now())
+	repo := auction.NewMemoryAuctionRepository(eventDispatcher, fakeClock)
+
+	auctionEntity, _ := auction_entity.CreateAuction(
+		"Product Test", "Category", "Description", auction_entity.New, fakeClock.Now())
+	assert.Nil(t, repo.CreateAuction(context.Background(), auctionEntity))
+	<-events
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		repo.StartAuctionCloser(ctx)
+		close(stopped)
+	}()
+	assert.Eventually(t, func() bool { return fakeClock.Tickers() == 1 }, time.Second, time.Millisecond)
+
+	// The first tick comes before the auction ends, the second after.
+	fakeClock.Advance(10 * time.Second)
+	fakeClock.Advance(10 * time.Second)
+
+	select {
+	case closed := <-events:
+		assert.Equal(t, event_entity.AuctionClosed, closed.Type)
+	case <-time.After(time.Second):
+		t.Fatal("auction was not closed")
+	}
+
+	cancel()
+	<-stopped
+	assert.Zero(t, fakeClock.Tickers())
+}
+
+func TestMemoryAuctionFindAuctionsFilters(t *testing.T) {
+	ctx := context.Background()
+	fakeClock := clock.NewFakeClock(time.Now())
+	repo := auction.NewMemoryAuctionRepository(event.NewEventDispatcher(), fakeClock)
+
+	phone, _ := auction_entity.CreateAuction(
+		"Smartphone X", "Phones", "Description", auction_entity.New, fakeClock.Now())
+	laptop, _ := auction_entity.CreateAuction(
+		"Laptop Pro", "Computers", "Description", auction_entity.Used, fakeClock.Now())
+	assert.Nil(t, repo.CreateAuction(ctx, phone))
+	assert.Nil(t, repo.CreateAuction(ctx, laptop))
+
+	all, _ := repo.FindAuctions(ctx, 0, nil, "")
+	assert.Len(t, all, 2)
+	assert.Equal(t, phone.Id, all[0].Id)
+
+	byCategory, _ := repo.FindAuctions(ctx, 0, []string{"Computers"}, "")
+	assert.Len(t, byCategory, 1)
+	assert.Equal(t, laptop.Id, byCategory[0].Id)
+
+	byName, _ := repo.FindAuctions(ctx, 0, nil, "smart")
+	assert.Len(t, byName, 1)
+	assert.Equal(t, phone.Id, byName[0].Id)
+
+	completed, _ := repo.FindAuctions(ctx, auction_entity.Completed, nil, "")
+	assert.Empty(t, completed)
+
+	_, internalErr := repo.FindAuctionById(ctx, "not-a-uuid")
+	assert.Equal(t, "bad_request", internalErr.Err)
+
+	_, internalErr = repo.FindAuctionById(ctx, "2c8a3f1e-5d47-4b8b-9a0e-7f3b6c1d2e4f")
+	assert.Equal(t, "not_found", internalErr.Err)
+}
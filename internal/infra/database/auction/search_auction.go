@@ -0,0 +1,295 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type searchHitMongo struct {
+	AuctionEntityMongo `bson:",inline"`
+	Score              float64 `bson:"score"`
+}
+
+type facetCountMongo struct {
+	Value interface{} `bson:"_id"`
+	Count int         `bson:"count"`
+}
+
+type searchResultMongo struct {
+	Hits       []searchHitMongo  `bson:"hits"`
+	Total      []facetCountMongo `bson:"total"`
+	Categories []facetCountMongo `bson:"categories"`
+	Conditions []facetCountMongo `bson:"conditions"`
+}
+
+// SearchAuctions runs a $text query over the search_text index, which
+// weighs product_name, category_name and description like
+// auction_entity.ProductNameWeight and its siblings. The hits and the
+// facets come from a single aggregation.
+func (ar *AuctionRepository) SearchAuctions(
+	ctx context.Context,
+	query auction_entity.SearchQuery) (*auction_entity.SearchResult, *internal_error.InternalError) {
+
+	result := &auction_entity.SearchResult{
+		Hits:       []auction_entity.SearchHit{},
+		Categories: []auction_entity.CategoryCount{},
+		Conditions: []auction_entity.ConditionCount{},
+	}
+
+	terms := auction_entity.SearchTerms(query.Text)
+	if len(terms) == 0 {
+		return result, nil
+	}
+
+	match := bson.M{"$text": bson.M{"$search": strings.Join(terms, " ")}}
+	if query.Status != nil {
+		match["status"] = *query.Status
+	}
+
+	categoryFilter := bson.M{}
+	if query.Category != "" {
+		categoryFilter["category"] = query.Category
+	}
+	conditionFilter := bson.M{}
+	if query.Condition != 0 {
+		conditionFilter["condition"] = query.Condition
+	}
+	bothFilters := bson.M{"$and": bson.A{categoryFilter, conditionFilter}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$addFields", Value: bson.M{"score": bson.M{"$meta": "textScore"}}}},
+		{{Key: "$facet", Value: bson.M{
+			"hits": bson.A{
+				bson.M{"$match": bothFilters},
+				bson.M{"$sort": bson.D{
+					{Key: "score", Value: -1}, {Key: "timestamp", Value: -1}, {Key: "_id", Value: 1}}},
+				bson.M{"$limit": searchLimit(query)},
+			},
+			"total": bson.A{
+				bson.M{"$match": bothFilters},
+				bson.M{"$group": bson.M{"_id": nil, "count": bson.M{"$sum": 1}}},
+			},
+			"categories": bson.A{
+				bson.M{"$match": conditionFilter},
+				bson.M{"$group": bson.M{"_id": "$category", "count": bson.M{"$sum": 1}}},
+			},
+			"conditions": bson.A{
+				bson.M{"$match": categoryFilter},
+				bson.M{"$group": bson.M{"_id": "$condition", "count": bson.M{"$sum": 1}}},
+			},
+		}}},
+	}
+
+	cursor, err := ar.Collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		logger.Error("Error searching auctions", err)
+		return nil, internal_error.NewInternalServerError("Error searching auctions")
+	}
+	defer cursor.Close(ctx)
+
+	var facets []searchResultMongo
+	if err := cursor.All(ctx, &facets); err != nil || len(facets) != 1 {
+		logger.Error("Error decoding auction search", err)
+		return nil, internal_error.NewInternalServerError("Error searching auctions")
+	}
+
+	for _, hit := range facets[0].Hits {
+		result.Hits = append(result.Hits, auction_entity.SearchHit{
+			Auction: auction_entity.Auction{
+				Id:           hit.Id,
+				ProductName:  hit.ProductName,
+				Category:     hit.Category,
+				CategoryName: hit.CategoryName,
+				Description:  hit.Description,
+				Condition:    hit.Condition,
+				Status:       hit.Status,
+				Timestamp:    time.Unix(hit.Timestamp, 0),
+				EndTime:      time.Unix(hit.EndTime, 0),
+			},
+			Score: hit.Score,
+		})
+	}
+
+	if len(facets[0].Total) > 0 {
+		result.Total = facets[0].Total[0].Count
+	}
+
+	for _, count := range facets[0].Categories {
+		category, _ := count.Value.(string)
+		result.Categories = append(result.Categories,
+			auction_entity.CategoryCount{Category: category, Count: count.Count})
+	}
+
+	for _, count := range facets[0].Conditions {
+		var condition auction_entity.ProductCondition
+		switch value := count.Value.(type) {
+		case int32:
+			condition = auction_entity.ProductCondition(value)
+		case int64:
+			condition = auction_entity.ProductCondition(value)
+		}
+		result.Conditions = append(result.Conditions,
+			auction_entity.ConditionCount{Condition: condition, Count: count.Count})
+	}
+
+	sortFacets(result)
+	return result, nil
+}
+
+// searchAuctions scores candidates against query in process, for the
+// repositories without a text index. candidates must already be filtered by
+// status.
+func searchAuctions(
+	candidates []auction_entity.Auction,
+	query auction_entity.SearchQuery) *auction_entity.SearchResult {
+
+	result := &auction_entity.SearchResult{
+		Hits:       []auction_entity.SearchHit{},
+		Categories: []auction_entity.CategoryCount{},
+		Conditions: []auction_entity.ConditionCount{},
+	}
+
+	terms := make(map[string]bool)
+	for _, term := range auction_entity.SearchTerms(query.Text) {
+		terms[term] = true
+	}
+	if len(terms) == 0 {
+		return result
+	}
+
+	categories := make(map[string]int)
+	conditions := make(map[auction_entity.ProductCondition]int)
+	for _, candidate := range candidates {
+		score := scoreAuction(candidate, terms)
+		if score == 0 {
+			continue
+		}
+
+		categoryMatches := query.Category == "" || candidate.Category == query.Category
+		conditionMatches := query.Condition == 0 || candidate.Condition == query.Condition
+		if conditionMatches {
+			categories[candidate.Category]++
+		}
+		if categoryMatches {
+			conditions[candidate.Condition]++
+		}
+		if categoryMatches && conditionMatches {
+			result.Hits = append(result.Hits, auction_entity.SearchHit{Auction: candidate, Score: score})
+		}
+	}
+
+	sortSearchHits(result.Hits)
+
+	result.Total = len(result.Hits)
+	if limit := searchLimit(query); len(result.Hits) > limit {
+		result.Hits = result.Hits[:limit]
+	}
+
+	for category, count := range categories {
+		result.Categories = append(result.Categories, auction_entity.CategoryCount{Category: category, Count: count})
+	}
+	for condition, count := range conditions {
+		result.Conditions = append(result.Conditions, auction_entity.ConditionCount{Condition: condition, Count: count})
+	}
+
+	sortFacets(result)
+	return result
+}
+
+// rankSearchHits scores hits against terms in process and returns the best
+// limit of them, best first.
+func rankSearchHits(hits []auction_entity.SearchHit, terms []string, limit int) []auction_entity.SearchHit {
+	wanted := make(map[string]bool)
+	for _, term := range terms {
+		wanted[term] = true
+	}
+
+	ranked := []auction_entity.SearchHit{}
+	for _, hit := range hits {
+		if hit.Score = scoreAuction(hit.Auction, wanted); hit.Score > 0 {
+			ranked = append(ranked, hit)
+		}
+	}
+
+	sortSearchHits(ranked)
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+
+	return ranked
+}
+
+// sortSearchHits orders hits by score, then newest first.
+func sortSearchHits(hits []auction_entity.SearchHit) {
+	sort.SliceStable(hits, func(i, j int) bool {
+		a, b := hits[i], hits[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if !a.Auction.Timestamp.Equal(b.Auction.Timestamp) {
+			return a.Auction.Timestamp.After(b.Auction.Timestamp)
+		}
+		return a.Auction.Id < b.Auction.Id
+	})
+}
+
+// searchText is the text the SQL dialects index for one field of an
+// auction: its search terms joined by spaces.
+func searchText(text string) string {
+	return strings.Join(auction_entity.SearchTerms(text), " ")
+}
+
+// scoreAuction adds the field weight for every word of the auction that is
+// one of terms.
+func scoreAuction(auctionEntity auction_entity.Auction, terms map[string]bool) float64 {
+	score := 0.0
+	fields := []struct {
+		text   string
+		weight float64
+	}{
+		{auctionEntity.ProductName, auction_entity.ProductNameWeight},
+		{auctionEntity.CategoryName, auction_entity.CategoryWeight},
+		{auctionEntity.Description, auction_entity.DescriptionWeight},
+	}
+	for _, field := range fields {
+		for _, word := range auction_entity.SearchTerms(field.text) {
+			if terms[word] {
+				score += field.weight
+			}
+		}
+	}
+
+	return score
+}
+
+func searchLimit(query auction_entity.SearchQuery) int {
+	if query.Limit <= 0 {
+		return auction_entity.DefaultSearchLimit
+	}
+
+	return query.Limit
+}
+
+// sortFacets orders categories by count, most common first, and conditions
+// by their value.
+func sortFacets(result *auction_entity.SearchResult) {
+	sort.Slice(result.Categories, func(i, j int) bool {
+		a, b := result.Categories[i], result.Categories[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Category < b.Category
+	})
+	sort.Slice(result.Conditions, func(i, j int) bool {
+		return result.Conditions[i].Condition < result.Conditions[j].Condition
+	})
+}
@@ -0,0 +1,491 @@
+package auction
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/database/sqldb"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/clock"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const auctionColumns = "id, product_name, category, category_name, description, condition, status, timestamp, end_time"
+
+// SQLAuctionRepository stores auctions in the auctions table of a SQL
+// database, created by the sqldb migrations.
+type SQLAuctionRepository struct {
+	DB              *sql.DB
+	Dialect         sqldb.Dialect
+	EventDispatcher event_entity.EventDispatcherInterface
+	Clock           clock.Clock
+}
+
+func NewSQLAuctionRepository(
+	db *sql.DB,
+	dialect sqldb.Dialect,
+	eventDispatcher event_entity.EventDispatcherInterface,
+	clock clock.Clock) *SQLAuctionRepository {
+	return &SQLAuctionRepository{
+		DB:              db,
+		Dialect:         dialect,
+		EventDispatcher: eventDispatcher,
+		Clock:           clock,
+	}
+}
+
+func (sr *SQLAuctionRepository) CreateAuction(
+	ctx context.Context,
+	auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+
+	duration := getAuctionDuration()
+	endTime := sr.Clock.Now().Add(duration).Unix()
+
+	tx, err := sr.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("Error inserting auction", err)
+		return internal_error.NewInternalServerError("Error inserting auction")
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		"INSERT INTO auctions ("+auctionColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+		auctionEntity.Id,
+		auctionEntity.ProductName,
+		auctionEntity.Category,
+		auctionEntity.CategoryName,
+		auctionEntity.Description,
+		auctionEntity.Condition,
+		auctionEntity.Status,
+		auctionEntity.Timestamp.Unix(),
+		endTime); err != nil {
+		logger.Error("Error inserting auction", err)
+		return internal_error.NewInternalServerError("Error inserting auction")
+	}
+
+	if _, err := tx.ExecContext(ctx, sr.Dialect.IndexAuctionText,
+		auctionEntity.Id,
+		searchText(auctionEntity.ProductName),
+		searchText(auctionEntity.CategoryName),
+		searchText(auctionEntity.Description)); err != nil {
+		logger.Error("Error indexing auction", err)
+		return internal_error.NewInternalServerError("Error inserting auction")
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("Error inserting auction", err)
+		return internal_error.NewInternalServerError("Error inserting auction")
+	}
+	auctionEntity.EndTime = time.Unix(endTime, 0)
+
+	logger.Info("Auction created",
+		zap.String("id", auctionEntity.Id),
+		zap.Int64("end_time", endTime),
+		zap.String("duration", duration.String()))
+
+	publishAuctionEvent(sr.EventDispatcher, event_entity.AuctionCreated, auctionEntity)
+
+	return nil
+}
+
+func (sr *SQLAuctionRepository) FindAuctions(
+	ctx context.Context,
+	status auction_entity.AuctionStatus,
+	categories []string,
+	productName string) ([]auction_entity.Auction, *internal_error.InternalError) {
+
+	result := []auction_entity.Auction{}
+
+	query := "SELECT " + auctionColumns + " FROM auctions WHERE 1 = 1"
+	var args []interface{}
+
+	if status != 0 {
+		args = append(args, status)
+		query += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+
+	if len(categories) > 0 {
+		first := len(args) + 1
+		for _, category := range categories {
+			args = append(args, category)
+		}
+		query += " AND category IN (" + sqldb.Placeholders(first, len(categories)) + ")"
+	}
+
+	if productName != "" {
+		args = append(args, regexp.QuoteMeta(productName))
+		query += " AND " + sr.Dialect.MatchesRegex("product_name", fmt.Sprintf("$%d", len(args)))
+	}
+
+	rows, err := sr.DB.QueryContext(ctx, query+" ORDER BY seq", args...)
+	if err != nil {
+		logger.Error("Error finding auctions", err)
+		return result, nil
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		auctionEntity, err := scanAuction(rows)
+		if err != nil {
+			logger.Error("Error decoding auctions", err)
+			return []auction_entity.Auction{}, nil
+		}
+		result = append(result, *auctionEntity)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.Error("Error finding auctions", err)
+		return []auction_entity.Auction{}, nil
+	}
+
+	return result, nil
+}
+
+// SearchAuctions finds the matching auctions through the dialect's text
+// index and counts the facets in SQL. Where the dialect ranks matches the
+// hits are ranked and limited in SQL too; otherwise only the matches that
+// pass the category and condition filters are scored in process.
+func (sr *SQLAuctionRepository) SearchAuctions(
+	ctx context.Context,
+	query auction_entity.SearchQuery) (*auction_entity.SearchResult, *internal_error.InternalError) {
+
+	result := &auction_entity.SearchResult{
+		Hits:       []auction_entity.SearchHit{},
+		Categories: []auction_entity.CategoryCount{},
+		Conditions: []auction_entity.ConditionCount{},
+	}
+
+	terms := auction_entity.SearchTerms(query.Text)
+	if len(terms) == 0 {
+		return result, nil
+	}
+
+	match, value, rank := sr.Dialect.MatchesAuctionText(terms, "$1")
+	args := []interface{}{value}
+	if query.Status != nil {
+		args = append(args, *query.Status)
+		match += " AND status = $2"
+	}
+
+	if err := sr.countSearchFacets(ctx, query, match, args, result); err != nil {
+		logger.Error("Error counting auction search facets", err)
+		return nil, internal_error.NewInternalServerError("Error searching auctions")
+	}
+
+	hitsMatch := match
+	hitsArgs := append([]interface{}{}, args...)
+	if query.Category != "" {
+		hitsArgs = append(hitsArgs, query.Category)
+		hitsMatch += fmt.Sprintf(" AND category = $%d", len(hitsArgs))
+	}
+	if query.Condition != 0 {
+		hitsArgs = append(hitsArgs, query.Condition)
+		hitsMatch += fmt.Sprintf(" AND condition = $%d", len(hitsArgs))
+	}
+
+	hits, err := sr.findSearchHits(ctx, query, terms, hitsMatch, hitsArgs, rank)
+	if err != nil {
+		logger.Error("Error searching auctions", err)
+		return nil, internal_error.NewInternalServerError("Error searching auctions")
+	}
+	result.Hits = hits
+
+	return result, nil
+}
+
+// countSearchFacets counts the matches per category and condition once and
+// derives the total and both facets from those counts: each facet ignores
+// its own filter but applies the other one.
+func (sr *SQLAuctionRepository) countSearchFacets(
+	ctx context.Context,
+	query auction_entity.SearchQuery,
+	match string,
+	args []interface{},
+	result *auction_entity.SearchResult) error {
+
+	rows, err := sr.DB.QueryContext(ctx,
+		"SELECT category, condition, COUNT(*) FROM auctions WHERE "+match+" GROUP BY category, condition", args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	categories := make(map[string]int)
+	conditions := make(map[auction_entity.ProductCondition]int)
+	for rows.Next() {
+		var category string
+		var condition auction_entity.ProductCondition
+		var count int
+		if err := rows.Scan(&category, &condition, &count); err != nil {
+			return err
+		}
+
+		categoryMatches := query.Category == "" || category == query.Category
+		conditionMatches := query.Condition == 0 || condition == query.Condition
+		if conditionMatches {
+			categories[category] += count
+		}
+		if categoryMatches {
+			conditions[condition] += count
+		}
+		if categoryMatches && conditionMatches {
+			result.Total += count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for category, count := range categories {
+		result.Categories = append(result.Categories, auction_entity.CategoryCount{Category: category, Count: count})
+	}
+	for condition, count := range conditions {
+		result.Conditions = append(result.Conditions, auction_entity.ConditionCount{Condition: condition, Count: count})
+	}
+	sortFacets(result)
+
+	return nil
+}
+
+func (sr *SQLAuctionRepository) findSearchHits(
+	ctx context.Context,
+	query auction_entity.SearchQuery,
+	terms []string,
+	match string,
+	args []interface{},
+	rank string) ([]auction_entity.SearchHit, error) {
+
+	statement := "SELECT " + auctionColumns + " FROM auctions WHERE " + match
+	if rank != "" {
+		args = append(args, searchLimit(query))
+		statement = "SELECT " + auctionColumns + ", " + rank + " AS score FROM auctions WHERE " + match +
+			fmt.Sprintf(" ORDER BY score DESC, timestamp DESC, id LIMIT $%d", len(args))
+	}
+
+	rows, err := sr.DB.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hits := []auction_entity.SearchHit{}
+	for rows.Next() {
+		var hit auction_entity.SearchHit
+		var auctionEntity *auction_entity.Auction
+		if rank != "" {
+			auctionEntity, err = scanAuction(rows, &hit.Score)
+		} else {
+			auctionEntity, err = scanAuction(rows)
+		}
+		if err != nil {
+			return nil, err
+		}
+		hit.Auction = *auctionEntity
+		hits = append(hits, hit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if rank != "" {
+		return hits, nil
+	}
+
+	return rankSearchHits(hits, terms, searchLimit(query)), nil
+}
+
+// UpdateCategoryName also refreshes the text index entry of every auction it
+// renames, in the same transaction.
+func (sr *SQLAuctionRepository) UpdateCategoryName(
+	ctx context.Context, categoryId, name string) *internal_error.InternalError {
+
+	tx, err := sr.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("Error updating the category name of auctions", err)
+		return internal_error.NewInternalServerError("Error updating the category name of auctions")
+	}
+	defer tx.Rollback()
+
+	if err := updateCategoryName(ctx, tx, sr.Dialect, categoryId, name); err != nil {
+		logger.Error("Error updating the category name of auctions", err, zap.String("category_id", categoryId))
+		return internal_error.NewInternalServerError("Error updating the category name of auctions")
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("Error updating the category name of auctions", err)
+		return internal_error.NewInternalServerError("Error updating the category name of auctions")
+	}
+
+	return nil
+}
+
+func updateCategoryName(ctx context.Context, tx *sql.Tx, dialect sqldb.Dialect, categoryId, name string) error {
+	if _, err := tx.ExecContext(ctx,
+		"UPDATE auctions SET category_name = $1 WHERE category = $2", name, categoryId); err != nil {
+		return err
+	}
+
+	rows, err := tx.QueryContext(ctx,
+		"SELECT id, product_name, description FROM auctions WHERE category = $1", categoryId)
+	if err != nil {
+		return err
+	}
+
+	var renamed []auction_entity.Auction
+	for rows.Next() {
+		var auctionEntity auction_entity.Auction
+		if err := rows.Scan(&auctionEntity.Id, &auctionEntity.ProductName, &auctionEntity.Description); err != nil {
+			rows.Close()
+			return err
+		}
+		renamed = append(renamed, auctionEntity)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, auctionEntity := range renamed {
+		if _, err := tx.ExecContext(ctx, dialect.IndexAuctionText,
+			auctionEntity.Id,
+			searchText(auctionEntity.ProductName),
+			searchText(name),
+			searchText(auctionEntity.Description)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (sr *SQLAuctionRepository) FindAuctionById(
+	ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError) {
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, internal_error.NewBadRequestError("Invalid auction ID format")
+	}
+
+	auctionEntity, err := scanAuction(sr.DB.QueryRowContext(ctx,
+		"SELECT "+auctionColumns+" FROM auctions WHERE id = $1", id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, internal_error.NewNotFoundError("Auction not found")
+		}
+		logger.Error(fmt.Sprintf("Error trying to find auction by id = %s", id), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find auction by id")
+	}
+
+	return auctionEntity, nil
+}
+
+// StartAuctionCloser closes expired auctions every 10 seconds and returns once
+// ctx is cancelled.
+func (sr *SQLAuctionRepository) StartAuctionCloser(ctx context.Context) {
+	runAuctionCloser(ctx, sr.Clock, sr.CloseExpiredAuctions)
+}
+
+// CloseExpiredAuctions completes the expired auctions in one transaction.
+// The rows are locked with SKIP LOCKED where the database supports it, so
+// instances sharing the database close each auction exactly once, and bids
+// being placed on an auction (which lock its row) finish first.
+func (sr *SQLAuctionRepository) CloseExpiredAuctions(ctx context.Context) {
+	now := sr.Clock.Now().Unix()
+	logger.Info("Checking for expired auctions", zap.Int64("now", now))
+
+	tx, err := sr.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("Error finding expired auctions", err)
+		return
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx,
+		"SELECT "+auctionColumns+" FROM auctions WHERE status = $1 AND end_time <= $2 ORDER BY end_time"+
+			sr.Dialect.ForUpdateSkipLocked,
+		auction_entity.Active, now)
+	if err != nil {
+		logger.Error("Error finding expired auctions", err)
+		return
+	}
+
+	var expiredAuctions []auction_entity.Auction
+	for rows.Next() {
+		auctionEntity, err := scanAuction(rows)
+		if err != nil {
+			rows.Close()
+			logger.Error("Error decoding expired auctions", err)
+			return
+		}
+		expiredAuctions = append(expiredAuctions, *auctionEntity)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		logger.Error("Error decoding expired auctions", err)
+		return
+	}
+
+	if len(expiredAuctions) == 0 {
+		logger.Info("No auctions to close")
+		return
+	}
+
+	for _, expiredAuction := range expiredAuctions {
+		if _, err := tx.ExecContext(ctx,
+			"UPDATE auctions SET status = $1 WHERE id = $2",
+			auction_entity.Completed, expiredAuction.Id); err != nil {
+			logger.Error(fmt.Sprintf("Error closing auction id = %s", expiredAuction.Id), err)
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("Error closing expired auctions", err)
+		return
+	}
+
+	for i := range expiredAuctions {
+		expiredAuctions[i].Status = auction_entity.Completed
+
+		logger.Info("Closed auction",
+			zap.String("id", expiredAuctions[i].Id),
+			zap.Int64("end_time", expiredAuctions[i].EndTime.Unix()))
+
+		publishAuctionEvent(sr.EventDispatcher, event_entity.AuctionClosed, &expiredAuctions[i])
+	}
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanAuction reads the auctionColumns of row, followed by the extra columns
+// selected after them into extra.
+func scanAuction(row rowScanner, extra ...interface{}) (*auction_entity.Auction, error) {
+	var auctionEntity auction_entity.Auction
+	var timestamp, endTime int64
+	if err := row.Scan(append([]interface{}{
+		&auctionEntity.Id,
+		&auctionEntity.ProductName,
+		&auctionEntity.Category,
+		&auctionEntity.CategoryName,
+		&auctionEntity.Description,
+		&auctionEntity.Condition,
+		&auctionEntity.Status,
+		&timestamp,
+		&endTime}, extra...)...); err != nil {
+		return nil, err
+	}
+
+	auctionEntity.Timestamp = time.Unix(timestamp, 0)
+	auctionEntity.EndTime = time.Unix(endTime, 0)
+
+	return &auctionEntity, nil
+}
@@ -2,16 +2,22 @@ package bid
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"fullcycle-auction_go/configuration/logger"
-	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/clock"
 	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
 	"fullcycle-auction_go/internal/infra/database/auction"
 	"fullcycle-auction_go/internal/internal_error"
-	"os"
+	"math"
 	"sync"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
 )
 
 type BidEntityMongo struct {
@@ -22,100 +28,299 @@ type BidEntityMongo struct {
 	Timestamp int64   `bson:"timestamp"`
 }
 
+// AuctionStateFinder is the part of an auction repository bids are
+// validated against.
+type AuctionStateFinder interface {
+	FindAuctionState(ctx context.Context, id string) (auction.AuctionState, *internal_error.InternalError)
+}
+
+// maxBestAmountClaims is how many times a group of bids is checked again
+// after other writers raised the auction's best amount first.
+const maxBestAmountClaims = 5
+
 type BidRepository struct {
-	Collection            *mongo.Collection
-	AuctionRepository     *auction.AuctionRepository
-	auctionInterval       time.Duration
-	auctionStatusMap      map[string]auction_entity.AuctionStatus
-	auctionEndTimeMap     map[string]time.Time
-	auctionStatusMapMutex *sync.Mutex
-	auctionEndTimeMutex   *sync.Mutex
+	Collection *mongo.Collection
+	// AuctionCollection holds the best_amount each group of bids claims
+	// before it is inserted; see createAuctionBids.
+	AuctionCollection *mongo.Collection
+	AuctionRepository AuctionStateFinder
+	EventDispatcher   event_entity.EventDispatcherInterface
+	Clock             clock.Clock
 }
 
-func NewBidRepository(database *mongo.Database, auctionRepository *auction.AuctionRepository) *BidRepository {
+func NewBidRepository(
+	database *mongo.Database,
+	auctionRepository AuctionStateFinder,
+	eventDispatcher event_entity.EventDispatcherInterface,
+	clock clock.Clock) *BidRepository {
 	return &BidRepository{
-		auctionInterval:       getAuctionInterval(),
-		auctionStatusMap:      make(map[string]auction_entity.AuctionStatus),
-		auctionEndTimeMap:     make(map[string]time.Time),
-		auctionStatusMapMutex: &sync.Mutex{},
-		auctionEndTimeMutex:   &sync.Mutex{},
-		Collection:            database.Collection("bids"),
-		AuctionRepository:     auctionRepository,
+		Collection:        database.Collection("bids"),
+		AuctionCollection: database.Collection("auctions"),
+		AuctionRepository: auctionRepository,
+		EventDispatcher:   eventDispatcher,
+		Clock:             clock,
 	}
 }
 
+// CreateBid groups the bids by auction and places each group concurrently.
+// Within a group bids are checked in arrival order: each one must beat the
+// auction's best bid so far, including the ones accepted before it in the
+// batch. The accepted bids of a group are written with a single InsertMany,
+// after the group claims the auction's new best amount.
 func (bd *BidRepository) CreateBid(
 	ctx context.Context,
-	bidEntities []bid_entity.Bid) *internal_error.InternalError {
+	bidEntities []bid_entity.Bid) []bid_entity.BidFailure {
+
 	var wg sync.WaitGroup
-	for _, bid := range bidEntities {
+	var failuresMutex sync.Mutex
+	var failures []bid_entity.BidFailure
+	for _, group := range groupByAuction(bidEntities) {
 		wg.Add(1)
-		go func(bidValue bid_entity.Bid) {
+		go func(bids []bid_entity.Bid) {
 			defer wg.Done()
 
-			bd.auctionStatusMapMutex.Lock()
-			auctionStatus, okStatus := bd.auctionStatusMap[bidValue.AuctionId]
-			bd.auctionStatusMapMutex.Unlock()
+			if groupFailures := bd.createAuctionBids(ctx, bids); len(groupFailures) > 0 {
+				failuresMutex.Lock()
+				failures = append(failures, groupFailures...)
+				failuresMutex.Unlock()
+			}
+		}(group)
+	}
+	wg.Wait()
 
-			bd.auctionEndTimeMutex.Lock()
-			auctionEndTime, okEndTime := bd.auctionEndTimeMap[bidValue.AuctionId]
-			bd.auctionEndTimeMutex.Unlock()
+	return failures
+}
 
-			bidEntityMongo := &BidEntityMongo{
-				Id:        bidValue.Id,
-				UserId:    bidValue.UserId,
-				AuctionId: bidValue.AuctionId,
-				Amount:    bidValue.Amount,
-				Timestamp: bidValue.Timestamp.Unix(),
-			}
+func (bd *BidRepository) createAuctionBids(
+	ctx context.Context,
+	bids []bid_entity.Bid) []bid_entity.BidFailure {
 
-			if okEndTime && okStatus {
-				now := time.Now()
-				if auctionStatus == auction_entity.Completed || now.After(auctionEndTime) {
-					return
-				}
+	auctionId := bids[0].AuctionId
 
-				if _, err := bd.Collection.InsertOne(ctx, bidEntityMongo); err != nil {
-					logger.Error("Error trying to insert bid", err)
-					return
-				}
+	if err := checkAuctionIsOpen(ctx, bd.AuctionRepository, auctionId, bd.Clock.Now()); err != nil {
+		return failAll(bids, err)
+	}
 
-				return
-			}
+	// Another instance may be placing bids on the same auction. Each group
+	// claims the best amount it read by raising best_amount on the auction
+	// document only if it still holds that amount, like the SQL repository
+	// locks the auction's row; a group that loses the race is checked again
+	// against the new best amount.
+	for attempt := 1; ; attempt++ {
+		bestAmount, err := bd.findBestAmount(ctx, auctionId)
+		if err != nil {
+			return failAll(bids, err)
+		}
+
+		accepted, failures := selectOutbiddingBids(bids, bestAmount)
+		if len(accepted) == 0 {
+			return failures
+		}
+
+		claimed, err := bd.claimBestAmount(ctx, auctionId, bestAmount, accepted[len(accepted)-1].Amount)
+		if err != nil {
+			return failAll(bids, err)
+		}
+		if claimed {
+			return append(failures, bd.insertBids(ctx, auctionId, bestAmount, accepted)...)
+		}
+
+		if attempt == maxBestAmountClaims {
+			return failAll(bids, internal_error.NewConflictError("Too many concurrent bids on the auction, retry"))
+		}
+	}
+}
+
+// insertBids writes the accepted bids of one auction, which already claimed
+// the amount of the last one. When some of them fail best_amount goes back
+// to the highest bid written, or to previousBest when none was.
+func (bd *BidRepository) insertBids(
+	ctx context.Context,
+	auctionId string,
+	previousBest float64,
+	accepted []bid_entity.Bid) []bid_entity.BidFailure {
 
-			auctionEntity, err := bd.AuctionRepository.FindAuctionById(ctx, bidValue.AuctionId)
-			if err != nil {
-				logger.Error("Error trying to find auction by id", err)
-				return
+	var failures []bid_entity.BidFailure
+	documents := make([]interface{}, 0, len(accepted))
+	for _, bid := range accepted {
+		documents = append(documents, &BidEntityMongo{
+			Id:        bid.Id,
+			UserId:    bid.UserId,
+			AuctionId: bid.AuctionId,
+			Amount:    bid.Amount,
+			Timestamp: bid.Timestamp.Unix(),
+		})
+	}
+
+	failedIndexes := make(map[int]bool)
+	if _, err := bd.Collection.InsertMany(ctx, documents, options.InsertMany().SetOrdered(false)); err != nil {
+		logger.Error("Error trying to insert bids", err, zap.String("auction_id", auctionId))
+
+		var bulkWriteException mongo.BulkWriteException
+		if !errors.As(err, &bulkWriteException) || len(bulkWriteException.WriteErrors) == 0 {
+			failures = failAll(accepted, internal_error.NewInternalServerError("Error trying to insert bids"))
+			for index := range accepted {
+				failedIndexes[index] = true
 			}
-			if auctionEntity.Status == auction_entity.Completed {
-				return
+		} else {
+			for _, writeError := range bulkWriteException.WriteErrors {
+				failedIndexes[writeError.Index] = true
+				failures = append(failures, bid_entity.BidFailure{
+					Bid: accepted[writeError.Index],
+					Err: internal_error.NewInternalServerError("Error trying to insert bid"),
+				})
 			}
+		}
+	}
 
-			bd.auctionStatusMapMutex.Lock()
-			bd.auctionStatusMap[bidValue.AuctionId] = auctionEntity.Status
-			bd.auctionStatusMapMutex.Unlock()
+	bestInserted := previousBest
+	for index, bid := range accepted {
+		if !failedIndexes[index] {
+			bestInserted = bid.Amount
+			publishBidCreated(bd.EventDispatcher, bid)
+		}
+	}
 
-			bd.auctionEndTimeMutex.Lock()
-			bd.auctionEndTimeMap[bidValue.AuctionId] = auctionEntity.Timestamp.Add(bd.auctionInterval)
-			bd.auctionEndTimeMutex.Unlock()
+	if claimed := accepted[len(accepted)-1].Amount; bestInserted != claimed {
+		if _, err := bd.AuctionCollection.UpdateOne(ctx,
+			bson.M{"_id": auctionId, "best_amount": claimed},
+			bson.M{"$set": bson.M{"best_amount": bestInserted}}); err != nil {
+			logger.Error("Error trying to restore the best amount", err, zap.String("auction_id", auctionId))
+		}
+	}
 
-			if _, err := bd.Collection.InsertOne(ctx, bidEntityMongo); err != nil {
-				logger.Error("Error trying to insert bid", err)
-				return
-			}
-		}(bid)
+	return failures
+}
+
+func checkAuctionIsOpen(
+	ctx context.Context,
+	auctionRepository AuctionStateFinder,
+	auctionId string,
+	now time.Time) *internal_error.InternalError {
+
+	state, err := auctionRepository.FindAuctionState(ctx, auctionId)
+	if err != nil {
+		return err
 	}
-	wg.Wait()
+
+	if !state.IsOpen(now) {
+		return internal_error.NewBadRequestError("Auction is closed")
+	}
+
 	return nil
 }
 
-func getAuctionInterval() time.Duration {
-	auctionInterval := os.Getenv("AUCTION_INTERVAL")
-	duration, err := time.ParseDuration(auctionInterval)
+// groupByAuction splits bids by auction, keeping the arrival order within
+// each group and the order in which auctions first appear.
+func groupByAuction(bids []bid_entity.Bid) [][]bid_entity.Bid {
+	var groups [][]bid_entity.Bid
+	indexes := make(map[string]int)
+	for _, bid := range bids {
+		index, ok := indexes[bid.AuctionId]
+		if !ok {
+			index = len(groups)
+			indexes[bid.AuctionId] = index
+			groups = append(groups, nil)
+		}
+		groups[index] = append(groups[index], bid)
+	}
+
+	return groups
+}
+
+// findBestAmount returns the highest amount bid or claimed on the auction,
+// or zero when it has neither. Auctions that had bids before best_amount
+// was introduced have no claim yet, so the bids are checked too.
+func (bd *BidRepository) findBestAmount(ctx context.Context, auctionId string) (float64, *internal_error.InternalError) {
+	var auctionClaim struct {
+		BestAmount float64 `bson:"best_amount"`
+	}
+	if err := bd.AuctionCollection.FindOne(ctx, bson.M{"_id": auctionId},
+		options.FindOne().SetProjection(bson.M{"best_amount": 1})).Decode(&auctionClaim); err != nil &&
+		err != mongo.ErrNoDocuments {
+		logger.Error("Error trying to find the best amount", err, zap.String("auction_id", auctionId))
+		return 0, internal_error.NewInternalServerError("Error trying to find the best bid")
+	}
+
+	var bidEntityMongo BidEntityMongo
+	opts := options.FindOne().
+		SetSort(bson.D{{Key: "amount", Value: -1}}).
+		SetProjection(bson.M{"amount": 1})
+	if err := bd.Collection.FindOne(ctx, bson.M{"auction_id": auctionId}, opts).Decode(&bidEntityMongo); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return auctionClaim.BestAmount, nil
+		}
+		logger.Error("Error trying to find the best bid", err, zap.String("auction_id", auctionId))
+		return 0, internal_error.NewInternalServerError("Error trying to find the best bid")
+	}
+
+	return math.Max(auctionClaim.BestAmount, bidEntityMongo.Amount), nil
+}
+
+// claimBestAmount raises the auction's best_amount to amount unless another
+// writer moved it past expected first, and reports whether it did.
+func (bd *BidRepository) claimBestAmount(
+	ctx context.Context, auctionId string, expected, amount float64) (bool, *internal_error.InternalError) {
+
+	result, err := bd.AuctionCollection.UpdateOne(ctx,
+		bson.M{"_id": auctionId, "$or": bson.A{
+			bson.M{"best_amount": bson.M{"$exists": false}},
+			bson.M{"best_amount": bson.M{"$lte": expected}},
+		}},
+		bson.M{"$set": bson.M{"best_amount": amount}})
 	if err != nil {
-		return time.Minute * 5
+		logger.Error("Error trying to claim the best amount", err, zap.String("auction_id", auctionId))
+		return false, internal_error.NewInternalServerError("Error trying to insert bids")
+	}
+
+	return result.MatchedCount == 1, nil
+}
+
+// selectOutbiddingBids walks the bids of one auction in arrival order and
+// accepts each one that is higher than bestAmount and every bid accepted
+// before it.
+func selectOutbiddingBids(
+	bids []bid_entity.Bid,
+	bestAmount float64) ([]bid_entity.Bid, []bid_entity.BidFailure) {
+
+	var accepted []bid_entity.Bid
+	var failures []bid_entity.BidFailure
+	for _, bid := range bids {
+		if bid.Amount <= bestAmount {
+			failures = append(failures, bid_entity.BidFailure{
+				Bid: bid,
+				Err: internal_error.NewBadRequestError(
+					fmt.Sprintf("Bid must be higher than the current best of %.2f", bestAmount)),
+			})
+			continue
+		}
+
+		bestAmount = bid.Amount
+		accepted = append(accepted, bid)
 	}
 
-	return duration
+	return accepted, failures
+}
+
+func failAll(bids []bid_entity.Bid, err *internal_error.InternalError) []bid_entity.BidFailure {
+	failures := make([]bid_entity.BidFailure, 0, len(bids))
+	for _, bid := range bids {
+		failures = append(failures, bid_entity.BidFailure{Bid: bid, Err: err})
+	}
+
+	return failures
+}
+
+func publishBidCreated(eventDispatcher event_entity.EventDispatcherInterface, bidValue bid_entity.Bid) {
+	eventDispatcher.Publish(event_entity.Event{
+		Type:      event_entity.BidCreated,
+		AuctionId: bidValue.AuctionId,
+		Data: event_entity.BidData{
+			Id:        bidValue.Id,
+			UserId:    bidValue.UserId,
+			AuctionId: bidValue.AuctionId,
+			Amount:    bidValue.Amount,
+			Timestamp: bidValue.Timestamp,
+		},
+	})
 }
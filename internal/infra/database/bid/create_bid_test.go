@@ -0,0 +1,28 @@
+package bid
+
+import (
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectOutbiddingBidsKeepsArrivalOrder(t *testing.T) {
+	bids := []bid_entity.Bid{
+		{Id: "1", Amount: 90},
+		{Id: "2", Amount: 110},
+		{Id: "3", Amount: 105},
+		{Id: "4", Amount: 110},
+		{Id: "5", Amount: 120},
+	}
+
+	accepted, failures := selectOutbiddingBids(bids, 100)
+
+	assert.Equal(t, []bid_entity.Bid{bids[1], bids[4]}, accepted)
+	assert.Len(t, failures, 3)
+	for i, id := range []string{"1", "3", "4"} {
+		assert.Equal(t, id, failures[i].Bid.Id)
+		assert.Equal(t, "bad_request", failures[i].Err.Err)
+	}
+	assert.Equal(t, "Bid must be higher than the current best of 110.00", failures[2].Err.Message)
+}
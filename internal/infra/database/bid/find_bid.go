@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
@@ -42,13 +43,48 @@ func (br *BidRepository) FindBidByAuctionId(
 	return bids, nil
 }
 
+func (br *BidRepository) FindBidsByAuctionIds(
+	ctx context.Context, auctionIds []string) ([]bid_entity.Bid, *internal_error.InternalError) {
+
+	filter := bson.M{"auction_id": bson.M{"$in": auctionIds}}
+	opts := options.Find().SetSort(bson.D{{Key: "amount", Value: -1}})
+	cursor, err := br.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error("Error finding bids by auction ids", err)
+		return nil, internal_error.NewInternalServerError("Error finding bids by auction ids")
+	}
+	defer cursor.Close(ctx)
+
+	var bidsMongo []BidEntityMongo
+	if err := cursor.All(ctx, &bidsMongo); err != nil {
+		logger.Error("Error decoding bids", err)
+		return nil, internal_error.NewInternalServerError("Error decoding bids")
+	}
+
+	bids := make([]bid_entity.Bid, 0, len(bidsMongo))
+	for _, bidMongo := range bidsMongo {
+		bids = append(bids, bid_entity.Bid{
+			Id:        bidMongo.Id,
+			UserId:    bidMongo.UserId,
+			AuctionId: bidMongo.AuctionId,
+			Amount:    bidMongo.Amount,
+			Timestamp: time.Unix(bidMongo.Timestamp, 0),
+		})
+	}
+
+	return bids, nil
+}
+
 func (bd *BidRepository) FindWinningBidByAuctionId(
 	ctx context.Context, auctionId string) (*bid_entity.Bid, *internal_error.InternalError) {
 	filter := bson.M{"auction_id": auctionId}
 
 	var bidEntityMongo BidEntityMongo
-	opts := options.FindOne().SetSort(bson.D{{"amount", -1}})
+	opts := options.FindOne().SetSort(bson.D{{Key: "amount", Value: -1}})
 	if err := bd.Collection.FindOne(ctx, filter, opts).Decode(&bidEntityMongo); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
 		logger.Error("Error trying to find the auction winner", err)
 		return nil, internal_error.NewInternalServerError("Error trying to find the auction winner")
 	}
@@ -0,0 +1,159 @@
+package bid
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/clock"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryBidRepository keeps bids in process memory with the same behavior as
+// BidRepository, including timestamps stored with second precision.
+type MemoryBidRepository struct {
+	AuctionRepository AuctionStateFinder
+	EventDispatcher   event_entity.EventDispatcherInterface
+	Clock             clock.Clock
+
+	mutex sync.RWMutex
+	// bids is in insertion order, which is the order Mongo returns unsorted
+	// queries in.
+	bids []bid_entity.Bid
+	ids  map[string]bool
+}
+
+func NewMemoryBidRepository(
+	auctionRepository AuctionStateFinder,
+	eventDispatcher event_entity.EventDispatcherInterface,
+	clock clock.Clock) *MemoryBidRepository {
+	return &MemoryBidRepository{
+		AuctionRepository: auctionRepository,
+		EventDispatcher:   eventDispatcher,
+		Clock:             clock,
+		ids:               make(map[string]bool),
+	}
+}
+
+// CreateBid places the bids of each auction in arrival order like
+// BidRepository.CreateBid. The whole batch is checked and stored under one
+// lock, so concurrent batches cannot interleave.
+func (mr *MemoryBidRepository) CreateBid(
+	ctx context.Context,
+	bidEntities []bid_entity.Bid) []bid_entity.BidFailure {
+
+	var failures []bid_entity.BidFailure
+	var placed []bid_entity.Bid
+
+	now := mr.Clock.Now()
+
+	mr.mutex.Lock()
+	for _, group := range groupByAuction(bidEntities) {
+		if err := checkAuctionIsOpen(ctx, mr.AuctionRepository, group[0].AuctionId, now); err != nil {
+			failures = append(failures, failAll(group, err)...)
+			continue
+		}
+
+		accepted, groupFailures := selectOutbiddingBids(group, mr.bestAmount(group[0].AuctionId))
+		failures = append(failures, groupFailures...)
+
+		for _, bid := range accepted {
+			if mr.ids[bid.Id] {
+				logger.Error("Error trying to insert bids", fmt.Errorf("duplicate bid id %s", bid.Id))
+				failures = append(failures, bid_entity.BidFailure{
+					Bid: bid,
+					Err: internal_error.NewInternalServerError("Error trying to insert bid"),
+				})
+				continue
+			}
+
+			bid.Timestamp = time.Unix(bid.Timestamp.Unix(), 0)
+			mr.ids[bid.Id] = true
+			mr.bids = append(mr.bids, bid)
+			placed = append(placed, bid)
+		}
+	}
+	mr.mutex.Unlock()
+
+	for _, bid := range placed {
+		publishBidCreated(mr.EventDispatcher, bid)
+	}
+
+	return failures
+}
+
+func (mr *MemoryBidRepository) bestAmount(auctionId string) float64 {
+	bestAmount := 0.0
+	for _, bid := range mr.bids {
+		if bid.AuctionId == auctionId && bid.Amount > bestAmount {
+			bestAmount = bid.Amount
+		}
+	}
+
+	return bestAmount
+}
+
+func (mr *MemoryBidRepository) FindBidByAuctionId(
+	ctx context.Context, auctionId string) ([]bid_entity.Bid, *internal_error.InternalError) {
+
+	mr.mutex.RLock()
+	defer mr.mutex.RUnlock()
+
+	var bids []bid_entity.Bid
+	for _, bid := range mr.bids {
+		if bid.AuctionId == auctionId {
+			bids = append(bids, bid)
+		}
+	}
+
+	return bids, nil
+}
+
+func (mr *MemoryBidRepository) FindBidsByAuctionIds(
+	ctx context.Context, auctionIds []string) ([]bid_entity.Bid, *internal_error.InternalError) {
+
+	wanted := make(map[string]bool, len(auctionIds))
+	for _, auctionId := range auctionIds {
+		wanted[auctionId] = true
+	}
+
+	mr.mutex.RLock()
+	bids := make([]bid_entity.Bid, 0)
+	for _, bid := range mr.bids {
+		if wanted[bid.AuctionId] {
+			bids = append(bids, bid)
+		}
+	}
+	mr.mutex.RUnlock()
+
+	sort.SliceStable(bids, func(i, j int) bool {
+		return bids[i].Amount > bids[j].Amount
+	})
+
+	return bids, nil
+}
+
+func (mr *MemoryBidRepository) FindWinningBidByAuctionId(
+	ctx context.Context, auctionId string) (*bid_entity.Bid, *internal_error.InternalError) {
+
+	mr.mutex.RLock()
+	defer mr.mutex.RUnlock()
+
+	var winningBid *bid_entity.Bid
+	for i := range mr.bids {
+		if mr.bids[i].AuctionId == auctionId && (winningBid == nil || mr.bids[i].Amount > winningBid.Amount) {
+			winningBid = &mr.bids[i]
+		}
+	}
+
+	if winningBid == nil {
+		return nil, nil
+	}
+
+	bid := *winningBid
+	return &bid, nil
+}
@@ -0,0 +1,56 @@
+package bid_test
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/clock"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"fullcycle-auction_go/internal/infra/database/bid"
+	"fullcycle-auction_go/internal/infra/event"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const userId = "6b0d6f1c-0b5e-4d6b-8f8e-2b9d1a3c4e5f"
+
+func TestMemoryBidRepositoryPlacesOutbiddingBidsOnOpenAuctions(t *testing.T) {
+	t.Setenv("AUCTION_DURATION", "1m")
+
+	ctx := context.Background()
+	eventDispatcher := event.NewEventDispatcher()
+	fakeClock := clock.NewFakeClock(time.Now())
+	auctionRepository := auction.NewMemoryAuctionRepository(eventDispatcher, fakeClock)
+	bidRepository := bid.NewMemoryBidRepository(auctionRepository, eventDispatcher, fakeClock)
+
+	auctionEntity, _ := auction_entity.CreateAuction(
+		"Product", "Category", "Description", auction_entity.New, fakeClock.Now())
+	assert.Nil(t, auctionRepository.CreateAuction(ctx, auctionEntity))
+
+	first, _ := bid_entity.CreateBid(userId, auctionEntity.Id, 10, fakeClock.Now())
+	lower, _ := bid_entity.CreateBid(userId, auctionEntity.Id, 5, fakeClock.Now())
+	second, _ := bid_entity.CreateBid(userId, auctionEntity.Id, 20, fakeClock.Now())
+
+	failures := bidRepository.CreateBid(ctx, []bid_entity.Bid{*first, *lower, *second})
+	assert.Len(t, failures, 1)
+	assert.Equal(t, lower.Id, failures[0].Bid.Id)
+	assert.Equal(t, "bad_request", failures[0].Err.Err)
+
+	winner, internalErr := bidRepository.FindWinningBidByAuctionId(ctx, auctionEntity.Id)
+	assert.Nil(t, internalErr)
+	assert.Equal(t, second.Id, winner.Id)
+
+	bids, _ := bidRepository.FindBidByAuctionId(ctx, auctionEntity.Id)
+	assert.Len(t, bids, 2)
+	assert.Equal(t, first.Id, bids[0].Id)
+
+	fakeClock.Advance(time.Minute)
+	auctionRepository.CloseExpiredAuctions(ctx)
+
+	late, _ := bid_entity.CreateBid(userId, auctionEntity.Id, 30, fakeClock.Now())
+	failures = bidRepository.CreateBid(ctx, []bid_entity.Bid{*late})
+	assert.Len(t, failures, 1)
+	assert.Equal(t, "bad_request", failures[0].Err.Err)
+}
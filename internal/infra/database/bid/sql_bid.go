@@ -0,0 +1,247 @@
+package bid
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fullcycle-auction_go/configuration/database/sqldb"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/clock"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"fullcycle-auction_go/internal/internal_error"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const bidColumns = "id, user_id, auction_id, amount, timestamp"
+
+// SQLBidRepository stores bids in the bids table of a SQL database, created
+// by the sqldb migrations.
+type SQLBidRepository struct {
+	DB              *sql.DB
+	Dialect         sqldb.Dialect
+	EventDispatcher event_entity.EventDispatcherInterface
+	Clock           clock.Clock
+}
+
+func NewSQLBidRepository(
+	db *sql.DB,
+	dialect sqldb.Dialect,
+	eventDispatcher event_entity.EventDispatcherInterface,
+	clock clock.Clock) *SQLBidRepository {
+	return &SQLBidRepository{
+		DB:              db,
+		Dialect:         dialect,
+		EventDispatcher: eventDispatcher,
+		Clock:           clock,
+	}
+}
+
+// CreateBid places the bids of each auction in arrival order like
+// BidRepository.CreateBid. Each auction's bids are placed in a transaction
+// that locks the auction's row, so the auction cannot close and no other
+// instance can place bids on it until they are written.
+func (sr *SQLBidRepository) CreateBid(
+	ctx context.Context,
+	bidEntities []bid_entity.Bid) []bid_entity.BidFailure {
+
+	var wg sync.WaitGroup
+	var failuresMutex sync.Mutex
+	var failures []bid_entity.BidFailure
+	for _, group := range groupByAuction(bidEntities) {
+		wg.Add(1)
+		go func(bids []bid_entity.Bid) {
+			defer wg.Done()
+
+			if groupFailures := sr.createAuctionBids(ctx, bids); len(groupFailures) > 0 {
+				failuresMutex.Lock()
+				failures = append(failures, groupFailures...)
+				failuresMutex.Unlock()
+			}
+		}(group)
+	}
+	wg.Wait()
+
+	return failures
+}
+
+func (sr *SQLBidRepository) createAuctionBids(
+	ctx context.Context,
+	bids []bid_entity.Bid) []bid_entity.BidFailure {
+
+	auctionId := bids[0].AuctionId
+
+	tx, err := sr.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("Error trying to insert bids", err, zap.String("auction_id", auctionId))
+		return failAll(bids, internal_error.NewInternalServerError("Error trying to insert bids"))
+	}
+	defer tx.Rollback()
+
+	if err := sr.lockOpenAuction(ctx, tx, auctionId); err != nil {
+		return failAll(bids, err)
+	}
+
+	var bestAmount float64
+	if err := tx.QueryRowContext(ctx,
+		"SELECT COALESCE(MAX(amount), 0) FROM bids WHERE auction_id = $1", auctionId).Scan(&bestAmount); err != nil {
+		logger.Error("Error trying to find the best bid", err, zap.String("auction_id", auctionId))
+		return failAll(bids, internal_error.NewInternalServerError("Error trying to find the best bid"))
+	}
+
+	accepted, failures := selectOutbiddingBids(bids, bestAmount)
+	if len(accepted) == 0 {
+		return failures
+	}
+
+	// ON CONFLICT DO NOTHING keeps a duplicate id from aborting the
+	// transaction, so only that bid fails, as with an unordered InsertMany.
+	var inserted []bid_entity.Bid
+	for _, bid := range accepted {
+		result, err := tx.ExecContext(ctx,
+			"INSERT INTO bids ("+bidColumns+") VALUES ($1, $2, $3, $4, $5) ON CONFLICT (id) DO NOTHING",
+			bid.Id, bid.UserId, bid.AuctionId, bid.Amount, bid.Timestamp.Unix())
+		if err != nil {
+			logger.Error("Error trying to insert bids", err, zap.String("auction_id", auctionId))
+			return append(failures, failAll(accepted,
+				internal_error.NewInternalServerError("Error trying to insert bids"))...)
+		}
+
+		if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+			logger.Error("Error trying to insert bids", errors.New("duplicate bid id"), zap.String("id", bid.Id))
+			failures = append(failures, bid_entity.BidFailure{
+				Bid: bid,
+				Err: internal_error.NewInternalServerError("Error trying to insert bid"),
+			})
+			continue
+		}
+
+		inserted = append(inserted, bid)
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("Error trying to insert bids", err, zap.String("auction_id", auctionId))
+		return append(failures, failAll(inserted,
+			internal_error.NewInternalServerError("Error trying to insert bids"))...)
+	}
+
+	for _, bid := range inserted {
+		publishBidCreated(sr.EventDispatcher, bid)
+	}
+
+	return failures
+}
+
+// lockOpenAuction locks the auction's row for the rest of tx and checks that
+// it still takes bids.
+func (sr *SQLBidRepository) lockOpenAuction(
+	ctx context.Context, tx *sql.Tx, auctionId string) *internal_error.InternalError {
+
+	if _, err := uuid.Parse(auctionId); err != nil {
+		return internal_error.NewBadRequestError("Invalid auction ID format")
+	}
+
+	var state auction.AuctionState
+	var endTime int64
+	if err := tx.QueryRowContext(ctx,
+		"SELECT status, end_time FROM auctions WHERE id = $1"+sr.Dialect.ForUpdate,
+		auctionId).Scan(&state.Status, &endTime); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return internal_error.NewNotFoundError("Auction not found")
+		}
+		logger.Error("Error trying to find auction by id = "+auctionId, err)
+		return internal_error.NewInternalServerError("Error trying to find auction by id")
+	}
+	state.EndTime = time.Unix(endTime, 0)
+
+	if !state.IsOpen(sr.Clock.Now()) {
+		return internal_error.NewBadRequestError("Auction is closed")
+	}
+
+	return nil
+}
+
+func (sr *SQLBidRepository) FindBidByAuctionId(
+	ctx context.Context, auctionId string) ([]bid_entity.Bid, *internal_error.InternalError) {
+
+	bids, err := sr.queryBids(ctx,
+		"SELECT "+bidColumns+" FROM bids WHERE auction_id = $1 ORDER BY seq", auctionId)
+	if err != nil {
+		logger.Error("Error finding bids", err)
+		return []bid_entity.Bid{}, nil
+	}
+
+	return bids, nil
+}
+
+func (sr *SQLBidRepository) FindBidsByAuctionIds(
+	ctx context.Context, auctionIds []string) ([]bid_entity.Bid, *internal_error.InternalError) {
+
+	if len(auctionIds) == 0 {
+		return []bid_entity.Bid{}, nil
+	}
+
+	args := make([]interface{}, 0, len(auctionIds))
+	for _, auctionId := range auctionIds {
+		args = append(args, auctionId)
+	}
+
+	bids, err := sr.queryBids(ctx,
+		"SELECT "+bidColumns+" FROM bids WHERE auction_id IN ("+sqldb.Placeholders(1, len(args))+
+			") ORDER BY amount DESC, seq", args...)
+	if err != nil {
+		logger.Error("Error finding bids by auction ids", err)
+		return nil, internal_error.NewInternalServerError("Error finding bids by auction ids")
+	}
+
+	if bids == nil {
+		bids = []bid_entity.Bid{}
+	}
+
+	return bids, nil
+}
+
+func (sr *SQLBidRepository) FindWinningBidByAuctionId(
+	ctx context.Context, auctionId string) (*bid_entity.Bid, *internal_error.InternalError) {
+
+	bids, err := sr.queryBids(ctx,
+		"SELECT "+bidColumns+" FROM bids WHERE auction_id = $1 ORDER BY amount DESC, seq LIMIT 1", auctionId)
+	if err != nil {
+		logger.Error("Error trying to find the auction winner", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find the auction winner")
+	}
+
+	if len(bids) == 0 {
+		return nil, nil
+	}
+
+	return &bids[0], nil
+}
+
+func (sr *SQLBidRepository) queryBids(
+	ctx context.Context, query string, args ...interface{}) ([]bid_entity.Bid, error) {
+
+	rows, err := sr.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bids []bid_entity.Bid
+	for rows.Next() {
+		var bid bid_entity.Bid
+		var timestamp int64
+		if err := rows.Scan(&bid.Id, &bid.UserId, &bid.AuctionId, &bid.Amount, &timestamp); err != nil {
+			return nil, err
+		}
+		bid.Timestamp = time.Unix(timestamp, 0)
+		bids = append(bids, bid)
+	}
+
+	return bids, rows.Err()
+}
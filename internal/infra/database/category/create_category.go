@@ -0,0 +1,90 @@
+package category
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+type CategoryEntityMongo struct {
+	Id        string `bson:"_id"`
+	Name      string `bson:"name"`
+	Slug      string `bson:"slug"`
+	ParentId  string `bson:"parent_id,omitempty"`
+	Timestamp int64  `bson:"timestamp"`
+}
+
+type CategoryRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewCategoryRepository(database *mongo.Database) *CategoryRepository {
+	return &CategoryRepository{
+		Collection: database.Collection("categories"),
+	}
+}
+
+func (cr *CategoryRepository) CreateCategory(
+	ctx context.Context,
+	categoryEntity *category_entity.Category) *internal_error.InternalError {
+
+	categoryEntityMongo := &CategoryEntityMongo{
+		Id:        categoryEntity.Id,
+		Name:      categoryEntity.Name,
+		Slug:      categoryEntity.Slug,
+		ParentId:  categoryEntity.ParentId,
+		Timestamp: categoryEntity.Timestamp.Unix(),
+	}
+
+	if _, err := cr.Collection.InsertOne(ctx, categoryEntityMongo); err != nil {
+		logger.Error("Error inserting category", err)
+		return internal_error.NewInternalServerError("Error inserting category")
+	}
+
+	return nil
+}
+
+func (cr *CategoryRepository) UpdateCategory(
+	ctx context.Context,
+	categoryEntity *category_entity.Category) *internal_error.InternalError {
+
+	update := bson.M{"$set": bson.M{"name": categoryEntity.Name, "slug": categoryEntity.Slug}}
+	if categoryEntity.ParentId == "" {
+		update["$unset"] = bson.M{"parent_id": ""}
+	} else {
+		update["$set"].(bson.M)["parent_id"] = categoryEntity.ParentId
+	}
+
+	result, err := cr.Collection.UpdateOne(ctx, bson.M{"_id": categoryEntity.Id}, update)
+	if err != nil {
+		logger.Error("Error updating category", err, zap.String("id", categoryEntity.Id))
+		return internal_error.NewInternalServerError("Error updating category")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError("Category not found")
+	}
+
+	return nil
+}
+
+func (cr *CategoryRepository) DeleteCategory(
+	ctx context.Context, id string) *internal_error.InternalError {
+
+	result, err := cr.Collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		logger.Error("Error deleting category", err, zap.String("id", id))
+		return internal_error.NewInternalServerError("Error deleting category")
+	}
+
+	if result.DeletedCount == 0 {
+		return internal_error.NewNotFoundError("Category not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package category
+
+import (
+	"context"
+	"errors"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func (cr *CategoryRepository) FindCategoryById(
+	ctx context.Context, id string) (*category_entity.Category, *internal_error.InternalError) {
+
+	return cr.findCategory(ctx, bson.M{"_id": id})
+}
+
+func (cr *CategoryRepository) FindCategoryBySlug(
+	ctx context.Context, slug string) (*category_entity.Category, *internal_error.InternalError) {
+
+	return cr.findCategory(ctx, bson.M{"slug": slug})
+}
+
+func (cr *CategoryRepository) findCategory(
+	ctx context.Context, filter bson.M) (*category_entity.Category, *internal_error.InternalError) {
+
+	var categoryEntityMongo CategoryEntityMongo
+	if err := cr.Collection.FindOne(ctx, filter).Decode(&categoryEntityMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, internal_error.NewNotFoundError("Category not found")
+		}
+
+		logger.Error("Error trying to find category", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find category")
+	}
+
+	categoryEntity := toCategory(categoryEntityMongo)
+	return &categoryEntity, nil
+}
+
+func (cr *CategoryRepository) FindCategories(
+	ctx context.Context) ([]category_entity.Category, *internal_error.InternalError) {
+
+	cursor, err := cr.Collection.Find(ctx, bson.M{})
+	if err != nil {
+		logger.Error("Error trying to find categories", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find categories")
+	}
+	defer cursor.Close(ctx)
+
+	var categoriesMongo []CategoryEntityMongo
+	if err := cursor.All(ctx, &categoriesMongo); err != nil {
+		logger.Error("Error trying to decode categories", err)
+		return nil, internal_error.NewInternalServerError("Error trying to decode categories")
+	}
+
+	categories := make([]category_entity.Category, 0, len(categoriesMongo))
+	for _, categoryEntityMongo := range categoriesMongo {
+		categories = append(categories, toCategory(categoryEntityMongo))
+	}
+
+	return categories, nil
+}
+
+func toCategory(categoryEntityMongo CategoryEntityMongo) category_entity.Category {
+	return category_entity.Category{
+		Id:        categoryEntityMongo.Id,
+		Name:      categoryEntityMongo.Name,
+		Slug:      categoryEntityMongo.Slug,
+		ParentId:  categoryEntityMongo.ParentId,
+		Timestamp: time.Unix(categoryEntityMongo.Timestamp, 0),
+	}
+}
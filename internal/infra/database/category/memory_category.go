@@ -0,0 +1,135 @@
+package category
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sync"
+	"time"
+)
+
+// MemoryCategoryRepository keeps categories in process memory with the same
+// behavior as CategoryRepository, including the unique slug index.
+type MemoryCategoryRepository struct {
+	mutex sync.RWMutex
+	// categories is in insertion order, which is the order Mongo returns
+	// unsorted queries in.
+	categories []*category_entity.Category
+}
+
+func NewMemoryCategoryRepository() *MemoryCategoryRepository {
+	return &MemoryCategoryRepository{}
+}
+
+func (mr *MemoryCategoryRepository) CreateCategory(
+	ctx context.Context,
+	categoryEntity *category_entity.Category) *internal_error.InternalError {
+
+	mr.mutex.Lock()
+	defer mr.mutex.Unlock()
+
+	for _, category := range mr.categories {
+		if category.Id == categoryEntity.Id || category.Slug == categoryEntity.Slug {
+			logger.Error("Error inserting category", fmt.Errorf("duplicate category %s", categoryEntity.Id))
+			return internal_error.NewInternalServerError("Error inserting category")
+		}
+	}
+
+	category := *categoryEntity
+	category.Timestamp = time.Unix(categoryEntity.Timestamp.Unix(), 0)
+	mr.categories = append(mr.categories, &category)
+
+	return nil
+}
+
+func (mr *MemoryCategoryRepository) UpdateCategory(
+	ctx context.Context,
+	categoryEntity *category_entity.Category) *internal_error.InternalError {
+
+	mr.mutex.Lock()
+	defer mr.mutex.Unlock()
+
+	var stored *category_entity.Category
+	for _, category := range mr.categories {
+		if category.Id == categoryEntity.Id {
+			stored = category
+		} else if category.Slug == categoryEntity.Slug {
+			logger.Error("Error updating category", fmt.Errorf("duplicate slug %s", categoryEntity.Slug))
+			return internal_error.NewInternalServerError("Error updating category")
+		}
+	}
+
+	if stored == nil {
+		return internal_error.NewNotFoundError("Category not found")
+	}
+
+	stored.Name = categoryEntity.Name
+	stored.Slug = categoryEntity.Slug
+	stored.ParentId = categoryEntity.ParentId
+
+	return nil
+}
+
+func (mr *MemoryCategoryRepository) DeleteCategory(
+	ctx context.Context, id string) *internal_error.InternalError {
+
+	mr.mutex.Lock()
+	defer mr.mutex.Unlock()
+
+	for index, category := range mr.categories {
+		if category.Id == id {
+			mr.categories = append(mr.categories[:index], mr.categories[index+1:]...)
+			return nil
+		}
+	}
+
+	return internal_error.NewNotFoundError("Category not found")
+}
+
+func (mr *MemoryCategoryRepository) FindCategoryById(
+	ctx context.Context, id string) (*category_entity.Category, *internal_error.InternalError) {
+
+	return mr.findCategory(func(category *category_entity.Category) bool {
+		return category.Id == id
+	})
+}
+
+func (mr *MemoryCategoryRepository) FindCategoryBySlug(
+	ctx context.Context, slug string) (*category_entity.Category, *internal_error.InternalError) {
+
+	return mr.findCategory(func(category *category_entity.Category) bool {
+		return category.Slug == slug
+	})
+}
+
+func (mr *MemoryCategoryRepository) findCategory(
+	matches func(*category_entity.Category) bool) (*category_entity.Category, *internal_error.InternalError) {
+
+	mr.mutex.RLock()
+	defer mr.mutex.RUnlock()
+
+	for _, category := range mr.categories {
+		if matches(category) {
+			found := *category
+			return &found, nil
+		}
+	}
+
+	return nil, internal_error.NewNotFoundError("Category not found")
+}
+
+func (mr *MemoryCategoryRepository) FindCategories(
+	ctx context.Context) ([]category_entity.Category, *internal_error.InternalError) {
+
+	mr.mutex.RLock()
+	defer mr.mutex.RUnlock()
+
+	categories := make([]category_entity.Category, 0, len(mr.categories))
+	for _, category := range mr.categories {
+		categories = append(categories, *category)
+	}
+
+	return categories, nil
+}
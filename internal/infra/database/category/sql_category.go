@@ -0,0 +1,160 @@
+package category
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const categoryColumns = "id, name, slug, parent_id, timestamp"
+
+// SQLCategoryRepository stores categories in the categories table of a SQL
+// database, created by the sqldb migrations. Root categories have a NULL
+// parent_id.
+type SQLCategoryRepository struct {
+	DB *sql.DB
+}
+
+func NewSQLCategoryRepository(db *sql.DB) *SQLCategoryRepository {
+	return &SQLCategoryRepository{DB: db}
+}
+
+func (sr *SQLCategoryRepository) CreateCategory(
+	ctx context.Context,
+	categoryEntity *category_entity.Category) *internal_error.InternalError {
+
+	if _, err := sr.DB.ExecContext(ctx,
+		"INSERT INTO categories ("+categoryColumns+") VALUES ($1, $2, $3, $4, $5)",
+		categoryEntity.Id,
+		categoryEntity.Name,
+		categoryEntity.Slug,
+		nullableParentId(categoryEntity.ParentId),
+		categoryEntity.Timestamp.Unix()); err != nil {
+		logger.Error("Error inserting category", err)
+		return internal_error.NewInternalServerError("Error inserting category")
+	}
+
+	return nil
+}
+
+func (sr *SQLCategoryRepository) UpdateCategory(
+	ctx context.Context,
+	categoryEntity *category_entity.Category) *internal_error.InternalError {
+
+	result, err := sr.DB.ExecContext(ctx,
+		"UPDATE categories SET name = $1, slug = $2, parent_id = $3 WHERE id = $4",
+		categoryEntity.Name,
+		categoryEntity.Slug,
+		nullableParentId(categoryEntity.ParentId),
+		categoryEntity.Id)
+	if err != nil {
+		logger.Error("Error updating category", err, zap.String("id", categoryEntity.Id))
+		return internal_error.NewInternalServerError("Error updating category")
+	}
+
+	return notFoundWhenUnchanged(result, "Error updating category")
+}
+
+func (sr *SQLCategoryRepository) DeleteCategory(
+	ctx context.Context, id string) *internal_error.InternalError {
+
+	result, err := sr.DB.ExecContext(ctx, "DELETE FROM categories WHERE id = $1", id)
+	if err != nil {
+		logger.Error("Error deleting category", err, zap.String("id", id))
+		return internal_error.NewInternalServerError("Error deleting category")
+	}
+
+	return notFoundWhenUnchanged(result, "Error deleting category")
+}
+
+func (sr *SQLCategoryRepository) FindCategoryById(
+	ctx context.Context, id string) (*category_entity.Category, *internal_error.InternalError) {
+
+	return scanCategory(sr.DB.QueryRowContext(ctx,
+		"SELECT "+categoryColumns+" FROM categories WHERE id = $1", id))
+}
+
+func (sr *SQLCategoryRepository) FindCategoryBySlug(
+	ctx context.Context, slug string) (*category_entity.Category, *internal_error.InternalError) {
+
+	return scanCategory(sr.DB.QueryRowContext(ctx,
+		"SELECT "+categoryColumns+" FROM categories WHERE slug = $1", slug))
+}
+
+func (sr *SQLCategoryRepository) FindCategories(
+	ctx context.Context) ([]category_entity.Category, *internal_error.InternalError) {
+
+	rows, err := sr.DB.QueryContext(ctx, "SELECT "+categoryColumns+" FROM categories ORDER BY seq")
+	if err != nil {
+		logger.Error("Error trying to find categories", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find categories")
+	}
+	defer rows.Close()
+
+	categories := []category_entity.Category{}
+	for rows.Next() {
+		categoryEntity, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, *categoryEntity)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.Error("Error trying to decode categories", err)
+		return nil, internal_error.NewInternalServerError("Error trying to decode categories")
+	}
+
+	return categories, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanCategory(row rowScanner) (*category_entity.Category, *internal_error.InternalError) {
+	var categoryEntity category_entity.Category
+	var parentId sql.NullString
+	var timestamp int64
+	if err := row.Scan(
+		&categoryEntity.Id,
+		&categoryEntity.Name,
+		&categoryEntity.Slug,
+		&parentId,
+		&timestamp); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, internal_error.NewNotFoundError("Category not found")
+		}
+
+		logger.Error("Error trying to find category", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find category")
+	}
+
+	categoryEntity.ParentId = parentId.String
+	categoryEntity.Timestamp = time.Unix(timestamp, 0)
+	return &categoryEntity, nil
+}
+
+func nullableParentId(parentId string) sql.NullString {
+	return sql.NullString{String: parentId, Valid: parentId != ""}
+}
+
+func notFoundWhenUnchanged(result sql.Result, message string) *internal_error.InternalError {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		logger.Error(message, err)
+		return internal_error.NewInternalServerError(message)
+	}
+
+	if affected == 0 {
+		return internal_error.NewNotFoundError("Category not found")
+	}
+
+	return nil
+}
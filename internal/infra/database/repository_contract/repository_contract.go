@@ -0,0 +1,646 @@
+// Package repository_contract holds the behavior every storage backend must
+// share. A backend's tests call Run with a constructor for its repositories,
+// so Mongo, memory and any later backend are checked against the same cases.
+package repository_contract
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/clock"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/infra/event"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// AuctionRepository is the auction repository of a backend, including the
+// closing of expired auctions that the application runs in the background.
+type AuctionRepository interface {
+	auction_entity.AuctionRepositoryInterface
+	CloseExpiredAuctions(ctx context.Context)
+}
+
+type Backend struct {
+	Auctions   AuctionRepository
+	Bids       bid_entity.BidEntityRepository
+	Users      user_entity.UserRepositoryInterface
+	Categories category_entity.CategoryRepositoryInterface
+
+	// SaveUser stores a user. Users are written outside the API, so the
+	// repository interface has no method for it.
+	SaveUser func(ctx context.Context, user user_entity.User) error
+}
+
+// NewBackend returns empty repositories that publish their events to
+// eventDispatcher and read the time from clock. It is called once per case;
+// cleanup goes in t.Cleanup.
+type NewBackend func(
+	t *testing.T,
+	eventDispatcher event_entity.EventDispatcherInterface,
+	clock clock.Clock) Backend
+
+const unknownId = "2c8a3f1e-5d47-4b8b-9a0e-7f3b6c1d2e4f"
+
+// Run checks the backend against every case. Time only moves when a case
+// advances the fake clock passed to newBackend.
+func Run(t *testing.T, newBackend NewBackend) {
+	cases := []struct {
+		name string
+		run  func(t *testing.T, newBackend NewBackend)
+	}{
+		{"CreateAuctionAndFindById", testCreateAuctionAndFindById},
+		{"FindAuctionByIdErrors", testFindAuctionByIdErrors},
+		{"FindAuctionsFilters", testFindAuctionsFilters},
+		{"SearchAuctions", testSearchAuctions},
+		{"UpdateCategoryName", testUpdateCategoryName},
+		{"CloseExpiredAuctions", testCloseExpiredAuctions},
+		{"CreateBidPlacesOutbiddingBidsInOrder", testCreateBidPlacesOutbiddingBidsInOrder},
+		{"CreateBidRejectsUnknownAndClosedAuctions", testCreateBidRejectsUnknownAndClosedAuctions},
+		{"FindBidsByAuctionIdsOrdersByAmount", testFindBidsByAuctionIdsOrdersByAmount},
+		{"FindWinningBidWithoutBids", testFindWinningBidWithoutBids},
+		{"FindUsers", testFindUsers},
+		{"Categories", testCategories},
+	}
+
+	for _, testCase := range cases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.run(t, newBackend)
+		})
+	}
+}
+
+// start is deliberately not a whole second, since times are stored with
+// second precision.
+var start = time.Date(2024, time.March, 1, 12, 0, 0, 500000000, time.UTC)
+
+type fixture struct {
+	Backend
+	events <-chan event_entity.Event
+	clock  *clock.FakeClock
+}
+
+// setup builds a backend on a fake clock with a subscription to all of its
+// events.
+func setup(t *testing.T, newBackend NewBackend) *fixture {
+	eventDispatcher := event.NewEventDispatcher()
+	events, unsubscribe := eventDispatcher.Subscribe(100)
+	t.Cleanup(unsubscribe)
+
+	fakeClock := clock.NewFakeClock(start)
+
+	return &fixture{
+		Backend: newBackend(t, eventDispatcher, fakeClock),
+		events:  events,
+		clock:   fakeClock,
+	}
+}
+
+// createAuction creates an auction whose category name is category, like
+// the auctions created before the category tree.
+func (f *fixture) createAuction(
+	t *testing.T, productName, category string) *auction_entity.Auction {
+
+	auctionEntity, internalErr := auction_entity.CreateAuction(
+		productName, category, "Contract test auction", auction_entity.New, f.clock.Now())
+	require.Nil(t, internalErr)
+	auctionEntity.CategoryName = category
+	require.Nil(t, f.Auctions.CreateAuction(context.Background(), auctionEntity))
+
+	return auctionEntity
+}
+
+func (f *fixture) newBid(t *testing.T, auctionId string, amount float64) bid_entity.Bid {
+	bidEntity, internalErr := bid_entity.CreateBid(
+		"6b0d6f1c-0b5e-4d6b-8f8e-2b9d1a3c4e5f", auctionId, amount, f.clock.Now())
+	require.Nil(t, internalErr)
+
+	return *bidEntity
+}
+
+func bidIds(bids []bid_entity.Bid) []string {
+	ids := make([]string, 0, len(bids))
+	for _, bid := range bids {
+		ids = append(ids, bid.Id)
+	}
+
+	return ids
+}
+
+func auctionIds(auctions []auction_entity.Auction) []string {
+	ids := make([]string, 0, len(auctions))
+	for _, auction := range auctions {
+		ids = append(ids, auction.Id)
+	}
+
+	return ids
+}
+
+// nextEvent returns the next event or fails after a second.
+func nextEvent(t *testing.T, events <-chan event_entity.Event) event_entity.Event {
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for an event")
+		return event_entity.Event{}
+	}
+}
+
+func assertNoEvent(t *testing.T, events <-chan event_entity.Event) {
+	select {
+	case e := <-events:
+		t.Fatalf("unexpected %s event", e.Type)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func testCreateAuctionAndFindById(t *testing.T, newBackend NewBackend) {
+	t.Setenv("AUCTION_DURATION", "1h")
+	ctx := context.Background()
+	f := setup(t, newBackend)
+
+	auctionEntity := f.createAuction(t, "Vintage Camera", "Cameras")
+	assert.Equal(t, start.Add(time.Hour).Unix(), auctionEntity.EndTime.Unix())
+
+	created := nextEvent(t, f.events)
+	assert.Equal(t, event_entity.AuctionCreated, created.Type)
+	assert.Equal(t, auctionEntity.Id, created.AuctionId)
+
+	found, internalErr := f.Auctions.FindAuctionById(ctx, auctionEntity.Id)
+	require.Nil(t, internalErr)
+	assert.Equal(t, auctionEntity.Id, found.Id)
+	assert.Equal(t, "Vintage Camera", found.ProductName)
+	assert.Equal(t, "Cameras", found.Category)
+	assert.Equal(t, "Cameras", found.CategoryName)
+	assert.Equal(t, "Contract test auction", found.Description)
+	assert.Equal(t, auction_entity.New, found.Condition)
+	assert.Equal(t, auction_entity.Active, found.Status)
+
+	// Times are stored with second precision.
+	assert.Equal(t, auctionEntity.Timestamp.Unix(), found.Timestamp.Unix())
+	assert.Zero(t, found.Timestamp.Nanosecond())
+	assert.Equal(t, auctionEntity.EndTime.Unix(), found.EndTime.Unix())
+}
+
+func testFindAuctionByIdErrors(t *testing.T, newBackend NewBackend) {
+	ctx := context.Background()
+	f := setup(t, newBackend)
+
+	_, internalErr := f.Auctions.FindAuctionById(ctx, "not-a-uuid")
+	require.NotNil(t, internalErr)
+	assert.Equal(t, "bad_request", internalErr.Err)
+
+	_, internalErr = f.Auctions.FindAuctionById(ctx, unknownId)
+	require.NotNil(t, internalErr)
+	assert.Equal(t, "not_found", internalErr.Err)
+}
+
+func testFindAuctionsFilters(t *testing.T, newBackend NewBackend) {
+	t.Setenv("AUCTION_DURATION", "1h")
+	ctx := context.Background()
+	f := setup(t, newBackend)
+
+	phone := f.createAuction(t, "Smartphone X", "Phones")
+	laptop := f.createAuction(t, "Laptop Pro", "Computers")
+	oldPhone := f.createAuction(t, "Old SMARTPHONE", "Phones")
+
+	cases := []struct {
+		name        string
+		status      auction_entity.AuctionStatus
+		categories  []string
+		productName string
+		want        []string
+	}{
+		{"no filter, insertion order", 0, nil, "", []string{phone.Id, laptop.Id, oldPhone.Id}},
+		{"category", 0, []string{"Phones"}, "", []string{phone.Id, oldPhone.Id}},
+		{"category is matched exactly", 0, []string{"Phone"}, "", []string{}},
+		{"any of the categories", 0, []string{"Computers", "Phones"}, "", []string{phone.Id, laptop.Id, oldPhone.Id}},
+		{"product name ignores case", 0, nil, "smartphone", []string{phone.Id, oldPhone.Id}},
+		{"product name is a substring", 0, nil, "top p", []string{laptop.Id}},
+		{"product name is not a pattern", 0, nil, "^laptop", []string{}},
+		{"category and product name", 0, []string{"Phones"}, "old", []string{oldPhone.Id}},
+		{"completed status", auction_entity.Completed, nil, "", []string{}},
+		{"no match", 0, nil, "tablet", []string{}},
+	}
+
+	for _, testCase := range cases {
+		auctions, internalErr := f.Auctions.FindAuctions(
+			ctx, testCase.status, testCase.categories, testCase.productName)
+		require.Nil(t, internalErr, testCase.name)
+		assert.NotNil(t, auctions, testCase.name)
+		assert.Equal(t, testCase.want, auctionIds(auctions), testCase.name)
+	}
+}
+
+func testSearchAuctions(t *testing.T, newBackend NewBackend) {
+	t.Setenv("AUCTION_DURATION", "1h")
+	ctx := context.Background()
+	f := setup(t, newBackend)
+
+	// Auctions store the category id; search matches the category name.
+	const (
+		phones      = "8f14e45f-ceea-467f-a0e6-3b1c2f6d4a11"
+		accessories = "c9f0f895-fb98-4ab6-9b2f-5d3e1a7c8b22"
+		computers   = "45c48cce-2e2d-4fbd-8a4c-7e9b0d1f3c33"
+	)
+
+	create := func(
+		productName, category, categoryName, description string,
+		condition auction_entity.ProductCondition) string {
+		auctionEntity, internalErr := auction_entity.CreateAuction(
+			productName, category, description, condition, f.clock.Now())
+		require.Nil(t, internalErr)
+		auctionEntity.CategoryName = categoryName
+		require.Nil(t, f.Auctions.CreateAuction(ctx, auctionEntity))
+		return auctionEntity.Id
+	}
+
+	galaxy := create("Smartphone Galaxy", phones, "Phones", "Aparelho novo com câmera", auction_entity.New)
+	phoneCase := create("Capa protetora", accessories, "Acessórios", "Capa para qualquer smartphone", auction_entity.Used)
+	laptop := create("Laptop Pro", computers, "Computers", "Notebook para trabalho", auction_entity.New)
+	oldPhone := create("Smartphone Antigo", phones, "Phones", "Smartphone usado com marcas", auction_entity.Used)
+
+	active := auction_entity.Active
+	completed := auction_entity.Completed
+
+	type facets struct {
+		categories []auction_entity.CategoryCount
+		conditions []auction_entity.ConditionCount
+	}
+	allFacets := facets{
+		categories: []auction_entity.CategoryCount{{Category: phones, Count: 2}, {Category: accessories, Count: 1}},
+		conditions: []auction_entity.ConditionCount{{Condition: auction_entity.New, Count: 1}, {Condition: auction_entity.Used, Count: 2}},
+	}
+
+	cases := []struct {
+		name   string
+		query  auction_entity.SearchQuery
+		want   []string
+		total  int
+		facets *facets
+	}{
+		{"name weighs more than description",
+			auction_entity.SearchQuery{Text: "smartphone", Limit: 10},
+			[]string{oldPhone, galaxy, phoneCase}, 3, &allFacets},
+		{"any word matches",
+			auction_entity.SearchQuery{Text: "SMARTPHONE galaxy", Limit: 10},
+			[]string{galaxy, oldPhone, phoneCase}, 3, nil},
+		{"query syntax is ignored",
+			auction_entity.SearchQuery{Text: `"smartphone" -galaxy`, Limit: 10},
+			[]string{galaxy, oldPhone, phoneCase}, 3, nil},
+		{"regular expressions are plain words",
+			auction_entity.SearchQuery{Text: "smart.*", Limit: 10},
+			[]string{}, 0, nil},
+		{"accents are ignored",
+			auction_entity.SearchQuery{Text: "camera", Limit: 10},
+			[]string{galaxy}, 1, nil},
+		{"category name matches",
+			auction_entity.SearchQuery{Text: "acessorios", Limit: 10},
+			[]string{phoneCase}, 1, nil},
+		{"category id does not match",
+			auction_entity.SearchQuery{Text: "45c48cce", Limit: 10},
+			[]string{}, 0, nil},
+		{"category name weighs more than description",
+			auction_entity.SearchQuery{Text: "computers notebook", Limit: 10},
+			[]string{laptop}, 1, nil},
+		{"limit keeps the best hits and the total",
+			auction_entity.SearchQuery{Text: "smartphone", Limit: 1},
+			[]string{oldPhone}, 3, nil},
+		{"category filter keeps the other categories in the facet",
+			auction_entity.SearchQuery{Text: "smartphone", Category: phones, Limit: 10},
+			[]string{oldPhone, galaxy}, 2, &facets{
+				categories: allFacets.categories,
+				conditions: []auction_entity.ConditionCount{
+					{Condition: auction_entity.New, Count: 1}, {Condition: auction_entity.Used, Count: 1}},
+			}},
+		{"condition filter keeps the other conditions in the facet",
+			auction_entity.SearchQuery{Text: "smartphone", Condition: auction_entity.Used, Limit: 10},
+			[]string{oldPhone, phoneCase}, 2, &facets{
+				categories: []auction_entity.CategoryCount{
+					{Category: phones, Count: 1}, {Category: accessories, Count: 1}},
+				conditions: allFacets.conditions,
+			}},
+		{"active status", auction_entity.SearchQuery{Text: "smartphone", Status: &active, Limit: 10},
+			[]string{oldPhone, galaxy, phoneCase}, 3, nil},
+		{"completed status", auction_entity.SearchQuery{Text: "smartphone", Status: &completed, Limit: 10},
+			[]string{}, 0, nil},
+	}
+
+	for _, testCase := range cases {
+		result, internalErr := f.Auctions.SearchAuctions(ctx, testCase.query)
+		require.Nil(t, internalErr, testCase.name)
+
+		ids := make([]string, 0, len(result.Hits))
+		for i, hit := range result.Hits {
+			ids = append(ids, hit.Auction.Id)
+			assert.Greater(t, hit.Score, 0.0, testCase.name)
+			if i > 0 {
+				assert.GreaterOrEqual(t, result.Hits[i-1].Score, hit.Score, testCase.name)
+			}
+		}
+
+		assert.Equal(t, testCase.want, ids, testCase.name)
+		assert.Equal(t, testCase.total, result.Total, testCase.name)
+		if testCase.facets != nil {
+			assert.Equal(t, testCase.facets.categories, result.Categories, testCase.name)
+			assert.Equal(t, testCase.facets.conditions, result.Conditions, testCase.name)
+		}
+	}
+}
+
+func testUpdateCategoryName(t *testing.T, newBackend NewBackend) {
+	t.Setenv("AUCTION_DURATION", "1h")
+	ctx := context.Background()
+	f := setup(t, newBackend)
+
+	const computers = "45c48cce-2e2d-4fbd-8a4c-7e9b0d1f3c33"
+	laptop := f.createAuction(t, "Laptop Pro", computers)
+	book := f.createAuction(t, "Livro de receitas", "Books")
+
+	require.Nil(t, f.Auctions.UpdateCategoryName(ctx, computers, "Informática"))
+
+	found, internalErr := f.Auctions.FindAuctionById(ctx, laptop.Id)
+	require.Nil(t, internalErr)
+	assert.Equal(t, computers, found.Category)
+	assert.Equal(t, "Informática", found.CategoryName)
+
+	found, internalErr = f.Auctions.FindAuctionById(ctx, book.Id)
+	require.Nil(t, internalErr)
+	assert.Equal(t, "Books", found.CategoryName)
+
+	for text, want := range map[string][]string{
+		"informatica": {laptop.Id},
+		"computers":   {},
+		"books":       {book.Id},
+	} {
+		result, internalErr := f.Auctions.SearchAuctions(ctx, auction_entity.SearchQuery{Text: text, Limit: 10})
+		require.Nil(t, internalErr, text)
+
+		ids := []string{}
+		for _, hit := range result.Hits {
+			ids = append(ids, hit.Auction.Id)
+		}
+		assert.Equal(t, want, ids, text)
+	}
+}
+
+func testCloseExpiredAuctions(t *testing.T, newBackend NewBackend) {
+	ctx := context.Background()
+	f := setup(t, newBackend)
+
+	t.Setenv("AUCTION_DURATION", "1m")
+	expiring := f.createAuction(t, "Expiring", "Category")
+	t.Setenv("AUCTION_DURATION", "1h")
+	lasting := f.createAuction(t, "Lasting", "Category")
+	nextEvent(t, f.events)
+	nextEvent(t, f.events)
+
+	// Nothing has expired yet.
+	f.clock.Advance(59 * time.Second)
+	f.Auctions.CloseExpiredAuctions(ctx)
+	assertNoEvent(t, f.events)
+
+	f.clock.Advance(time.Second)
+	f.Auctions.CloseExpiredAuctions(ctx)
+
+	closed := nextEvent(t, f.events)
+	assert.Equal(t, event_entity.AuctionClosed, closed.Type)
+	assert.Equal(t, expiring.Id, closed.AuctionId)
+
+	found, internalErr := f.Auctions.FindAuctionById(ctx, expiring.Id)
+	require.Nil(t, internalErr)
+	assert.Equal(t, auction_entity.Completed, found.Status)
+
+	found, internalErr = f.Auctions.FindAuctionById(ctx, lasting.Id)
+	require.Nil(t, internalErr)
+	assert.Equal(t, auction_entity.Active, found.Status)
+
+	completed, internalErr := f.Auctions.FindAuctions(ctx, auction_entity.Completed, nil, "")
+	require.Nil(t, internalErr)
+	assert.Equal(t, []string{expiring.Id}, auctionIds(completed))
+
+	// An auction is closed only once.
+	f.Auctions.CloseExpiredAuctions(ctx)
+	assertNoEvent(t, f.events)
+}
+
+func testCreateBidPlacesOutbiddingBidsInOrder(t *testing.T, newBackend NewBackend) {
+	t.Setenv("AUCTION_DURATION", "1h")
+	ctx := context.Background()
+	f := setup(t, newBackend)
+
+	auctionEntity := f.createAuction(t, "Product", "Category")
+	nextEvent(t, f.events)
+
+	first := f.newBid(t, auctionEntity.Id, 10)
+	lower := f.newBid(t, auctionEntity.Id, 5)
+	second := f.newBid(t, auctionEntity.Id, 20)
+	equal := f.newBid(t, auctionEntity.Id, 20)
+
+	failures := f.Bids.CreateBid(ctx, []bid_entity.Bid{first, lower, second, equal})
+	require.Len(t, failures, 2)
+	assert.Equal(t, lower.Id, failures[0].Bid.Id)
+	assert.Equal(t, "bad_request", failures[0].Err.Err)
+	assert.Equal(t, equal.Id, failures[1].Bid.Id)
+	assert.Equal(t, "bad_request", failures[1].Err.Err)
+
+	for i := 0; i < 2; i++ {
+		created := nextEvent(t, f.events)
+		assert.Equal(t, event_entity.BidCreated, created.Type)
+		assert.Equal(t, auctionEntity.Id, created.AuctionId)
+	}
+	assertNoEvent(t, f.events)
+
+	// A later batch must beat the best bid already stored.
+	failures = f.Bids.CreateBid(ctx, []bid_entity.Bid{f.newBid(t, auctionEntity.Id, 15)})
+	require.Len(t, failures, 1)
+	assert.Equal(t, "bad_request", failures[0].Err.Err)
+
+	bids, internalErr := f.Bids.FindBidByAuctionId(ctx, auctionEntity.Id)
+	require.Nil(t, internalErr)
+	assert.Equal(t, []string{first.Id, second.Id}, bidIds(bids))
+	assert.Equal(t, first.Timestamp.Unix(), bids[0].Timestamp.Unix())
+	assert.Zero(t, bids[0].Timestamp.Nanosecond())
+
+	winner, internalErr := f.Bids.FindWinningBidByAuctionId(ctx, auctionEntity.Id)
+	require.Nil(t, internalErr)
+	assert.Equal(t, second.Id, winner.Id)
+	assert.Equal(t, 20.0, winner.Amount)
+	assert.Equal(t, second.UserId, winner.UserId)
+}
+
+func testCreateBidRejectsUnknownAndClosedAuctions(t *testing.T, newBackend NewBackend) {
+	ctx := context.Background()
+	f := setup(t, newBackend)
+
+	t.Setenv("AUCTION_DURATION", "1m")
+	expiring := f.createAuction(t, "Expiring", "Category")
+	t.Setenv("AUCTION_DURATION", "1h")
+	open := f.createAuction(t, "Open", "Category")
+
+	unknown := f.newBid(t, unknownId, 10)
+	failures := f.Bids.CreateBid(ctx, []bid_entity.Bid{unknown})
+	require.Len(t, failures, 1)
+	assert.Equal(t, "not_found", failures[0].Err.Err)
+
+	f.clock.Advance(time.Minute)
+
+	// An auction past its end time takes no bids even before the closer ran,
+	// and a failing auction does not affect the others of the batch.
+	late := f.newBid(t, expiring.Id, 10)
+	placed := f.newBid(t, open.Id, 10)
+	failures = f.Bids.CreateBid(ctx, []bid_entity.Bid{late, placed})
+	require.Len(t, failures, 1)
+	assert.Equal(t, late.Id, failures[0].Bid.Id)
+	assert.Equal(t, "bad_request", failures[0].Err.Err)
+
+	f.Auctions.CloseExpiredAuctions(ctx)
+	failures = f.Bids.CreateBid(ctx, []bid_entity.Bid{f.newBid(t, expiring.Id, 20)})
+	require.Len(t, failures, 1)
+	assert.Equal(t, "bad_request", failures[0].Err.Err)
+
+	bids, internalErr := f.Bids.FindBidByAuctionId(ctx, expiring.Id)
+	require.Nil(t, internalErr)
+	assert.Empty(t, bids)
+
+	bids, internalErr = f.Bids.FindBidByAuctionId(ctx, open.Id)
+	require.Nil(t, internalErr)
+	assert.Equal(t, []string{placed.Id}, bidIds(bids))
+}
+
+func testFindBidsByAuctionIdsOrdersByAmount(t *testing.T, newBackend NewBackend) {
+	t.Setenv("AUCTION_DURATION", "1h")
+	ctx := context.Background()
+	f := setup(t, newBackend)
+
+	first := f.createAuction(t, "First", "Category")
+	second := f.createAuction(t, "Second", "Category")
+	other := f.createAuction(t, "Other", "Category")
+
+	firstLow := f.newBid(t, first.Id, 10)
+	firstHigh := f.newBid(t, first.Id, 40)
+	secondBid := f.newBid(t, second.Id, 25)
+	otherBid := f.newBid(t, other.Id, 100)
+	require.Empty(t, f.Bids.CreateBid(ctx, []bid_entity.Bid{firstLow, secondBid, firstHigh, otherBid}))
+
+	bids, internalErr := f.Bids.FindBidsByAuctionIds(ctx, []string{first.Id, second.Id, unknownId})
+	require.Nil(t, internalErr)
+	assert.Equal(t, []string{firstHigh.Id, secondBid.Id, firstLow.Id}, bidIds(bids))
+
+	bids, internalErr = f.Bids.FindBidsByAuctionIds(ctx, []string{})
+	require.Nil(t, internalErr)
+	assert.NotNil(t, bids)
+	assert.Empty(t, bids)
+}
+
+func testFindWinningBidWithoutBids(t *testing.T, newBackend NewBackend) {
+	t.Setenv("AUCTION_DURATION", "1h")
+	ctx := context.Background()
+	f := setup(t, newBackend)
+
+	auctionEntity := f.createAuction(t, "Product", "Category")
+
+	// No bids is a normal state, not an error.
+	winner, internalErr := f.Bids.FindWinningBidByAuctionId(ctx, auctionEntity.Id)
+	require.Nil(t, internalErr)
+	assert.Nil(t, winner)
+
+	bids, internalErr := f.Bids.FindBidByAuctionId(ctx, auctionEntity.Id)
+	require.Nil(t, internalErr)
+	assert.Empty(t, bids)
+}
+
+func testFindUsers(t *testing.T, newBackend NewBackend) {
+	ctx := context.Background()
+	f := setup(t, newBackend)
+
+	maria := user_entity.User{Id: "6b0d6f1c-0b5e-4d6b-8f8e-2b9d1a3c4e5f", Name: "Maria"}
+	joao := user_entity.User{Id: "9f1e2d3c-4b5a-4c6d-8e7f-0a1b2c3d4e5f", Name: "João"}
+	require.NoError(t, f.SaveUser(ctx, maria))
+	require.NoError(t, f.SaveUser(ctx, joao))
+
+	found, internalErr := f.Users.FindUserById(ctx, maria.Id)
+	require.Nil(t, internalErr)
+	assert.Equal(t, maria, *found)
+
+	_, internalErr = f.Users.FindUserById(ctx, unknownId)
+	require.NotNil(t, internalErr)
+	assert.Equal(t, "not_found", internalErr.Err)
+	assert.Equal(t, "User not found with this id = "+unknownId, internalErr.Message)
+
+	users, internalErr := f.Users.FindUsersByIds(ctx, []string{joao.Id, unknownId, maria.Id})
+	require.Nil(t, internalErr)
+	assert.ElementsMatch(t, []user_entity.User{maria, joao}, users)
+
+	users, internalErr = f.Users.FindUsersByIds(ctx, []string{unknownId})
+	require.Nil(t, internalErr)
+	assert.NotNil(t, users)
+	assert.Empty(t, users)
+}
+
+func testCategories(t *testing.T, newBackend NewBackend) {
+	ctx := context.Background()
+	f := setup(t, newBackend)
+
+	categories, internalErr := f.Categories.FindCategories(ctx)
+	require.Nil(t, internalErr)
+	assert.NotNil(t, categories)
+	assert.Empty(t, categories)
+
+	music, internalErr := category_entity.CreateCategory("Música", "", "", f.clock.Now())
+	require.Nil(t, internalErr)
+	require.Nil(t, f.Categories.CreateCategory(ctx, music))
+	f.clock.Advance(time.Second)
+	guitars, internalErr := category_entity.CreateCategory("Guitars", "", music.Id, f.clock.Now())
+	require.Nil(t, internalErr)
+	require.Nil(t, f.Categories.CreateCategory(ctx, guitars))
+
+	found, internalErr := f.Categories.FindCategoryById(ctx, guitars.Id)
+	require.Nil(t, internalErr)
+	assert.Equal(t, guitars.Name, found.Name)
+	assert.Equal(t, "guitars", found.Slug)
+	assert.Equal(t, music.Id, found.ParentId)
+	assert.Equal(t, start.Add(time.Second).Unix(), found.Timestamp.Unix())
+
+	found, internalErr = f.Categories.FindCategoryBySlug(ctx, "musica")
+	require.Nil(t, internalErr)
+	assert.Equal(t, music.Id, found.Id)
+	assert.Equal(t, "", found.ParentId)
+
+	// Moving guitars to the root clears its parent.
+	require.Nil(t, guitars.Change("Electric guitars", "", ""))
+	require.Nil(t, f.Categories.UpdateCategory(ctx, guitars))
+
+	categories, internalErr = f.Categories.FindCategories(ctx)
+	require.Nil(t, internalErr)
+	if assert.Len(t, categories, 2) {
+		assert.Equal(t, music.Id, categories[0].Id)
+		assert.Equal(t, guitars.Id, categories[1].Id)
+		assert.Equal(t, "electric-guitars", categories[1].Slug)
+		assert.Equal(t, "", categories[1].ParentId)
+	}
+
+	require.Nil(t, f.Categories.DeleteCategory(ctx, guitars.Id))
+	_, internalErr = f.Categories.FindCategoryById(ctx, guitars.Id)
+	require.NotNil(t, internalErr)
+	assert.Equal(t, "not_found", internalErr.Err)
+
+	_, internalErr = f.Categories.FindCategoryBySlug(ctx, "electric-guitars")
+	require.NotNil(t, internalErr)
+	assert.Equal(t, "not_found", internalErr.Err)
+
+	internalErr = f.Categories.UpdateCategory(ctx, guitars)
+	require.NotNil(t, internalErr)
+	assert.Equal(t, "not_found", internalErr.Err)
+
+	internalErr = f.Categories.DeleteCategory(ctx, guitars.Id)
+	require.NotNil(t, internalErr)
+	assert.Equal(t, "not_found", internalErr.Err)
+}
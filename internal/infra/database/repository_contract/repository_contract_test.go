@@ -0,0 +1,185 @@
+package repository_contract_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"fullcycle-auction_go/configuration/database/sqldb"
+	"fullcycle-auction_go/internal/clock"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"fullcycle-auction_go/internal/infra/database/bid"
+	"fullcycle-auction_go/internal/infra/database/category"
+	"fullcycle-auction_go/internal/infra/database/repository_contract"
+	"fullcycle-auction_go/internal/infra/database/user"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestMemoryRepositories(t *testing.T) {
+	repository_contract.Run(t, func(
+		t *testing.T,
+		eventDispatcher event_entity.EventDispatcherInterface,
+		clock clock.Clock) repository_contract.Backend {
+
+		auctionRepository := auction.NewMemoryAuctionRepository(eventDispatcher, clock)
+		userRepository := user.NewMemoryUserRepository()
+
+		return repository_contract.Backend{
+			Auctions:   auctionRepository,
+			Bids:       bid.NewMemoryBidRepository(auctionRepository, eventDispatcher, clock),
+			Users:      userRepository,
+			Categories: category.NewMemoryCategoryRepository(),
+			SaveUser: func(ctx context.Context, userEntity user_entity.User) error {
+				userRepository.SaveUser(userEntity)
+				return nil
+			},
+		}
+	})
+}
+
+// TestMongoRepositories needs a MongoDB and is skipped unless
+// MONGODB_TEST_URL is set. Each case gets its own database.
+func TestMongoRepositories(t *testing.T) {
+	mongoURL := os.Getenv("MONGODB_TEST_URL")
+	if mongoURL == "" {
+		t.Skip("MONGODB_TEST_URL not set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURL))
+	if err != nil {
+		t.Fatalf("connecting to MongoDB: %v", err)
+	}
+	defer client.Disconnect(context.Background())
+
+	repository_contract.Run(t, func(
+		t *testing.T,
+		eventDispatcher event_entity.EventDispatcherInterface,
+		clock clock.Clock) repository_contract.Backend {
+
+		database := client.Database("test_repository_contract_" + time.Now().Format("20060102150405.000000"))
+		t.Cleanup(func() {
+			database.Drop(context.Background())
+		})
+
+		auctionRepository := auction.NewAuctionRepository(database, eventDispatcher, clock)
+		userRepository := user.NewUserRepository(database)
+
+		return repository_contract.Backend{
+			Auctions:   auctionRepository,
+			Bids:       bid.NewBidRepository(database, auctionRepository, eventDispatcher, clock),
+			Users:      userRepository,
+			Categories: category.NewCategoryRepository(database),
+			SaveUser: func(ctx context.Context, userEntity user_entity.User) error {
+				_, err := userRepository.Collection.InsertOne(ctx, user.UserEntityMongo{
+					Id:   userEntity.Id,
+					Name: userEntity.Name,
+				})
+				return err
+			},
+		}
+	})
+}
+
+// TestPostgresRepositories needs a Postgres database and is skipped unless
+// POSTGRES_TEST_URL is set. Each case gets its own schema.
+func TestPostgresRepositories(t *testing.T) {
+	postgresURL := os.Getenv("POSTGRES_TEST_URL")
+	if postgresURL == "" {
+		t.Skip("POSTGRES_TEST_URL not set")
+	}
+
+	admin, err := sql.Open("postgres", postgresURL)
+	if err != nil {
+		t.Fatalf("connecting to Postgres: %v", err)
+	}
+	defer admin.Close()
+
+	schemas := 0
+	repository_contract.Run(t, func(
+		t *testing.T,
+		eventDispatcher event_entity.EventDispatcherInterface,
+		clock clock.Clock) repository_contract.Backend {
+
+		schemas++
+		schema := fmt.Sprintf("test_repository_contract_%d_%d", time.Now().UnixNano(), schemas)
+		if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+			t.Fatalf("creating schema: %v", err)
+		}
+		t.Cleanup(func() {
+			admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+		})
+
+		schemaURL, err := url.Parse(postgresURL)
+		if err != nil {
+			t.Fatalf("parsing POSTGRES_TEST_URL: %v", err)
+		}
+		query := schemaURL.Query()
+		query.Set("search_path", schema)
+		schemaURL.RawQuery = query.Encode()
+
+		db, err := sql.Open("postgres", schemaURL.String())
+		if err != nil {
+			t.Fatalf("connecting to Postgres: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+
+		if err := sqldb.Migrate(context.Background(), db, sqldb.Postgres); err != nil {
+			t.Fatalf("migrating: %v", err)
+		}
+
+		return repository_contract.Backend{
+			Auctions:   auction.NewSQLAuctionRepository(db, sqldb.Postgres, eventDispatcher, clock),
+			Bids:       bid.NewSQLBidRepository(db, sqldb.Postgres, eventDispatcher, clock),
+			Users:      user.NewSQLUserRepository(db),
+			Categories: category.NewSQLCategoryRepository(db),
+			SaveUser: func(ctx context.Context, userEntity user_entity.User) error {
+				_, err := db.ExecContext(ctx,
+					"INSERT INTO users (id, name) VALUES ($1, $2)", userEntity.Id, userEntity.Name)
+				return err
+			},
+		}
+	})
+}
+
+// TestSQLiteRepositories needs no server: each case gets its own database
+// file.
+func TestSQLiteRepositories(t *testing.T) {
+	repository_contract.Run(t, func(
+		t *testing.T,
+		eventDispatcher event_entity.EventDispatcherInterface,
+		clock clock.Clock) repository_contract.Backend {
+
+		db, err := sqldb.OpenSQLite(filepath.Join(t.TempDir(), "auction.db"))
+		if err != nil {
+			t.Fatalf("opening SQLite: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+
+		if err := sqldb.Migrate(context.Background(), db, sqldb.SQLite); err != nil {
+			t.Fatalf("migrating: %v", err)
+		}
+
+		return repository_contract.Backend{
+			Auctions:   auction.NewSQLAuctionRepository(db, sqldb.SQLite, eventDispatcher, clock),
+			Bids:       bid.NewSQLBidRepository(db, sqldb.SQLite, eventDispatcher, clock),
+			Users:      user.NewSQLUserRepository(db),
+			Categories: category.NewSQLCategoryRepository(db),
+			SaveUser: func(ctx context.Context, userEntity user_entity.User) error {
+				_, err := db.ExecContext(ctx,
+					"INSERT INTO users (id, name) VALUES ($1, $2)", userEntity.Id, userEntity.Name)
+				return err
+			},
+		}
+	})
+}
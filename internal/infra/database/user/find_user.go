@@ -51,3 +51,31 @@ func (ur *UserRepository) FindUserById(
 
 	return userEntity, nil
 }
+
+func (ur *UserRepository) FindUsersByIds(
+	ctx context.Context, userIds []string) ([]user_entity.User, *internal_error.InternalError) {
+	filter := bson.M{"_id": bson.M{"$in": userIds}}
+
+	cursor, err := ur.Collection.Find(ctx, filter)
+	if err != nil {
+		logger.Error("Error trying to find users by ids", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find users by ids")
+	}
+	defer cursor.Close(ctx)
+
+	var usersMongo []UserEntityMongo
+	if err := cursor.All(ctx, &usersMongo); err != nil {
+		logger.Error("Error trying to decode users", err)
+		return nil, internal_error.NewInternalServerError("Error trying to decode users")
+	}
+
+	users := make([]user_entity.User, 0, len(usersMongo))
+	for _, userEntityMongo := range usersMongo {
+		users = append(users, user_entity.User{
+			Id:   userEntityMongo.Id,
+			Name: userEntityMongo.Name,
+		})
+	}
+
+	return users, nil
+}
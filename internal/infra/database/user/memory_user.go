@@ -0,0 +1,96 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"strings"
+	"sync"
+)
+
+// MemoryUserRepository keeps users in process memory with the same behavior
+// as UserRepository. Like the users collection, it is only filled from
+// outside the API: through NewMemoryUserRepository or SaveUser.
+type MemoryUserRepository struct {
+	mutex sync.RWMutex
+	// users is in insertion order, which is the order Mongo returns
+	// unsorted queries in.
+	users []user_entity.User
+	byId  map[string]int
+}
+
+func NewMemoryUserRepository(users ...user_entity.User) *MemoryUserRepository {
+	repository := &MemoryUserRepository{byId: make(map[string]int)}
+	for _, user := range users {
+		repository.SaveUser(user)
+	}
+
+	return repository
+}
+
+// NewMemoryUserRepositoryFromEnv seeds the repository from MEMORY_USERS, a
+// comma-separated list of id=name pairs.
+func NewMemoryUserRepositoryFromEnv() *MemoryUserRepository {
+	var users []user_entity.User
+	for _, pair := range strings.Split(os.Getenv("MEMORY_USERS"), ",") {
+		id, name, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if ok && id != "" {
+			users = append(users, user_entity.User{Id: id, Name: name})
+		}
+	}
+
+	return NewMemoryUserRepository(users...)
+}
+
+// SaveUser adds the user, replacing any user with the same id.
+func (mr *MemoryUserRepository) SaveUser(user user_entity.User) {
+	mr.mutex.Lock()
+	defer mr.mutex.Unlock()
+
+	if index, ok := mr.byId[user.Id]; ok {
+		mr.users[index] = user
+		return
+	}
+
+	mr.byId[user.Id] = len(mr.users)
+	mr.users = append(mr.users, user)
+}
+
+func (mr *MemoryUserRepository) FindUserById(
+	ctx context.Context, userId string) (*user_entity.User, *internal_error.InternalError) {
+
+	mr.mutex.RLock()
+	defer mr.mutex.RUnlock()
+
+	index, ok := mr.byId[userId]
+	if !ok {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("User not found with this id = %s", userId))
+	}
+
+	user := mr.users[index]
+	return &user, nil
+}
+
+func (mr *MemoryUserRepository) FindUsersByIds(
+	ctx context.Context, userIds []string) ([]user_entity.User, *internal_error.InternalError) {
+
+	wanted := make(map[string]bool, len(userIds))
+	for _, userId := range userIds {
+		wanted[userId] = true
+	}
+
+	mr.mutex.RLock()
+	defer mr.mutex.RUnlock()
+
+	users := make([]user_entity.User, 0, len(userIds))
+	for _, user := range mr.users {
+		if wanted[user.Id] {
+			users = append(users, user)
+		}
+	}
+
+	return users, nil
+}
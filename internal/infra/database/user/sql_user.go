@@ -0,0 +1,80 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/database/sqldb"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+// SQLUserRepository reads users from the users table of a SQL database,
+// which like the users collection is filled from outside the API.
+type SQLUserRepository struct {
+	DB *sql.DB
+}
+
+func NewSQLUserRepository(db *sql.DB) *SQLUserRepository {
+	return &SQLUserRepository{DB: db}
+}
+
+func (sr *SQLUserRepository) FindUserById(
+	ctx context.Context, userId string) (*user_entity.User, *internal_error.InternalError) {
+
+	var userEntity user_entity.User
+	err := sr.DB.QueryRowContext(ctx,
+		"SELECT id, name FROM users WHERE id = $1", userId).Scan(&userEntity.Id, &userEntity.Name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Error(fmt.Sprintf("User not found with this id = %s", userId), err)
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("User not found with this id = %s", userId))
+		}
+
+		logger.Error("Error trying to find user by userId", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find user by userId")
+	}
+
+	return &userEntity, nil
+}
+
+func (sr *SQLUserRepository) FindUsersByIds(
+	ctx context.Context, userIds []string) ([]user_entity.User, *internal_error.InternalError) {
+
+	users := make([]user_entity.User, 0, len(userIds))
+	if len(userIds) == 0 {
+		return users, nil
+	}
+
+	args := make([]interface{}, 0, len(userIds))
+	for _, userId := range userIds {
+		args = append(args, userId)
+	}
+
+	rows, err := sr.DB.QueryContext(ctx,
+		"SELECT id, name FROM users WHERE id IN ("+sqldb.Placeholders(1, len(args))+") ORDER BY seq", args...)
+	if err != nil {
+		logger.Error("Error trying to find users by ids", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find users by ids")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userEntity user_entity.User
+		if err := rows.Scan(&userEntity.Id, &userEntity.Name); err != nil {
+			logger.Error("Error trying to decode users", err)
+			return nil, internal_error.NewInternalServerError("Error trying to decode users")
+		}
+		users = append(users, userEntity)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.Error("Error trying to decode users", err)
+		return nil, internal_error.NewInternalServerError("Error trying to decode users")
+	}
+
+	return users, nil
+}
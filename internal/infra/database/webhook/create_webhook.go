@@ -0,0 +1,103 @@
+package webhook
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/entity/webhook_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type WebhookEntityMongo struct {
+	Id        string   `bson:"_id"`
+	UserId    string   `bson:"user_id,omitempty"`
+	Url       string   `bson:"url"`
+	Events    []string `bson:"events"`
+	Secret    string   `bson:"secret"`
+	Timestamp int64    `bson:"timestamp"`
+}
+
+type DeliveryEntityMongo struct {
+	Id         string `bson:"_id"`
+	WebhookId  string `bson:"webhook_id"`
+	EventId    uint64 `bson:"event_id"`
+	EventType  string `bson:"event_type"`
+	Attempt    int    `bson:"attempt"`
+	StatusCode int    `bson:"status_code"`
+	Success    bool   `bson:"success"`
+	Error      string `bson:"error"`
+	Timestamp  int64  `bson:"timestamp"`
+}
+
+type WebhookRepository struct {
+	Collection         *mongo.Collection
+	DeliveryCollection *mongo.Collection
+}
+
+func NewWebhookRepository(database *mongo.Database) *WebhookRepository {
+	return &WebhookRepository{
+		Collection:         database.Collection("webhooks"),
+		DeliveryCollection: database.Collection("webhook_deliveries"),
+	}
+}
+
+func (wr *WebhookRepository) CreateWebhook(
+	ctx context.Context,
+	webhookEntity *webhook_entity.Webhook) *internal_error.InternalError {
+
+	events := make([]string, 0, len(webhookEntity.Events))
+	for _, eventType := range webhookEntity.Events {
+		events = append(events, string(eventType))
+	}
+
+	webhookEntityMongo := &WebhookEntityMongo{
+		Id:        webhookEntity.Id,
+		UserId:    webhookEntity.UserId,
+		Url:       webhookEntity.Url,
+		Events:    events,
+		Secret:    webhookEntity.Secret,
+		Timestamp: webhookEntity.Timestamp.Unix(),
+	}
+
+	if _, err := wr.Collection.InsertOne(ctx, webhookEntityMongo); err != nil {
+		logger.Error("Error inserting webhook", err)
+		return internal_error.NewInternalServerError("Error inserting webhook")
+	}
+
+	return nil
+}
+
+func (wr *WebhookRepository) CreateDelivery(
+	ctx context.Context,
+	deliveryEntity *webhook_entity.Delivery) *internal_error.InternalError {
+
+	deliveryEntityMongo := &DeliveryEntityMongo{
+		Id:         deliveryEntity.Id,
+		WebhookId:  deliveryEntity.WebhookId,
+		EventId:    deliveryEntity.EventId,
+		EventType:  string(deliveryEntity.EventType),
+		Attempt:    deliveryEntity.Attempt,
+		StatusCode: deliveryEntity.StatusCode,
+		Success:    deliveryEntity.Success,
+		Error:      deliveryEntity.Error,
+		Timestamp:  deliveryEntity.Timestamp.Unix(),
+	}
+
+	if _, err := wr.DeliveryCollection.InsertOne(ctx, deliveryEntityMongo); err != nil {
+		logger.Error("Error inserting webhook delivery", err)
+		return internal_error.NewInternalServerError("Error inserting webhook delivery")
+	}
+
+	return nil
+}
+
+func toEventTypes(events []string) []event_entity.EventType {
+	eventTypes := make([]event_entity.EventType, 0, len(events))
+	for _, value := range events {
+		eventTypes = append(eventTypes, event_entity.EventType(value))
+	}
+
+	return eventTypes
+}
@@ -0,0 +1,110 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/entity/webhook_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (wr *WebhookRepository) FindWebhookById(
+	ctx context.Context, id string) (*webhook_entity.Webhook, *internal_error.InternalError) {
+	filter := bson.M{"_id": id}
+
+	var webhookEntityMongo WebhookEntityMongo
+	if err := wr.Collection.FindOne(ctx, filter).Decode(&webhookEntityMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Webhook not found with this id = %s", id))
+		}
+
+		logger.Error("Error trying to find webhook by id", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find webhook by id")
+	}
+
+	return &webhook_entity.Webhook{
+		Id:        webhookEntityMongo.Id,
+		UserId:    webhookEntityMongo.UserId,
+		Url:       webhookEntityMongo.Url,
+		Events:    toEventTypes(webhookEntityMongo.Events),
+		Secret:    webhookEntityMongo.Secret,
+		Timestamp: time.Unix(webhookEntityMongo.Timestamp, 0),
+	}, nil
+}
+
+func (wr *WebhookRepository) FindWebhooksByEventType(
+	ctx context.Context,
+	eventType event_entity.EventType) ([]webhook_entity.Webhook, *internal_error.InternalError) {
+	filter := bson.M{"events": string(eventType)}
+
+	cursor, err := wr.Collection.Find(ctx, filter)
+	if err != nil {
+		logger.Error("Error finding webhooks", err)
+		return nil, internal_error.NewInternalServerError("Error finding webhooks")
+	}
+	defer cursor.Close(ctx)
+
+	var webhooksMongo []WebhookEntityMongo
+	if err := cursor.All(ctx, &webhooksMongo); err != nil {
+		logger.Error("Error decoding webhooks", err)
+		return nil, internal_error.NewInternalServerError("Error decoding webhooks")
+	}
+
+	webhooks := make([]webhook_entity.Webhook, 0, len(webhooksMongo))
+	for _, value := range webhooksMongo {
+		webhooks = append(webhooks, webhook_entity.Webhook{
+			Id:        value.Id,
+			UserId:    value.UserId,
+			Url:       value.Url,
+			Events:    toEventTypes(value.Events),
+			Secret:    value.Secret,
+			Timestamp: time.Unix(value.Timestamp, 0),
+		})
+	}
+
+	return webhooks, nil
+}
+
+func (wr *WebhookRepository) FindDeliveriesByWebhookId(
+	ctx context.Context, webhookId string) ([]webhook_entity.Delivery, *internal_error.InternalError) {
+	filter := bson.M{"webhook_id": webhookId}
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}, {Key: "attempt", Value: 1}})
+
+	cursor, err := wr.DeliveryCollection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error("Error finding webhook deliveries", err)
+		return nil, internal_error.NewInternalServerError("Error finding webhook deliveries")
+	}
+	defer cursor.Close(ctx)
+
+	var deliveriesMongo []DeliveryEntityMongo
+	if err := cursor.All(ctx, &deliveriesMongo); err != nil {
+		logger.Error("Error decoding webhook deliveries", err)
+		return nil, internal_error.NewInternalServerError("Error decoding webhook deliveries")
+	}
+
+	deliveries := make([]webhook_entity.Delivery, 0, len(deliveriesMongo))
+	for _, value := range deliveriesMongo {
+		deliveries = append(deliveries, webhook_entity.Delivery{
+			Id:         value.Id,
+			WebhookId:  value.WebhookId,
+			EventId:    value.EventId,
+			EventType:  event_entity.EventType(value.EventType),
+			Attempt:    value.Attempt,
+			StatusCode: value.StatusCode,
+			Success:    value.Success,
+			Error:      value.Error,
+			Timestamp:  time.Unix(value.Timestamp, 0),
+		})
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,125 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/entity/webhook_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryWebhookRepository keeps webhooks and their deliveries in process
+// memory with the same behavior as WebhookRepository.
+type MemoryWebhookRepository struct {
+	mutex      sync.RWMutex
+	webhooks   []webhook_entity.Webhook
+	deliveries []webhook_entity.Delivery
+}
+
+func NewMemoryWebhookRepository() *MemoryWebhookRepository {
+	return &MemoryWebhookRepository{}
+}
+
+func (mr *MemoryWebhookRepository) CreateWebhook(
+	ctx context.Context,
+	webhookEntity *webhook_entity.Webhook) *internal_error.InternalError {
+
+	mr.mutex.Lock()
+	defer mr.mutex.Unlock()
+
+	for _, webhook := range mr.webhooks {
+		if webhook.Id == webhookEntity.Id {
+			logger.Error("Error inserting webhook", fmt.Errorf("duplicate webhook id %s", webhookEntity.Id))
+			return internal_error.NewInternalServerError("Error inserting webhook")
+		}
+	}
+
+	webhook := *webhookEntity
+	webhook.Events = append([]event_entity.EventType{}, webhookEntity.Events...)
+	webhook.Timestamp = time.Unix(webhookEntity.Timestamp.Unix(), 0)
+	mr.webhooks = append(mr.webhooks, webhook)
+
+	return nil
+}
+
+func (mr *MemoryWebhookRepository) CreateDelivery(
+	ctx context.Context,
+	deliveryEntity *webhook_entity.Delivery) *internal_error.InternalError {
+
+	mr.mutex.Lock()
+	defer mr.mutex.Unlock()
+
+	for _, delivery := range mr.deliveries {
+		if delivery.Id == deliveryEntity.Id {
+			logger.Error("Error inserting webhook delivery", fmt.Errorf("duplicate delivery id %s", deliveryEntity.Id))
+			return internal_error.NewInternalServerError("Error inserting webhook delivery")
+		}
+	}
+
+	delivery := *deliveryEntity
+	delivery.Timestamp = time.Unix(deliveryEntity.Timestamp.Unix(), 0)
+	mr.deliveries = append(mr.deliveries, delivery)
+
+	return nil
+}
+
+func (mr *MemoryWebhookRepository) FindWebhookById(
+	ctx context.Context, id string) (*webhook_entity.Webhook, *internal_error.InternalError) {
+
+	mr.mutex.RLock()
+	defer mr.mutex.RUnlock()
+
+	for _, webhook := range mr.webhooks {
+		if webhook.Id == id {
+			return &webhook, nil
+		}
+	}
+
+	return nil, internal_error.NewNotFoundError(fmt.Sprintf("Webhook not found with this id = %s", id))
+}
+
+func (mr *MemoryWebhookRepository) FindWebhooksByEventType(
+	ctx context.Context,
+	eventType event_entity.EventType) ([]webhook_entity.Webhook, *internal_error.InternalError) {
+
+	mr.mutex.RLock()
+	defer mr.mutex.RUnlock()
+
+	webhooks := make([]webhook_entity.Webhook, 0)
+	for _, webhook := range mr.webhooks {
+		for _, value := range webhook.Events {
+			if value == eventType {
+				webhooks = append(webhooks, webhook)
+				break
+			}
+		}
+	}
+
+	return webhooks, nil
+}
+
+func (mr *MemoryWebhookRepository) FindDeliveriesByWebhookId(
+	ctx context.Context, webhookId string) ([]webhook_entity.Delivery, *internal_error.InternalError) {
+
+	mr.mutex.RLock()
+	deliveries := make([]webhook_entity.Delivery, 0)
+	for _, delivery := range mr.deliveries {
+		if delivery.WebhookId == webhookId {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	mr.mutex.RUnlock()
+
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		if !deliveries[i].Timestamp.Equal(deliveries[j].Timestamp) {
+			return deliveries[i].Timestamp.Before(deliveries[j].Timestamp)
+		}
+		return deliveries[i].Attempt < deliveries[j].Attempt
+	})
+
+	return deliveries, nil
+}
@@ -0,0 +1,223 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/entity/webhook_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+const webhookColumns = "id, user_id, url, secret, timestamp"
+
+const deliveryColumns = "id, webhook_id, event_id, event_type, attempt, status_code, success, error, timestamp"
+
+// SQLWebhookRepository stores webhooks, their event types and their
+// deliveries in tables of a SQL database, created by the sqldb migrations.
+type SQLWebhookRepository struct {
+	DB *sql.DB
+}
+
+func NewSQLWebhookRepository(db *sql.DB) *SQLWebhookRepository {
+	return &SQLWebhookRepository{DB: db}
+}
+
+func (sr *SQLWebhookRepository) CreateWebhook(
+	ctx context.Context,
+	webhookEntity *webhook_entity.Webhook) *internal_error.InternalError {
+
+	tx, err := sr.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("Error inserting webhook", err)
+		return internal_error.NewInternalServerError("Error inserting webhook")
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		"INSERT INTO webhooks (id, user_id, url, secret, timestamp) VALUES ($1, $2, $3, $4, $5)",
+		webhookEntity.Id, webhookEntity.UserId, webhookEntity.Url, webhookEntity.Secret, webhookEntity.Timestamp.Unix()); err != nil {
+		logger.Error("Error inserting webhook", err)
+		return internal_error.NewInternalServerError("Error inserting webhook")
+	}
+
+	for position, eventType := range webhookEntity.Events {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO webhook_events (webhook_id, event_type, position) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING",
+			webhookEntity.Id, string(eventType), position); err != nil {
+			logger.Error("Error inserting webhook", err)
+			return internal_error.NewInternalServerError("Error inserting webhook")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("Error inserting webhook", err)
+		return internal_error.NewInternalServerError("Error inserting webhook")
+	}
+
+	return nil
+}
+
+func (sr *SQLWebhookRepository) CreateDelivery(
+	ctx context.Context,
+	deliveryEntity *webhook_entity.Delivery) *internal_error.InternalError {
+
+	if _, err := sr.DB.ExecContext(ctx,
+		"INSERT INTO webhook_deliveries ("+deliveryColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+		deliveryEntity.Id,
+		deliveryEntity.WebhookId,
+		int64(deliveryEntity.EventId),
+		string(deliveryEntity.EventType),
+		deliveryEntity.Attempt,
+		deliveryEntity.StatusCode,
+		deliveryEntity.Success,
+		deliveryEntity.Error,
+		deliveryEntity.Timestamp.Unix()); err != nil {
+		logger.Error("Error inserting webhook delivery", err)
+		return internal_error.NewInternalServerError("Error inserting webhook delivery")
+	}
+
+	return nil
+}
+
+func (sr *SQLWebhookRepository) FindWebhookById(
+	ctx context.Context, id string) (*webhook_entity.Webhook, *internal_error.InternalError) {
+
+	webhooks, err := sr.queryWebhooks(ctx,
+		"SELECT "+webhookColumns+" FROM webhooks WHERE id = $1", id)
+	if err != nil {
+		logger.Error("Error trying to find webhook by id", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find webhook by id")
+	}
+
+	if len(webhooks) == 0 {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("Webhook not found with this id = %s", id))
+	}
+
+	return &webhooks[0], nil
+}
+
+func (sr *SQLWebhookRepository) FindWebhooksByEventType(
+	ctx context.Context,
+	eventType event_entity.EventType) ([]webhook_entity.Webhook, *internal_error.InternalError) {
+
+	webhooks, err := sr.queryWebhooks(ctx,
+		"SELECT "+webhookColumns+" FROM webhooks WHERE id IN "+
+			"(SELECT webhook_id FROM webhook_events WHERE event_type = $1) ORDER BY seq", string(eventType))
+	if err != nil {
+		logger.Error("Error finding webhooks", err)
+		return nil, internal_error.NewInternalServerError("Error finding webhooks")
+	}
+
+	return webhooks, nil
+}
+
+func (sr *SQLWebhookRepository) FindDeliveriesByWebhookId(
+	ctx context.Context, webhookId string) ([]webhook_entity.Delivery, *internal_error.InternalError) {
+
+	rows, err := sr.DB.QueryContext(ctx,
+		"SELECT "+deliveryColumns+" FROM webhook_deliveries WHERE webhook_id = $1 ORDER BY timestamp, attempt",
+		webhookId)
+	if err != nil {
+		logger.Error("Error finding webhook deliveries", err)
+		return nil, internal_error.NewInternalServerError("Error finding webhook deliveries")
+	}
+	defer rows.Close()
+
+	deliveries := make([]webhook_entity.Delivery, 0)
+	for rows.Next() {
+		var delivery webhook_entity.Delivery
+		var eventId, timestamp int64
+		var eventType string
+		if err := rows.Scan(
+			&delivery.Id,
+			&delivery.WebhookId,
+			&eventId,
+			&eventType,
+			&delivery.Attempt,
+			&delivery.StatusCode,
+			&delivery.Success,
+			&delivery.Error,
+			&timestamp); err != nil {
+			logger.Error("Error decoding webhook deliveries", err)
+			return nil, internal_error.NewInternalServerError("Error decoding webhook deliveries")
+		}
+		delivery.EventId = uint64(eventId)
+		delivery.EventType = event_entity.EventType(eventType)
+		delivery.Timestamp = time.Unix(timestamp, 0)
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.Error("Error decoding webhook deliveries", err)
+		return nil, internal_error.NewInternalServerError("Error decoding webhook deliveries")
+	}
+
+	return deliveries, nil
+}
+
+// queryWebhooks runs a query selecting webhookColumns and loads the event
+// types of each webhook.
+func (sr *SQLWebhookRepository) queryWebhooks(
+	ctx context.Context, query string, args ...interface{}) ([]webhook_entity.Webhook, error) {
+
+	rows, err := sr.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := make([]webhook_entity.Webhook, 0)
+	for rows.Next() {
+		var webhook webhook_entity.Webhook
+		var timestamp int64
+		if err := rows.Scan(&webhook.Id, &webhook.UserId, &webhook.Url, &webhook.Secret, &timestamp); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		webhook.Timestamp = time.Unix(timestamp, 0)
+		webhooks = append(webhooks, webhook)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range webhooks {
+		events, err := sr.findEventTypes(ctx, webhooks[i].Id)
+		if err != nil {
+			return nil, err
+		}
+		webhooks[i].Events = events
+	}
+
+	return webhooks, nil
+}
+
+func (sr *SQLWebhookRepository) findEventTypes(
+	ctx context.Context, webhookId string) ([]event_entity.EventType, error) {
+
+	rows, err := sr.DB.QueryContext(ctx,
+		"SELECT event_type FROM webhook_events WHERE webhook_id = $1 ORDER BY position", webhookId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []string
+	for rows.Next() {
+		var eventType string
+		if err := rows.Scan(&eventType); err != nil {
+			return nil, err
+		}
+		events = append(events, eventType)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return toEventTypes(events), nil
+}
@@ -0,0 +1,129 @@
+package event
+
+import (
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type EventDispatcher struct {
+	mutex            sync.RWMutex
+	lastEventId      uint64
+	lastSubscriberId uint64
+	subscribers      map[uint64]chan event_entity.Event
+	listeners        map[uint64]func(event_entity.Event)
+	history          []event_entity.Event
+	historySize      int
+}
+
+func NewEventDispatcher() *EventDispatcher {
+	return &EventDispatcher{
+		subscribers: make(map[uint64]chan event_entity.Event),
+		listeners:   make(map[uint64]func(event_entity.Event)),
+		historySize: getEventHistorySize(),
+	}
+}
+
+func (ed *EventDispatcher) Publish(event event_entity.Event) {
+	ed.mutex.Lock()
+	defer ed.mutex.Unlock()
+
+	ed.lastEventId++
+	event.Id = ed.lastEventId
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	if ed.historySize > 0 {
+		if len(ed.history) >= ed.historySize {
+			ed.history = ed.history[1:]
+		}
+		ed.history = append(ed.history, event)
+	}
+
+	for _, listener := range ed.listeners {
+		listener(event)
+	}
+
+	for subscriberId, subscriber := range ed.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+			logger.Info("Dropping event for slow subscriber",
+				zap.Uint64("subscriber_id", subscriberId),
+				zap.Uint64("event_id", event.Id),
+				zap.String("event_type", string(event.Type)))
+		}
+	}
+}
+
+func (ed *EventDispatcher) Subscribe(bufferSize int) (<-chan event_entity.Event, func()) {
+	ed.mutex.Lock()
+	defer ed.mutex.Unlock()
+
+	ed.lastSubscriberId++
+	subscriberId := ed.lastSubscriberId
+	subscriber := make(chan event_entity.Event, bufferSize)
+	ed.subscribers[subscriberId] = subscriber
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			ed.mutex.Lock()
+			defer ed.mutex.Unlock()
+
+			delete(ed.subscribers, subscriberId)
+			close(subscriber)
+		})
+	}
+
+	return subscriber, unsubscribe
+}
+
+// Listen calls listener with every event published until the returned
+// function is called. Unlike Subscribe it never drops an event: listener
+// runs while Publish holds the dispatcher's lock, so it must return quickly
+// and must not publish.
+func (ed *EventDispatcher) Listen(listener func(event_entity.Event)) func() {
+	ed.mutex.Lock()
+	defer ed.mutex.Unlock()
+
+	ed.lastSubscriberId++
+	listenerId := ed.lastSubscriberId
+	ed.listeners[listenerId] = listener
+
+	return func() {
+		ed.mutex.Lock()
+		defer ed.mutex.Unlock()
+
+		delete(ed.listeners, listenerId)
+	}
+}
+
+func (ed *EventDispatcher) EventsSince(auctionId string, lastEventId uint64) []event_entity.Event {
+	ed.mutex.RLock()
+	defer ed.mutex.RUnlock()
+
+	var events []event_entity.Event
+	for _, event := range ed.history {
+		if event.Id > lastEventId && (auctionId == "" || event.AuctionId == auctionId) {
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+func getEventHistorySize() int {
+	value, err := strconv.Atoi(os.Getenv("EVENT_HISTORY_SIZE"))
+	if err != nil || value < 0 {
+		return 1000
+	}
+
+	return value
+}
@@ -0,0 +1,68 @@
+package event_test
+
+import (
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/infra/event"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventDispatcherDeliversToSubscribers(t *testing.T) {
+	dispatcher := event.NewEventDispatcher()
+
+	events, unsubscribe := dispatcher.Subscribe(2)
+	dispatcher.Publish(event_entity.Event{Type: event_entity.BidCreated, AuctionId: "a"})
+
+	received := <-events
+	assert.Equal(t, uint64(1), received.Id)
+	assert.Equal(t, event_entity.BidCreated, received.Type)
+	assert.False(t, received.Timestamp.IsZero())
+
+	unsubscribe()
+	_, ok := <-events
+	assert.False(t, ok)
+
+	dispatcher.Publish(event_entity.Event{Type: event_entity.BidCreated, AuctionId: "a"})
+}
+
+func TestEventDispatcherReplaysHistorySinceLastEventId(t *testing.T) {
+	os.Setenv("EVENT_HISTORY_SIZE", "3")
+	defer os.Unsetenv("EVENT_HISTORY_SIZE")
+
+	dispatcher := event.NewEventDispatcher()
+	dispatcher.Publish(event_entity.Event{Type: event_entity.AuctionCreated, AuctionId: "a"})
+	dispatcher.Publish(event_entity.Event{Type: event_entity.BidCreated, AuctionId: "b"})
+	dispatcher.Publish(event_entity.Event{Type: event_entity.BidCreated, AuctionId: "a"})
+	dispatcher.Publish(event_entity.Event{Type: event_entity.AuctionClosed, AuctionId: "a"})
+
+	replayed := dispatcher.EventsSince("a", 0)
+	assert.Len(t, replayed, 2)
+	assert.Equal(t, uint64(3), replayed[0].Id)
+	assert.Equal(t, uint64(4), replayed[1].Id)
+
+	assert.Len(t, dispatcher.EventsSince("a", 3), 1)
+	assert.Empty(t, dispatcher.EventsSince("a", 4))
+}
+
+func TestEventDispatcherListenersReceiveEveryEvent(t *testing.T) {
+	dispatcher := event.NewEventDispatcher()
+
+	// A full subscriber drops events; a listener does not.
+	_, unsubscribe := dispatcher.Subscribe(0)
+	defer unsubscribe()
+
+	var ids []uint64
+	stopListening := dispatcher.Listen(func(event event_entity.Event) {
+		ids = append(ids, event.Id)
+	})
+
+	for i := 0; i < 3; i++ {
+		dispatcher.Publish(event_entity.Event{Type: event_entity.BidCreated, AuctionId: "a"})
+	}
+	stopListening()
+	dispatcher.Publish(event_entity.Event{Type: event_entity.BidCreated, AuctionId: "a"})
+
+	assert.Equal(t, []uint64{1, 2, 3}, ids)
+}
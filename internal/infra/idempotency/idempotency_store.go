@@ -0,0 +1,66 @@
+package idempotency
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type State string
+
+const (
+	InProgress State = "in_progress"
+	Completed  State = "completed"
+)
+
+type Record struct {
+	Key         string
+	Fingerprint string
+	State       State
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	ExpiresAt   time.Time
+}
+
+type IdempotencyStoreInterface interface {
+	// Reserve claims key for a new request. When the key is already taken
+	// it returns the existing record and false instead.
+	Reserve(ctx context.Context, key, fingerprint string) (*Record, bool, error)
+
+	// Complete stores the response of a reserved key so repeats replay it.
+	Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error
+
+	// Release frees a reserved key whose request failed, so it can be
+	// retried.
+	Release(ctx context.Context, key string) error
+}
+
+// NewIdempotencyStore picks the store from IDEMPOTENCY_STORE: "memory"
+// (default) or "mongo" for deployments with several instances.
+func NewIdempotencyStore(database *mongo.Database) IdempotencyStoreInterface {
+	ttl := getIdempotencyTTL()
+
+	if os.Getenv("IDEMPOTENCY_STORE") == "mongo" {
+		return NewMongoIdempotencyStore(database, ttl)
+	}
+
+	return NewMemoryIdempotencyStore(ttl)
+}
+
+func getIdempotencyTTL() time.Duration {
+	value := os.Getenv("IDEMPOTENCY_TTL")
+	if value == "" {
+		return 24 * time.Hour
+	}
+
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		logger.Error("Invalid IDEMPOTENCY_TTL format, using default 24h", err)
+		return 24 * time.Hour
+	}
+	return ttl
+}
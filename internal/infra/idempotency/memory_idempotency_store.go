@@ -0,0 +1,86 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const sweepInterval = time.Minute
+
+type MemoryIdempotencyStore struct {
+	mutex     sync.Mutex
+	records   map[string]*Record
+	ttl       time.Duration
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryIdempotencyStore(ttl time.Duration) *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{
+		records: map[string]*Record{},
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+func (ms *MemoryIdempotencyStore) Reserve(ctx context.Context, key, fingerprint string) (*Record, bool, error) {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	now := ms.now()
+	ms.sweep(now)
+
+	if record, ok := ms.records[key]; ok && now.Before(record.ExpiresAt) {
+		existing := *record
+		return &existing, false, nil
+	}
+
+	ms.records[key] = &Record{
+		Key:         key,
+		Fingerprint: fingerprint,
+		State:       InProgress,
+		ExpiresAt:   now.Add(ms.ttl),
+	}
+
+	return nil, true, nil
+}
+
+func (ms *MemoryIdempotencyStore) Complete(
+	ctx context.Context, key string, statusCode int, contentType string, body []byte) error {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	if record, ok := ms.records[key]; ok {
+		record.State = Completed
+		record.StatusCode = statusCode
+		record.ContentType = contentType
+		record.Body = body
+	}
+
+	return nil
+}
+
+func (ms *MemoryIdempotencyStore) Release(ctx context.Context, key string) error {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	if record, ok := ms.records[key]; ok && record.State == InProgress {
+		delete(ms.records, key)
+	}
+
+	return nil
+}
+
+func (ms *MemoryIdempotencyStore) sweep(now time.Time) {
+	if now.Sub(ms.lastSweep) < sweepInterval {
+		return
+	}
+	ms.lastSweep = now
+
+	for key, record := range ms.records {
+		if !now.Before(record.ExpiresAt) {
+			delete(ms.records, key)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type IdempotencyEntityMongo struct {
+	Key         string    `bson:"_id"`
+	Fingerprint string    `bson:"fingerprint"`
+	State       string    `bson:"state"`
+	StatusCode  int       `bson:"status_code,omitempty"`
+	ContentType string    `bson:"content_type,omitempty"`
+	Body        []byte    `bson:"body,omitempty"`
+	ExpiresAt   time.Time `bson:"expires_at"`
+}
+
+// MongoIdempotencyStore relies on the unique _id to let exactly one instance
+// reserve a key; a TTL index on expires_at removes old records.
+type MongoIdempotencyStore struct {
+	Collection *mongo.Collection
+	ttl        time.Duration
+}
+
+func NewMongoIdempotencyStore(database *mongo.Database, ttl time.Duration) *MongoIdempotencyStore {
+	return &MongoIdempotencyStore{
+		Collection: database.Collection("idempotency_keys"),
+		ttl:        ttl,
+	}
+}
+
+func (ms *MongoIdempotencyStore) Reserve(ctx context.Context, key, fingerprint string) (*Record, bool, error) {
+	now := time.Now()
+
+	// The TTL monitor only runs every minute, so expired records may still be
+	// around and are replaced here.
+	if _, err := ms.Collection.DeleteOne(ctx, bson.M{"_id": key, "expires_at": bson.M{"$lte": now}}); err != nil {
+		return nil, false, err
+	}
+
+	_, err := ms.Collection.InsertOne(ctx, &IdempotencyEntityMongo{
+		Key:         key,
+		Fingerprint: fingerprint,
+		State:       string(InProgress),
+		ExpiresAt:   now.Add(ms.ttl),
+	})
+	if err == nil {
+		return nil, true, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return nil, false, err
+	}
+
+	var existing IdempotencyEntityMongo
+	if err := ms.Collection.FindOne(ctx, bson.M{"_id": key}).Decode(&existing); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			// Released between our insert and this read; let the client retry.
+			return &Record{Key: key, Fingerprint: fingerprint, State: InProgress}, false, nil
+		}
+		return nil, false, err
+	}
+
+	return &Record{
+		Key:         existing.Key,
+		Fingerprint: existing.Fingerprint,
+		State:       State(existing.State),
+		StatusCode:  existing.StatusCode,
+		ContentType: existing.ContentType,
+		Body:        existing.Body,
+		ExpiresAt:   existing.ExpiresAt,
+	}, false, nil
+}
+
+func (ms *MongoIdempotencyStore) Complete(
+	ctx context.Context, key string, statusCode int, contentType string, body []byte) error {
+
+	_, err := ms.Collection.UpdateOne(ctx, bson.M{"_id": key}, bson.M{"$set": bson.M{
+		"state":        string(Completed),
+		"status_code":  statusCode,
+		"content_type": contentType,
+		"body":         body,
+	}})
+	return err
+}
+
+func (ms *MongoIdempotencyStore) Release(ctx context.Context, key string) error {
+	_, err := ms.Collection.DeleteOne(ctx, bson.M{"_id": key, "state": string(InProgress)})
+	return err
+}
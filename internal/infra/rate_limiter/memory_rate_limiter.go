@@ -0,0 +1,76 @@
+package rate_limiter
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	full    time.Time
+}
+
+type MemoryRateLimiter struct {
+	mutex     sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryRateLimiter() *MemoryRateLimiter {
+	return &MemoryRateLimiter{
+		buckets: map[string]*bucket{},
+		now:     time.Now,
+	}
+}
+
+func (ml *MemoryRateLimiter) Allow(ctx context.Context, key string, quota Quota) (Decision, error) {
+	ml.mutex.Lock()
+	defer ml.mutex.Unlock()
+
+	now := ml.now()
+	ml.sweep(now)
+
+	b, ok := ml.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(quota.Limit), updated: now}
+		ml.buckets[key] = b
+	}
+
+	elapsed := now.Sub(b.updated).Seconds()
+	b.tokens = math.Min(float64(quota.Limit), b.tokens+elapsed*quota.ratePerSecond())
+	b.updated = now
+
+	decision := Decision{Allowed: b.tokens >= 1}
+	if decision.Allowed {
+		b.tokens--
+	} else {
+		decision.RetryAfter = quota.retryAfter(b.tokens)
+	}
+	decision.Remaining = int(b.tokens)
+
+	missing := float64(quota.Limit) - b.tokens
+	b.full = now.Add(time.Duration(missing / quota.ratePerSecond() * float64(time.Second)))
+
+	return decision, nil
+}
+
+// sweep drops buckets that have refilled completely, since a fresh bucket
+// behaves the same, so idle clients do not accumulate in memory.
+func (ml *MemoryRateLimiter) sweep(now time.Time) {
+	if now.Sub(ml.lastSweep) < sweepInterval {
+		return
+	}
+	ml.lastSweep = now
+
+	for key, b := range ml.buckets {
+		if !now.Before(b.full) {
+			delete(ml.buckets, key)
+		}
+	}
+}
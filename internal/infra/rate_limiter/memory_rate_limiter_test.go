@@ -0,0 +1,59 @@
+package rate_limiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRateLimiterRefillsTokens(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewMemoryRateLimiter()
+	limiter.now = func() time.Time { return now }
+	quota := Quota{Limit: 2, Period: 10 * time.Second}
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		decision, err := limiter.Allow(ctx, "user", quota)
+		assert.NoError(t, err)
+		assert.True(t, decision.Allowed)
+	}
+
+	decision, _ := limiter.Allow(ctx, "user", quota)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, 5*time.Second, decision.RetryAfter)
+
+	other, _ := limiter.Allow(ctx, "other", quota)
+	assert.True(t, other.Allowed, "buckets are independent per key")
+
+	now = now.Add(5 * time.Second)
+	decision, _ = limiter.Allow(ctx, "user", quota)
+	assert.True(t, decision.Allowed)
+}
+
+func TestMemoryRateLimiterSweepsFullBuckets(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewMemoryRateLimiter()
+	limiter.now = func() time.Time { return now }
+	quota := Quota{Limit: 1, Period: time.Second}
+
+	limiter.Allow(context.Background(), "idle", quota)
+	now = now.Add(2 * sweepInterval)
+	limiter.Allow(context.Background(), "active", quota)
+
+	assert.NotContains(t, limiter.buckets, "idle")
+	assert.Contains(t, limiter.buckets, "active")
+}
+
+func TestParseQuota(t *testing.T) {
+	quota, err := ParseQuota("10/1m")
+	assert.NoError(t, err)
+	assert.Equal(t, Quota{Limit: 10, Period: time.Minute}, quota)
+
+	for _, value := range []string{"10", "0/1m", "x/1m", "10/0s", "10/soon"} {
+		_, err := ParseQuota(value)
+		assert.Error(t, err, value)
+	}
+}
@@ -0,0 +1,85 @@
+package rate_limiter
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type rateLimitEntityMongo struct {
+	Id        string    `bson:"_id"`
+	Tokens    float64   `bson:"tokens"`
+	Allowed   bool      `bson:"allowed"`
+	UpdatedAt int64     `bson:"updated_at"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+// MongoRateLimiter keeps one bucket document per key so every instance
+// draws from the same quota. Refill and consumption happen in a single
+// pipeline update, which Mongo applies atomically per document.
+type MongoRateLimiter struct {
+	Collection *mongo.Collection
+	now        func() time.Time
+}
+
+func NewMongoRateLimiter(database *mongo.Database) *MongoRateLimiter {
+	return &MongoRateLimiter{
+		Collection: database.Collection("rate_limits"),
+		now:        time.Now,
+	}
+}
+
+func (ml *MongoRateLimiter) Allow(ctx context.Context, key string, quota Quota) (Decision, error) {
+	now := ml.now()
+	nowMillis := now.UnixMilli()
+	limit := float64(quota.Limit)
+	ratePerMillis := quota.ratePerSecond() / 1000
+
+	refilled := bson.M{"$min": bson.A{limit, bson.M{"$add": bson.A{
+		bson.M{"$ifNull": bson.A{"$tokens", limit}},
+		bson.M{"$multiply": bson.A{
+			bson.M{"$subtract": bson.A{nowMillis, bson.M{"$ifNull": bson.A{"$updated_at", nowMillis}}}},
+			ratePerMillis,
+		}},
+	}}}}
+
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"tokens":     refilled,
+			"updated_at": nowMillis,
+			// The TTL index removes buckets once they would be full again.
+			"expires_at": now.Add(quota.Period),
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"allowed": bson.M{"$gte": bson.A{"$tokens", 1}},
+			"tokens": bson.M{"$cond": bson.A{
+				bson.M{"$gte": bson.A{"$tokens", 1}},
+				bson.M{"$subtract": bson.A{"$tokens", 1}},
+				"$tokens",
+			}},
+		}}},
+	}
+
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var result rateLimitEntityMongo
+	err := ml.Collection.FindOneAndUpdate(ctx, bson.M{"_id": key}, update, opts).Decode(&result)
+	if mongo.IsDuplicateKeyError(err) {
+		// Two instances upserted the same new key; the retry updates the
+		// document the other one inserted.
+		err = ml.Collection.FindOneAndUpdate(ctx, bson.M{"_id": key}, update, opts).Decode(&result)
+	}
+	if err != nil {
+		return Decision{}, err
+	}
+
+	decision := Decision{Allowed: result.Allowed, Remaining: int(result.Tokens)}
+	if !decision.Allowed {
+		decision.RetryAfter = quota.retryAfter(result.Tokens)
+	}
+
+	return decision, nil
+}
@@ -0,0 +1,91 @@
+package rate_limiter
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Quota is a token bucket holding up to Limit tokens that refills Limit
+// tokens every Period.
+type Quota struct {
+	Limit  int
+	Period time.Duration
+}
+
+func (q Quota) ratePerSecond() float64 {
+	return float64(q.Limit) / q.Period.Seconds()
+}
+
+// retryAfter is how long until the bucket holds a whole token again.
+func (q Quota) retryAfter(tokens float64) time.Duration {
+	missing := 1 - tokens
+	if missing <= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(missing / q.ratePerSecond() * float64(time.Second)))
+}
+
+type Decision struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration
+}
+
+type RateLimiterInterface interface {
+	Allow(ctx context.Context, key string, quota Quota) (Decision, error)
+}
+
+// ParseQuota reads quotas written as "<limit>/<period>", e.g. "10/1m".
+func ParseQuota(value string) (Quota, error) {
+	limit, period, ok := strings.Cut(strings.TrimSpace(value), "/")
+	if !ok {
+		return Quota{}, fmt.Errorf("quota %q must look like 10/1m", value)
+	}
+
+	parsedLimit, err := strconv.Atoi(limit)
+	if err != nil || parsedLimit <= 0 {
+		return Quota{}, fmt.Errorf("quota %q has an invalid limit", value)
+	}
+
+	parsedPeriod, err := time.ParseDuration(period)
+	if err != nil || parsedPeriod <= 0 {
+		return Quota{}, fmt.Errorf("quota %q has an invalid period", value)
+	}
+
+	return Quota{Limit: parsedLimit, Period: parsedPeriod}, nil
+}
+
+// QuotaFromEnv returns the quota configured in the environment variable name,
+// or fallback when it is unset or invalid.
+func QuotaFromEnv(name string, fallback Quota) Quota {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+
+	quota, err := ParseQuota(value)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Invalid %s, using %d/%s", name, fallback.Limit, fallback.Period), err)
+		return fallback
+	}
+
+	return quota
+}
+
+// NewRateLimiter picks the store from RATE_LIMIT_STORE: "memory" (default)
+// keeps buckets per instance, "mongo" shares them between instances.
+func NewRateLimiter(database *mongo.Database) RateLimiterInterface {
+	if os.Getenv("RATE_LIMIT_STORE") == "mongo" {
+		return NewMongoRateLimiter(database)
+	}
+
+	return NewMemoryRateLimiter()
+}
@@ -0,0 +1,57 @@
+package rate_limiter
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RouteLimit applies a token bucket per client IP and, for authenticated
+// callers, another per user, both scoped to Route. Every transport serving a
+// route checks the same RouteLimit, so a caller cannot dodge its quota by
+// switching transports.
+type RouteLimit struct {
+	Limiter RateLimiterInterface
+	Route   string
+	PerUser Quota
+	PerIP   Quota
+}
+
+// NewBidRouteLimit limits placing bids as configured by
+// RATE_LIMIT_BID_PER_USER (default 10/1m) and RATE_LIMIT_BID_PER_IP
+// (default 30/1m).
+func NewBidRouteLimit(limiter RateLimiterInterface) RouteLimit {
+	return RouteLimit{
+		Limiter: limiter,
+		Route:   "bid",
+		PerUser: QuotaFromEnv("RATE_LIMIT_BID_PER_USER", Quota{Limit: 10, Period: time.Minute}),
+		PerIP:   QuotaFromEnv("RATE_LIMIT_BID_PER_IP", Quota{Limit: 30, Period: time.Minute}),
+	}
+}
+
+// Allow takes a token from the buckets of clientIP and, unless it is empty,
+// userId. When the limiter store fails the call is let through.
+func (rl RouteLimit) Allow(ctx context.Context, clientIP string, userId string) Decision {
+	keys := []string{rl.Route + ":ip:" + clientIP}
+	quotas := []Quota{rl.PerIP}
+	if userId != "" {
+		keys = append(keys, rl.Route+":user:"+userId)
+		quotas = append(quotas, rl.PerUser)
+	}
+
+	for i, key := range keys {
+		decision, err := rl.Limiter.Allow(ctx, key, quotas[i])
+		if err != nil {
+			logger.Error("Error checking rate limit", err, zap.String("key", key))
+			continue
+		}
+
+		if !decision.Allowed {
+			return decision
+		}
+	}
+
+	return Decision{Allowed: true}
+}
@@ -0,0 +1,98 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/internal/auth"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type jwtClaims struct {
+	jwt.RegisteredClaims
+	Roles []auth.Role `json:"roles"`
+}
+
+type JWTVerifier struct {
+	key    interface{}
+	parser *jwt.Parser
+}
+
+// NewJWTVerifier checks HS256 tokens against a []byte secret or RS256 tokens
+// against an *rsa.PublicKey. Issuer and audience are only enforced when set.
+func NewJWTVerifier(algorithm string, key interface{}, issuer, audience string) (*JWTVerifier, error) {
+	switch algorithm {
+	case jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg():
+	default:
+		return nil, fmt.Errorf("unsupported JWT algorithm %q", algorithm)
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{algorithm}),
+		jwt.WithExpirationRequired(),
+	}
+	if issuer != "" {
+		options = append(options, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		options = append(options, jwt.WithAudience(audience))
+	}
+
+	return &JWTVerifier{
+		key:    key,
+		parser: jwt.NewParser(options...),
+	}, nil
+}
+
+func NewJWTVerifierFromEnv() (*JWTVerifier, error) {
+	algorithm := os.Getenv("JWT_ALGORITHM")
+	if algorithm == "" {
+		algorithm = jwt.SigningMethodHS256.Alg()
+	}
+
+	var key interface{}
+	switch algorithm {
+	case jwt.SigningMethodHS256.Alg():
+		secret := os.Getenv("JWT_SECRET")
+		if secret == "" {
+			return nil, errors.New("JWT_SECRET is required for HS256")
+		}
+		key = []byte(secret)
+	case jwt.SigningMethodRS256.Alg():
+		pem, err := os.ReadFile(os.Getenv("JWT_PUBLIC_KEY_FILE"))
+		if err != nil {
+			return nil, fmt.Errorf("reading JWT_PUBLIC_KEY_FILE: %w", err)
+		}
+		publicKey, err := jwt.ParseRSAPublicKeyFromPEM(pem)
+		if err != nil {
+			return nil, fmt.Errorf("parsing JWT_PUBLIC_KEY_FILE: %w", err)
+		}
+		key = publicKey
+	}
+
+	return NewJWTVerifier(algorithm, key, os.Getenv("JWT_ISSUER"), os.Getenv("JWT_AUDIENCE"))
+}
+
+func (v *JWTVerifier) Verify(tokenString string) (*auth.Identity, error) {
+	var claims jwtClaims
+	if _, err := v.parser.ParseWithClaims(tokenString, &claims, func(*jwt.Token) (interface{}, error) {
+		return v.key, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	subject := strings.TrimSpace(claims.Subject)
+	if subject == "" {
+		return nil, errors.New("token has no subject")
+	}
+
+	// Tokens issued before roles existed belong to plain bidders.
+	roles := claims.Roles
+	if len(roles) == 0 {
+		roles = []auth.Role{auth.RoleBidder}
+	}
+
+	return &auth.Identity{UserId: subject, Roles: roles}, nil
+}
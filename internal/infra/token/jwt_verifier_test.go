@@ -0,0 +1,100 @@
+package token_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/infra/token"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+const userId = "6b0d6f1c-0b5e-4d6b-8f8e-2b9d1a3c4e5f"
+
+var secret = []byte("0123456789abcdef0123456789abcdef")
+
+func sign(t *testing.T, method jwt.SigningMethod, key interface{}, claims jwt.RegisteredClaims) string {
+	signed, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	assert.NoError(t, err)
+	return signed
+}
+
+func validClaims() jwt.RegisteredClaims {
+	return jwt.RegisteredClaims{
+		Subject:   userId,
+		Issuer:    "auction",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+}
+
+func TestJWTVerifierHS256(t *testing.T) {
+	verifier, err := token.NewJWTVerifier("HS256", secret, "auction", "")
+	assert.NoError(t, err)
+
+	identity, err := verifier.Verify(sign(t, jwt.SigningMethodHS256, secret, validClaims()))
+	assert.NoError(t, err)
+	assert.Equal(t, userId, identity.UserId)
+	assert.Equal(t, []auth.Role{auth.RoleBidder}, identity.Roles, "tokens without roles are bidders")
+
+	_, err = verifier.Verify(sign(t, jwt.SigningMethodHS256, []byte("another-secret-another-secret!!!"), validClaims()))
+	assert.Error(t, err, "wrong secret")
+
+	expired := validClaims()
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+	_, err = verifier.Verify(sign(t, jwt.SigningMethodHS256, secret, expired))
+	assert.Error(t, err, "expired")
+
+	withoutExpiry := validClaims()
+	withoutExpiry.ExpiresAt = nil
+	_, err = verifier.Verify(sign(t, jwt.SigningMethodHS256, secret, withoutExpiry))
+	assert.Error(t, err, "no expiry")
+
+	otherIssuer := validClaims()
+	otherIssuer.Issuer = "someone-else"
+	_, err = verifier.Verify(sign(t, jwt.SigningMethodHS256, secret, otherIssuer))
+	assert.Error(t, err, "issuer")
+
+	withoutSubject := validClaims()
+	withoutSubject.Subject = ""
+	_, err = verifier.Verify(sign(t, jwt.SigningMethodHS256, secret, withoutSubject))
+	assert.Error(t, err, "no subject")
+}
+
+func TestJWTVerifierReadsRoles(t *testing.T) {
+	verifier, err := token.NewJWTVerifier("HS256", secret, "", "")
+	assert.NoError(t, err)
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":   userId,
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": []string{"seller", "admin"},
+	}).SignedString(secret)
+	assert.NoError(t, err)
+
+	identity, err := verifier.Verify(signed)
+	assert.NoError(t, err)
+	assert.Equal(t, []auth.Role{auth.RoleSeller, auth.RoleAdmin}, identity.Roles)
+}
+
+func TestJWTVerifierRS256(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	verifier, err := token.NewJWTVerifier("RS256", &privateKey.PublicKey, "", "")
+	assert.NoError(t, err)
+
+	identity, err := verifier.Verify(sign(t, jwt.SigningMethodRS256, privateKey, validClaims()))
+	assert.NoError(t, err)
+	assert.Equal(t, userId, identity.UserId)
+
+	_, err = verifier.Verify(sign(t, jwt.SigningMethodHS256, secret, validClaims()))
+	assert.Error(t, err, "algorithm must match the configured one")
+}
+
+func TestJWTVerifierRejectsUnsupportedAlgorithms(t *testing.T) {
+	_, err := token.NewJWTVerifier("none", nil, "", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,278 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/entity/webhook_entity"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+var errNonPublicAddress = errors.New("webhook url resolves to a private or loopback address")
+
+type WebhookNotifier struct {
+	webhookRepository webhook_entity.WebhookRepositoryInterface
+	httpClient        *http.Client
+	maxAttempts       int
+	initialBackoff    time.Duration
+
+	// queue holds the events not yet matched against webhooks. It is
+	// unbounded so that Enqueue never blocks or drops the publisher's event.
+	mutex  sync.Mutex
+	queue  []event_entity.Event
+	queued chan struct{}
+	// deliveries tracks the deliveries in flight, retries included.
+	deliveries sync.WaitGroup
+}
+
+func NewWebhookNotifier(webhookRepository webhook_entity.WebhookRepositoryInterface) *WebhookNotifier {
+	return &WebhookNotifier{
+		webhookRepository: webhookRepository,
+		httpClient:        newWebhookClient(getWebhookTimeout(), getWebhookAllowPrivateNetworks()),
+		maxAttempts:       getWebhookMaxAttempts(),
+		initialBackoff:    getWebhookInitialBackoff(),
+		queued:            make(chan struct{}, 1),
+	}
+}
+
+// newWebhookClient does not follow redirects, so a receiver cannot bounce
+// deliveries elsewhere, and unless allowPrivateNetworks is set refuses to
+// connect to non-public addresses. The check runs on the address being
+// dialed, after DNS resolution, so host names that resolve to internal
+// addresses are refused too.
+func newWebhookClient(timeout time.Duration, allowPrivateNetworks bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivateNetworks {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !webhook_entity.IsPublicIP(ip) {
+				return errNonPublicAddress
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// Enqueue queues event for delivery to the webhooks subscribed to it. It
+// never blocks, so it can be registered as an event dispatcher listener.
+func (wn *WebhookNotifier) Enqueue(event event_entity.Event) {
+	wn.mutex.Lock()
+	wn.queue = append(wn.queue, event)
+	wn.mutex.Unlock()
+
+	select {
+	case wn.queued <- struct{}{}:
+	default:
+	}
+}
+
+// Start delivers queued events until ctx is cancelled. It then delivers the
+// events still queued and returns once every delivery in flight, retries
+// included, has finished, so a WaitGroup around Start covers them.
+// Deliveries run detached from ctx so shutdown does not cut them short.
+func (wn *WebhookNotifier) Start(ctx context.Context) {
+	deliveryCtx := context.Background()
+	for {
+		for _, event := range wn.takeQueued() {
+			wn.notify(deliveryCtx, event)
+		}
+
+		select {
+		case <-ctx.Done():
+			for _, event := range wn.takeQueued() {
+				wn.notify(deliveryCtx, event)
+			}
+			wn.deliveries.Wait()
+			return
+		case <-wn.queued:
+		}
+	}
+}
+
+func (wn *WebhookNotifier) takeQueued() []event_entity.Event {
+	wn.mutex.Lock()
+	defer wn.mutex.Unlock()
+
+	events := wn.queue
+	wn.queue = nil
+
+	return events
+}
+
+func (wn *WebhookNotifier) notify(ctx context.Context, event event_entity.Event) {
+	webhooks, err := wn.webhookRepository.FindWebhooksByEventType(ctx, event.Type)
+	if err != nil {
+		logger.Error("Error trying to find webhooks by event type", err)
+		return
+	}
+
+	for _, webhook := range webhooks {
+		wn.deliveries.Add(1)
+		go func(webhook webhook_entity.Webhook) {
+			defer wn.deliveries.Done()
+			wn.Deliver(ctx, webhook, event)
+		}(webhook)
+	}
+}
+
+func (wn *WebhookNotifier) Deliver(
+	ctx context.Context, webhook webhook_entity.Webhook, event event_entity.Event) bool {
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		logger.Error("Error trying to marshal webhook payload", err)
+		return false
+	}
+
+	backoff := wn.initialBackoff
+	for attempt := 1; attempt <= wn.maxAttempts; attempt++ {
+		delivery := wn.send(ctx, webhook, event, payload)
+		delivery.Attempt = attempt
+
+		if err := wn.webhookRepository.CreateDelivery(ctx, delivery); err != nil {
+			logger.Error("Error trying to record webhook delivery", err)
+		}
+
+		if delivery.Success {
+			return true
+		}
+
+		logger.Info("Webhook delivery failed",
+			zap.String("webhook_id", webhook.Id),
+			zap.Uint64("event_id", event.Id),
+			zap.Int("attempt", attempt),
+			zap.Int("status_code", delivery.StatusCode),
+			zap.String("error", delivery.Error))
+
+		if attempt == wn.maxAttempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	return false
+}
+
+func (wn *WebhookNotifier) send(
+	ctx context.Context,
+	webhook webhook_entity.Webhook,
+	event event_entity.Event,
+	payload []byte) *webhook_entity.Delivery {
+
+	delivery := &webhook_entity.Delivery{
+		Id:        uuid.New().String(),
+		WebhookId: webhook.Id,
+		EventId:   event.Id,
+		EventType: event.Type,
+		Timestamp: time.Now(),
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Url, bytes.NewReader(payload))
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(EventHeader, string(event.Type))
+	request.Header.Set(DeliveryHeader, delivery.Id)
+	request.Header.Set(SignatureHeader, "sha256="+Sign(webhook.Secret, payload))
+
+	response, err := wn.httpClient.Do(request)
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+	defer response.Body.Close()
+
+	delivery.StatusCode = response.StatusCode
+	delivery.Success = response.StatusCode >= 200 && response.StatusCode < 300
+	if !delivery.Success {
+		delivery.Error = fmt.Sprintf("unexpected status code %d", response.StatusCode)
+	}
+
+	return delivery
+}
+
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func getWebhookMaxAttempts() int {
+	value, err := strconv.Atoi(os.Getenv("WEBHOOK_MAX_ATTEMPTS"))
+	if err != nil || value <= 0 {
+		return 5
+	}
+
+	return value
+}
+
+func getWebhookInitialBackoff() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("WEBHOOK_INITIAL_BACKOFF"))
+	if err != nil {
+		return time.Second
+	}
+
+	return duration
+}
+
+// getWebhookAllowPrivateNetworks reads WEBHOOK_ALLOW_PRIVATE_NETWORKS,
+// which lets development setups deliver to receivers on the local network.
+func getWebhookAllowPrivateNetworks() bool {
+	value, err := strconv.ParseBool(os.Getenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS"))
+	return err == nil && value
+}
+
+func getWebhookTimeout() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("WEBHOOK_TIMEOUT"))
+	if err != nil {
+		return 10 * time.Second
+	}
+
+	return duration
+}
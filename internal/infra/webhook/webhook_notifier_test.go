@@ -0,0 +1,286 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/entity/webhook_entity"
+	"fullcycle-auction_go/internal/infra/webhook"
+	"fullcycle-auction_go/internal/internal_error"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type webhookRepositoryStub struct {
+	mutex      sync.Mutex
+	webhooks   []webhook_entity.Webhook
+	deliveries []webhook_entity.Delivery
+}
+
+func (ws *webhookRepositoryStub) CreateWebhook(
+	ctx context.Context, webhookEntity *webhook_entity.Webhook) *internal_error.InternalError {
+	ws.webhooks = append(ws.webhooks, *webhookEntity)
+	return nil
+}
+
+func (ws *webhookRepositoryStub) FindWebhookById(
+	ctx context.Context, id string) (*webhook_entity.Webhook, *internal_error.InternalError) {
+	for _, value := range ws.webhooks {
+		if value.Id == id {
+			return &value, nil
+		}
+	}
+	return nil, internal_error.NewNotFoundError("Webhook not found")
+}
+
+func (ws *webhookRepositoryStub) FindWebhooksByEventType(
+	ctx context.Context,
+	eventType event_entity.EventType) ([]webhook_entity.Webhook, *internal_error.InternalError) {
+	var result []webhook_entity.Webhook
+	for _, value := range ws.webhooks {
+		if value.Subscribes(eventType) {
+			result = append(result, value)
+		}
+	}
+	return result, nil
+}
+
+func (ws *webhookRepositoryStub) CreateDelivery(
+	ctx context.Context, deliveryEntity *webhook_entity.Delivery) *internal_error.InternalError {
+	ws.mutex.Lock()
+	defer ws.mutex.Unlock()
+	ws.deliveries = append(ws.deliveries, *deliveryEntity)
+	return nil
+}
+
+func (ws *webhookRepositoryStub) FindDeliveriesByWebhookId(
+	ctx context.Context, webhookId string) ([]webhook_entity.Delivery, *internal_error.InternalError) {
+	ws.mutex.Lock()
+	defer ws.mutex.Unlock()
+	return ws.deliveries, nil
+}
+
+const userId = "6b0d6f1c-0b5e-4d6b-8f8e-2b9d1a3c4e5f"
+
+// newWebhook creates a webhook and points it at url, which, being an
+// httptest server, is a loopback address CreateWebhook would refuse.
+func newWebhook(t *testing.T, url string, eventType event_entity.EventType) *webhook_entity.Webhook {
+	webhookEntity, internalErr := webhook_entity.CreateWebhook(
+		userId, "https://example.com/hooks", []event_entity.EventType{eventType}, "")
+	assert.Nil(t, internalErr)
+
+	webhookEntity.Url = url
+	return webhookEntity
+}
+
+func allowPrivateNetworks(t *testing.T) {
+	os.Setenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS", "true")
+	t.Cleanup(func() { os.Unsetenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS") })
+}
+
+func TestWebhookDeliverySignsAndRetries(t *testing.T) {
+	os.Setenv("WEBHOOK_INITIAL_BACKOFF", "10ms")
+	defer os.Unsetenv("WEBHOOK_INITIAL_BACKOFF")
+	allowPrivateNetworks(t)
+
+	webhookEntity, internalErr := webhook_entity.CreateWebhook(
+		userId, "https://example.com/hooks", []event_entity.EventType{event_entity.BidCreated}, "")
+	assert.Nil(t, internalErr)
+
+	var mutex sync.Mutex
+	requests := 0
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		requests++
+
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "sha256="+webhook.Sign(webhookEntity.Secret, body), r.Header.Get(webhook.SignatureHeader))
+		assert.Equal(t, string(event_entity.BidCreated), r.Header.Get(webhook.EventHeader))
+
+		var payload map[string]interface{}
+		assert.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, "auction-id", payload["auction_id"])
+
+		if requests == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	webhookEntity.Url = receiver.URL
+	repository := &webhookRepositoryStub{webhooks: []webhook_entity.Webhook{*webhookEntity}}
+	notifier := webhook.NewWebhookNotifier(repository)
+
+	delivered := notifier.Deliver(context.Background(), *webhookEntity, event_entity.Event{
+		Id:        1,
+		Type:      event_entity.BidCreated,
+		AuctionId: "auction-id",
+		Data:      event_entity.BidData{Amount: 10},
+	})
+
+	assert.True(t, delivered)
+	assert.Equal(t, 2, requests)
+
+	deliveries, _ := repository.FindDeliveriesByWebhookId(context.Background(), webhookEntity.Id)
+	assert.Len(t, deliveries, 2)
+	assert.False(t, deliveries[0].Success)
+	assert.Equal(t, http.StatusInternalServerError, deliveries[0].StatusCode)
+	assert.Equal(t, 1, deliveries[0].Attempt)
+	assert.True(t, deliveries[1].Success)
+	assert.Equal(t, 2, deliveries[1].Attempt)
+}
+
+func TestWebhookDeliveryGivesUpAfterMaxAttempts(t *testing.T) {
+	os.Setenv("WEBHOOK_INITIAL_BACKOFF", "1ms")
+	os.Setenv("WEBHOOK_MAX_ATTEMPTS", "3")
+	defer os.Unsetenv("WEBHOOK_INITIAL_BACKOFF")
+	defer os.Unsetenv("WEBHOOK_MAX_ATTEMPTS")
+	allowPrivateNetworks(t)
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer receiver.Close()
+
+	webhookEntity := newWebhook(t, receiver.URL, event_entity.AuctionClosed)
+
+	repository := &webhookRepositoryStub{}
+	delivered := webhook.NewWebhookNotifier(repository).Deliver(
+		context.Background(), *webhookEntity, event_entity.Event{Id: 7, Type: event_entity.AuctionClosed})
+
+	assert.False(t, delivered)
+	assert.Len(t, repository.deliveries, 3)
+}
+
+func TestWebhookUrlsMustBePublic(t *testing.T) {
+	for _, url := range []string{
+		"http://localhost:8080/hooks",
+		"http://api.localhost/hooks",
+		"http://127.0.0.1/hooks",
+		"http://10.0.0.5/hooks",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[::1]/hooks",
+	} {
+		_, internalErr := webhook_entity.CreateWebhook(
+			userId, url, []event_entity.EventType{event_entity.BidCreated}, "")
+		if assert.NotNil(t, internalErr, url) {
+			assert.Equal(t, "bad_request", internalErr.Err)
+		}
+	}
+
+	_, internalErr := webhook_entity.CreateWebhook(
+		userId, "https://93.184.216.34/hooks", []event_entity.EventType{event_entity.BidCreated}, "")
+	assert.Nil(t, internalErr)
+}
+
+func TestWebhookDeliveryRefusesPrivateAddresses(t *testing.T) {
+	os.Setenv("WEBHOOK_MAX_ATTEMPTS", "1")
+	defer os.Unsetenv("WEBHOOK_MAX_ATTEMPTS")
+
+	requests := 0
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer receiver.Close()
+
+	webhookEntity := newWebhook(t, receiver.URL, event_entity.BidCreated)
+
+	repository := &webhookRepositoryStub{}
+	delivered := webhook.NewWebhookNotifier(repository).Deliver(
+		context.Background(), *webhookEntity, event_entity.Event{Id: 1, Type: event_entity.BidCreated})
+
+	assert.False(t, delivered)
+	assert.Equal(t, 0, requests)
+	if assert.Len(t, repository.deliveries, 1) {
+		assert.Contains(t, repository.deliveries[0].Error, "private or loopback")
+	}
+}
+
+func TestWebhookDeliveryDoesNotFollowRedirects(t *testing.T) {
+	os.Setenv("WEBHOOK_MAX_ATTEMPTS", "1")
+	defer os.Unsetenv("WEBHOOK_MAX_ATTEMPTS")
+	allowPrivateNetworks(t)
+
+	redirected := 0
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirected++
+	}))
+	defer target.Close()
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer receiver.Close()
+
+	webhookEntity := newWebhook(t, receiver.URL, event_entity.BidCreated)
+
+	repository := &webhookRepositoryStub{}
+	delivered := webhook.NewWebhookNotifier(repository).Deliver(
+		context.Background(), *webhookEntity, event_entity.Event{Id: 1, Type: event_entity.BidCreated})
+
+	assert.False(t, delivered)
+	assert.Equal(t, 0, redirected)
+	if assert.Len(t, repository.deliveries, 1) {
+		assert.Equal(t, http.StatusTemporaryRedirect, repository.deliveries[0].StatusCode)
+	}
+}
+
+func TestWebhookNotifierFinishesDeliveriesBeforeStopping(t *testing.T) {
+	os.Setenv("WEBHOOK_INITIAL_BACKOFF", "50ms")
+	os.Setenv("WEBHOOK_MAX_ATTEMPTS", "2")
+	defer os.Unsetenv("WEBHOOK_INITIAL_BACKOFF")
+	defer os.Unsetenv("WEBHOOK_MAX_ATTEMPTS")
+	allowPrivateNetworks(t)
+
+	var mutex sync.Mutex
+	requests := 0
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer receiver.Close()
+
+	webhookEntity := newWebhook(t, receiver.URL, event_entity.BidCreated)
+	repository := &webhookRepositoryStub{webhooks: []webhook_entity.Webhook{*webhookEntity}}
+	notifier := webhook.NewWebhookNotifier(repository)
+
+	// Events queued before Start, as many as a full subscriber would have
+	// dropped, are all delivered.
+	for id := uint64(1); id <= 200; id++ {
+		notifier.Enqueue(event_entity.Event{Id: id, Type: event_entity.AuctionClosed})
+	}
+	notifier.Enqueue(event_entity.Event{Id: 201, Type: event_entity.BidCreated})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		notifier.Start(ctx)
+		close(stopped)
+	}()
+	cancel()
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("notifier did not stop")
+	}
+
+	// The first attempt failed, so Start only returned after the retry.
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Equal(t, 2, requests)
+}
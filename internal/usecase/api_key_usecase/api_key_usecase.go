@@ -0,0 +1,122 @@
+package api_key_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/entity/api_key_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+type ApiKeyInputDTO struct {
+	Name   string
+	Scopes []auth.Scope
+}
+
+type ApiKeyOutputDTO struct {
+	Id        string       `json:"id"`
+	Name      string       `json:"name"`
+	Key       string       `json:"key,omitempty"`
+	Prefix    string       `json:"prefix"`
+	Scopes    []auth.Scope `json:"scopes"`
+	Timestamp time.Time    `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+}
+
+type ApiKeyUseCase struct {
+	ApiKeyRepository api_key_entity.ApiKeyRepositoryInterface
+}
+
+type ApiKeyUseCaseInterface interface {
+	CreateApiKey(
+		ctx context.Context,
+		apiKeyInput ApiKeyInputDTO) (*ApiKeyOutputDTO, *internal_error.InternalError)
+
+	RevokeApiKey(
+		ctx context.Context, apiKeyId string) *internal_error.InternalError
+
+	VerifyApiKey(
+		ctx context.Context, key string) (*auth.Identity, *internal_error.InternalError)
+}
+
+func NewApiKeyUseCase(apiKeyRepository api_key_entity.ApiKeyRepositoryInterface) ApiKeyUseCaseInterface {
+	return &ApiKeyUseCase{
+		ApiKeyRepository: apiKeyRepository,
+	}
+}
+
+// CreateApiKey issues a key for the caller. The key keeps a snapshot of the
+// caller's roles, and its scopes only narrow them down.
+func (au *ApiKeyUseCase) CreateApiKey(
+	ctx context.Context,
+	apiKeyInput ApiKeyInputDTO) (*ApiKeyOutputDTO, *internal_error.InternalError) {
+
+	if err := auth.Authorize(ctx, auth.IssueApiKey, ""); err != nil {
+		return nil, err
+	}
+
+	caller, _ := auth.IdentityFromContext(ctx)
+	if caller.ApiKeyId != "" {
+		return nil, internal_error.NewForbiddenError("Api keys cannot issue other api keys")
+	}
+
+	apiKey, plaintext, err := api_key_entity.CreateApiKey(
+		caller.UserId, apiKeyInput.Name, apiKeyInput.Scopes, caller.Roles)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := au.ApiKeyRepository.CreateApiKey(ctx, apiKey); err != nil {
+		return nil, err
+	}
+
+	return &ApiKeyOutputDTO{
+		Id:        apiKey.Id,
+		Name:      apiKey.Name,
+		Key:       plaintext,
+		Prefix:    apiKey.Prefix,
+		Scopes:    apiKey.Scopes,
+		Timestamp: apiKey.Timestamp,
+	}, nil
+}
+
+func (au *ApiKeyUseCase) RevokeApiKey(
+	ctx context.Context, apiKeyId string) *internal_error.InternalError {
+
+	if _, ok := auth.IdentityFromContext(ctx); !ok {
+		return internal_error.NewUnauthorizedError("Authentication is required")
+	}
+
+	apiKey, err := au.ApiKeyRepository.FindApiKeyById(ctx, apiKeyId)
+	if err != nil {
+		return err
+	}
+
+	if err := auth.Authorize(ctx, auth.RevokeApiKey, apiKey.UserId); err != nil {
+		return err
+	}
+
+	return au.ApiKeyRepository.RevokeApiKey(ctx, apiKey.Id, time.Now())
+}
+
+func (au *ApiKeyUseCase) VerifyApiKey(
+	ctx context.Context, key string) (*auth.Identity, *internal_error.InternalError) {
+
+	apiKey, err := au.ApiKeyRepository.FindApiKeyByHash(ctx, api_key_entity.HashKey(key))
+	if err != nil {
+		if err.Err == "not_found" {
+			return nil, internal_error.NewUnauthorizedError("Invalid api key")
+		}
+		return nil, err
+	}
+
+	if apiKey.IsRevoked() {
+		return nil, internal_error.NewUnauthorizedError("Api key has been revoked")
+	}
+
+	return &auth.Identity{
+		UserId:   apiKey.UserId,
+		Roles:    apiKey.Roles,
+		Scopes:   apiKey.Scopes,
+		ApiKeyId: apiKey.Id,
+	}, nil
+}
@@ -0,0 +1,104 @@
+package api_key_usecase_test
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/entity/api_key_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/api_key_usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	userId      = "6b0d6f1c-0b5e-4d6b-8f8e-2b9d1a3c4e5f"
+	otherUserId = "9e1f2a3b-4c5d-4e6f-8a7b-0c1d2e3f4a5b"
+)
+
+type apiKeyRepositoryStub struct {
+	apiKeys map[string]*api_key_entity.ApiKey
+}
+
+func (rs *apiKeyRepositoryStub) CreateApiKey(
+	ctx context.Context, apiKeyEntity *api_key_entity.ApiKey) *internal_error.InternalError {
+	rs.apiKeys[apiKeyEntity.Id] = apiKeyEntity
+	return nil
+}
+
+func (rs *apiKeyRepositoryStub) FindApiKeyById(
+	ctx context.Context, id string) (*api_key_entity.ApiKey, *internal_error.InternalError) {
+	if apiKey, ok := rs.apiKeys[id]; ok {
+		return apiKey, nil
+	}
+	return nil, internal_error.NewNotFoundError("Api key not found")
+}
+
+func (rs *apiKeyRepositoryStub) FindApiKeyByHash(
+	ctx context.Context, hash string) (*api_key_entity.ApiKey, *internal_error.InternalError) {
+	for _, apiKey := range rs.apiKeys {
+		if apiKey.Hash == hash {
+			return apiKey, nil
+		}
+	}
+	return nil, internal_error.NewNotFoundError("Api key not found")
+}
+
+func (rs *apiKeyRepositoryStub) RevokeApiKey(
+	ctx context.Context, id string, revokedAt time.Time) *internal_error.InternalError {
+	rs.apiKeys[id].RevokedAt = &revokedAt
+	return nil
+}
+
+func as(userId string, roles ...auth.Role) context.Context {
+	return auth.WithIdentity(context.Background(), &auth.Identity{UserId: userId, Roles: roles})
+}
+
+func TestApiKeyLifecycle(t *testing.T) {
+	repository := &apiKeyRepositoryStub{apiKeys: map[string]*api_key_entity.ApiKey{}}
+	apiKeyUseCase := api_key_usecase.NewApiKeyUseCase(repository)
+	owner := as(userId, auth.RoleBidder)
+
+	output, err := apiKeyUseCase.CreateApiKey(owner, api_key_usecase.ApiKeyInputDTO{
+		Name: "partner", Scopes: []auth.Scope{auth.ScopeBid}})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, output.Key)
+	assert.NotEqual(t, output.Key, repository.apiKeys[output.Id].Hash, "only the hash is stored")
+
+	identity, err := apiKeyUseCase.VerifyApiKey(context.Background(), output.Key)
+	assert.Nil(t, err)
+	assert.Equal(t, userId, identity.UserId)
+	assert.Equal(t, []auth.Role{auth.RoleBidder}, identity.Roles)
+	assert.True(t, identity.HasScope(auth.ScopeBid))
+	assert.True(t, identity.HasScope(auth.ScopeReadOnly))
+	assert.False(t, identity.HasScope(auth.ScopeManageAuctions))
+
+	_, err = apiKeyUseCase.CreateApiKey(auth.WithIdentity(context.Background(), identity),
+		api_key_usecase.ApiKeyInputDTO{Name: "escalated", Scopes: []auth.Scope{auth.ScopeManageAuctions}})
+	assert.Equal(t, "forbidden", err.Err, "api keys cannot mint keys")
+
+	_, err = apiKeyUseCase.VerifyApiKey(context.Background(), output.Key+"x")
+	assert.Equal(t, "unauthorized", err.Err)
+
+	err = apiKeyUseCase.RevokeApiKey(as(otherUserId, auth.RoleBidder), output.Id)
+	assert.Equal(t, "forbidden", err.Err)
+
+	assert.Nil(t, apiKeyUseCase.RevokeApiKey(owner, output.Id))
+
+	_, err = apiKeyUseCase.VerifyApiKey(context.Background(), output.Key)
+	assert.Equal(t, "unauthorized", err.Err)
+}
+
+func TestCreateApiKeyValidatesScopes(t *testing.T) {
+	apiKeyUseCase := api_key_usecase.NewApiKeyUseCase(
+		&apiKeyRepositoryStub{apiKeys: map[string]*api_key_entity.ApiKey{}})
+
+	_, err := apiKeyUseCase.CreateApiKey(context.Background(), api_key_usecase.ApiKeyInputDTO{
+		Name: "partner", Scopes: []auth.Scope{auth.ScopeBid}})
+	assert.Equal(t, "unauthorized", err.Err)
+
+	_, err = apiKeyUseCase.CreateApiKey(as(userId, auth.RoleSeller), api_key_usecase.ApiKeyInputDTO{
+		Name: "partner", Scopes: []auth.Scope{"admin"}})
+	assert.Equal(t, "bad_request", err.Err)
+}
@@ -21,6 +21,28 @@ type (
 		Condition   auction_entity.ProductCondition
 		Status      auction_entity.AuctionStatus
 		Timestamp   time.Time
+		EndTime     time.Time
+	}
+
+	SearchOutputDTO struct {
+		Hits       []SearchHitOutputDTO
+		Total      int
+		Categories []CategoryFacetOutputDTO
+		Conditions []auction_entity.ConditionCount
+	}
+
+	// CategoryFacetOutputDTO counts the matches in a category. Category is
+	// its slug and Name its name, or both are the free-form category of
+	// auctions created before the category tree.
+	CategoryFacetOutputDTO struct {
+		Category string
+		Name     string
+		Count    int
+	}
+
+	SearchHitOutputDTO struct {
+		Auction AuctionOutputDTO
+		Score   float64
 	}
 
 	WinningInfoOutputDTO struct {
@@ -2,18 +2,25 @@ package auction_usecase
 
 import (
 	"context"
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/clock"
 	"fullcycle-auction_go/internal/entity/auction_entity"
 	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/category_entity"
 	"fullcycle-auction_go/internal/internal_error"
 )
 
 func NewAuctionUseCase(
 	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface,
-	bidRepositoryInterface bid_entity.BidEntityRepository) AuctionUseCaseInterface {
+	bidRepositoryInterface bid_entity.BidEntityRepository,
+	categoryRepositoryInterface category_entity.CategoryRepositoryInterface,
+	clock clock.Clock) AuctionUseCaseInterface {
 
 	return &AuctionUseCase{
-		auctionRepositoryInterface: auctionRepositoryInterface,
-		bidRepositoryInterface:     bidRepositoryInterface,
+		auctionRepositoryInterface:  auctionRepositoryInterface,
+		bidRepositoryInterface:      bidRepositoryInterface,
+		categoryRepositoryInterface: categoryRepositoryInterface,
+		clock:                       clock,
 	}
 }
 
@@ -24,22 +31,40 @@ type AuctionUseCaseInterface interface {
 }
 
 type AuctionUseCase struct {
-	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface
-	bidRepositoryInterface     bid_entity.BidEntityRepository
+	auctionRepositoryInterface  auction_entity.AuctionRepositoryInterface
+	bidRepositoryInterface      bid_entity.BidEntityRepository
+	categoryRepositoryInterface category_entity.CategoryRepositoryInterface
+	clock                       clock.Clock
 }
 
+// CreateAuction accepts the category as an id or a slug and stores its id,
+// along with its name for search.
 func (au *AuctionUseCase) CreateAuction(
 	ctx context.Context,
 	auctionInput AuctionInputDTO) (*AuctionOutputDTO, *internal_error.InternalError) {
 
+	if err := auth.Authorize(ctx, auth.CreateAuction, ""); err != nil {
+		return nil, err
+	}
+
+	category, err := category_entity.FindCategory(ctx, au.categoryRepositoryInterface, auctionInput.Category)
+	if err != nil {
+		if err.Err == "not_found" {
+			return nil, internal_error.NewBadRequestError("Category not found")
+		}
+		return nil, err
+	}
+
 	auction, err := auction_entity.CreateAuction(
 		auctionInput.ProductName,
-		auctionInput.Category,
+		category.Id,
 		auctionInput.Description,
-		auctionInput.Condition)
+		auctionInput.Condition,
+		au.clock.Now())
 	if err != nil {
 		return nil, err
 	}
+	auction.CategoryName = category.Name
 
 	if err := au.auctionRepositoryInterface.CreateAuction(
 		ctx, auction); err != nil {
@@ -54,5 +79,6 @@ func (au *AuctionUseCase) CreateAuction(
 		Condition:   auction.Condition,
 		Status:      auction.Status,
 		Timestamp:   auction.Timestamp,
+		EndTime:     auction.EndTime,
 	}, nil
 }
@@ -2,9 +2,12 @@ package auction_usecase
 
 import (
 	"context"
+	"fmt"
 	"fullcycle-auction_go/internal/entity/auction_entity"
 	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/category_entity"
 	"fullcycle-auction_go/internal/internal_error"
+	"sort"
 )
 
 type AuctionFindUseCaseInterface interface {
@@ -14,7 +17,12 @@ type AuctionFindUseCaseInterface interface {
 	FindAuctions(
 		ctx context.Context,
 		status auction_entity.AuctionStatus,
-		category, productName string) ([]AuctionOutputDTO, *internal_error.InternalError)
+		category, productName string,
+		includeSubcategories bool) ([]AuctionOutputDTO, *internal_error.InternalError)
+
+	SearchAuctions(
+		ctx context.Context,
+		query auction_entity.SearchQuery) (*SearchOutputDTO, *internal_error.InternalError)
 
 	FindWinningBidByAuctionId(
 		ctx context.Context,
@@ -22,17 +30,20 @@ type AuctionFindUseCaseInterface interface {
 }
 
 type AuctionFindUseCase struct {
-	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface
-	bidRepositoryInterface     bid_entity.BidEntityRepository
+	auctionRepositoryInterface  auction_entity.AuctionRepositoryInterface
+	bidRepositoryInterface      bid_entity.BidEntityRepository
+	categoryRepositoryInterface category_entity.CategoryRepositoryInterface
 }
 
 func NewAuctionFindUseCase(
 	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface,
-	bidRepositoryInterface bid_entity.BidEntityRepository) AuctionFindUseCaseInterface {
+	bidRepositoryInterface bid_entity.BidEntityRepository,
+	categoryRepositoryInterface category_entity.CategoryRepositoryInterface) AuctionFindUseCaseInterface {
 
 	return &AuctionFindUseCase{
-		auctionRepositoryInterface: auctionRepositoryInterface,
-		bidRepositoryInterface:     bidRepositoryInterface,
+		auctionRepositoryInterface:  auctionRepositoryInterface,
+		bidRepositoryInterface:      bidRepositoryInterface,
+		categoryRepositoryInterface: categoryRepositoryInterface,
 	}
 }
 
@@ -52,15 +63,24 @@ func (au *AuctionFindUseCase) FindAuctionById(
 		Condition:   auctionEntity.Condition,
 		Status:      auctionEntity.Status,
 		Timestamp:   auctionEntity.Timestamp,
+		EndTime:     auctionEntity.EndTime,
 	}, nil
 }
 
+// FindAuctions filters by a category id or slug, and with
+// includeSubcategories also by every category below it.
 func (au *AuctionFindUseCase) FindAuctions(
 	ctx context.Context,
 	status auction_entity.AuctionStatus,
-	category, productName string) ([]AuctionOutputDTO, *internal_error.InternalError) {
+	category, productName string,
+	includeSubcategories bool) ([]AuctionOutputDTO, *internal_error.InternalError) {
+
+	categories, err := au.categoryIds(ctx, category, includeSubcategories)
+	if err != nil {
+		return nil, err
+	}
 
-	auctionEntities, err := au.auctionRepositoryInterface.FindAuctions(ctx, status, category, productName)
+	auctionEntities, err := au.auctionRepositoryInterface.FindAuctions(ctx, status, categories, productName)
 	if err != nil {
 		return nil, err
 	}
@@ -75,12 +95,147 @@ func (au *AuctionFindUseCase) FindAuctions(
 			Condition:   value.Condition,
 			Status:      value.Status,
 			Timestamp:   value.Timestamp,
+			EndTime:     value.EndTime,
 		})
 	}
 
 	return auctionOutputs, nil
 }
 
+// SearchAuctions requires at least one word to search for and a Limit of
+// at most auction_entity.MaxSearchLimit; zero means the default.
+func (au *AuctionFindUseCase) SearchAuctions(
+	ctx context.Context,
+	query auction_entity.SearchQuery) (*SearchOutputDTO, *internal_error.InternalError) {
+
+	if len(auction_entity.SearchTerms(query.Text)) == 0 {
+		return nil, internal_error.NewBadRequestError("Search text must contain a letter or digit")
+	}
+
+	if query.Limit < 0 || query.Limit > auction_entity.MaxSearchLimit {
+		return nil, internal_error.NewBadRequestError(
+			fmt.Sprintf("Limit must be between 1 and %d", auction_entity.MaxSearchLimit))
+	}
+	if query.Limit == 0 {
+		query.Limit = auction_entity.DefaultSearchLimit
+	}
+
+	if query.Category != "" {
+		categories, err := au.categoryIds(ctx, query.Category, false)
+		if err != nil {
+			return nil, err
+		}
+		query.Category = categories[0]
+	}
+
+	result, err := au.auctionRepositoryInterface.SearchAuctions(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]SearchHitOutputDTO, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		hits = append(hits, SearchHitOutputDTO{
+			Auction: AuctionOutputDTO{
+				Id:          hit.Auction.Id,
+				ProductName: hit.Auction.ProductName,
+				Category:    hit.Auction.Category,
+				Description: hit.Auction.Description,
+				Condition:   hit.Auction.Condition,
+				Status:      hit.Auction.Status,
+				Timestamp:   hit.Auction.Timestamp,
+				EndTime:     hit.Auction.EndTime,
+			},
+			Score: hit.Score,
+		})
+	}
+
+	categories, err := au.categoryFacets(ctx, result.Categories)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SearchOutputDTO{
+		Hits:       hits,
+		Total:      result.Total,
+		Categories: categories,
+		Conditions: result.Conditions,
+	}, nil
+}
+
+// categoryFacets replaces the category ids the repository counts by with
+// the slug and name of each category, most common first.
+func (au *AuctionFindUseCase) categoryFacets(
+	ctx context.Context,
+	counts []auction_entity.CategoryCount) ([]CategoryFacetOutputDTO, *internal_error.InternalError) {
+
+	facets := make([]CategoryFacetOutputDTO, 0, len(counts))
+	if len(counts) == 0 {
+		return facets, nil
+	}
+
+	categories, err := au.categoryRepositoryInterface.FindCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byId := make(map[string]category_entity.Category, len(categories))
+	for _, category := range categories {
+		byId[category.Id] = category
+	}
+
+	for _, count := range counts {
+		facet := CategoryFacetOutputDTO{Category: count.Category, Name: count.Category, Count: count.Count}
+		if category, ok := byId[count.Category]; ok {
+			facet.Category = category.Slug
+			facet.Name = category.Name
+		}
+		facets = append(facets, facet)
+	}
+
+	sort.SliceStable(facets, func(i, j int) bool {
+		if facets[i].Count != facets[j].Count {
+			return facets[i].Count > facets[j].Count
+		}
+		return facets[i].Category < facets[j].Category
+	})
+
+	return facets, nil
+}
+
+// categoryIds turns a category filter into the category ids auctions are
+// matched against. Values that are neither a category id nor a slug are
+// kept as they are, so auctions created before the category tree can still
+// be listed by their free-form category.
+func (au *AuctionFindUseCase) categoryIds(
+	ctx context.Context,
+	category string,
+	includeSubcategories bool) ([]string, *internal_error.InternalError) {
+
+	if category == "" {
+		return nil, nil
+	}
+
+	categoryEntity, err := category_entity.FindCategory(ctx, au.categoryRepositoryInterface, category)
+	if err != nil {
+		if err.Err == "not_found" {
+			return []string{category}, nil
+		}
+		return nil, err
+	}
+
+	if !includeSubcategories {
+		return []string{categoryEntity.Id}, nil
+	}
+
+	categories, err := au.categoryRepositoryInterface.FindCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return category_entity.SubtreeIds(categories, categoryEntity.Id), nil
+}
+
 func (au *AuctionFindUseCase) FindWinningBidByAuctionId(
 	ctx context.Context,
 	auctionId string) (*WinningInfoOutputDTO, *internal_error.InternalError) {
@@ -119,6 +274,7 @@ func (au *AuctionFindUseCase) FindWinningBidByAuctionId(
 		Condition:   auction.Condition,
 		Status:      auction.Status,
 		Timestamp:   auction.Timestamp,
+		EndTime:     auction.EndTime,
 	}
 
 	return &WinningInfoOutputDTO{
@@ -0,0 +1,202 @@
+package auction_usecase_test
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/clock"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"fullcycle-auction_go/internal/infra/database/bid"
+	"fullcycle-auction_go/internal/infra/database/category"
+	"fullcycle-auction_go/internal/infra/event"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"fullcycle-auction_go/internal/usecase/category_usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newFindUseCase(t *testing.T, auctions int) auction_usecase.AuctionFindUseCaseInterface {
+	fakeClock := clock.NewFakeClock(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	eventDispatcher := event.NewEventDispatcher()
+	auctionRepository := auction.NewMemoryAuctionRepository(eventDispatcher, fakeClock)
+
+	for i := 0; i < auctions; i++ {
+		auctionEntity, err := auction_entity.CreateAuction(
+			fmt.Sprintf("Guitar %d", i), "Instruments", "Electric guitar with case",
+			auction_entity.Used, fakeClock.Now())
+		assert.Nil(t, err)
+		assert.Nil(t, auctionRepository.CreateAuction(context.Background(), auctionEntity))
+	}
+
+	return auction_usecase.NewAuctionFindUseCase(
+		auctionRepository,
+		bid.NewMemoryBidRepository(auctionRepository, eventDispatcher, fakeClock),
+		category.NewMemoryCategoryRepository())
+}
+
+func TestFindAuctionsBySubcategories(t *testing.T) {
+	ctx := context.Background()
+	fakeClock := clock.NewFakeClock(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	eventDispatcher := event.NewEventDispatcher()
+	auctionRepository := auction.NewMemoryAuctionRepository(eventDispatcher, fakeClock)
+	categoryRepository := category.NewMemoryCategoryRepository()
+
+	music, err := category_entity.CreateCategory("Music", "", "", fakeClock.Now())
+	assert.Nil(t, err)
+	assert.Nil(t, categoryRepository.CreateCategory(ctx, music))
+	guitars, err := category_entity.CreateCategory("Guitars", "", music.Id, fakeClock.Now())
+	assert.Nil(t, err)
+	assert.Nil(t, categoryRepository.CreateCategory(ctx, guitars))
+
+	for _, categoryId := range []string{music.Id, guitars.Id, "Instruments"} {
+		auctionEntity, err := auction_entity.CreateAuction(
+			"Guitar", categoryId, "Electric guitar with case", auction_entity.Used, fakeClock.Now())
+		assert.Nil(t, err)
+		assert.Nil(t, auctionRepository.CreateAuction(ctx, auctionEntity))
+	}
+
+	useCase := auction_usecase.NewAuctionFindUseCase(
+		auctionRepository,
+		bid.NewMemoryBidRepository(auctionRepository, eventDispatcher, fakeClock),
+		categoryRepository)
+
+	categoriesOf := func(auctions []auction_usecase.AuctionOutputDTO) []string {
+		categories := []string{}
+		for _, auction := range auctions {
+			categories = append(categories, auction.Category)
+		}
+		return categories
+	}
+
+	auctions, err := useCase.FindAuctions(ctx, auction_entity.Active, "music", "", false)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{music.Id}, categoriesOf(auctions))
+
+	auctions, err = useCase.FindAuctions(ctx, auction_entity.Active, "music", "", true)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{music.Id, guitars.Id}, categoriesOf(auctions))
+
+	auctions, err = useCase.FindAuctions(ctx, auction_entity.Active, guitars.Id, "", true)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{guitars.Id}, categoriesOf(auctions))
+
+	// Auctions created before the category tree keep their free-form
+	// category.
+	auctions, err = useCase.FindAuctions(ctx, auction_entity.Active, "Instruments", "", true)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Instruments"}, categoriesOf(auctions))
+}
+
+func TestSearchAuctionsRequiresWords(t *testing.T) {
+	useCase := newFindUseCase(t, 1)
+
+	for _, text := range []string{"", "  ", "*.-"} {
+		_, err := useCase.SearchAuctions(context.Background(), auction_entity.SearchQuery{Text: text})
+		if assert.NotNil(t, err, text) {
+			assert.Equal(t, "bad_request", err.Err)
+		}
+	}
+}
+
+func TestSearchAuctionsLimit(t *testing.T) {
+	useCase := newFindUseCase(t, auction_entity.DefaultSearchLimit+1)
+	ctx := context.Background()
+
+	result, err := useCase.SearchAuctions(ctx, auction_entity.SearchQuery{Text: "guitar"})
+	assert.Nil(t, err)
+	assert.Len(t, result.Hits, auction_entity.DefaultSearchLimit)
+	assert.Equal(t, auction_entity.DefaultSearchLimit+1, result.Total)
+	assert.Equal(t, []auction_usecase.CategoryFacetOutputDTO{
+		{Category: "Instruments", Name: "Instruments", Count: auction_entity.DefaultSearchLimit + 1}}, result.Categories)
+
+	result, err = useCase.SearchAuctions(ctx, auction_entity.SearchQuery{Text: "guitar", Limit: 2})
+	assert.Nil(t, err)
+	assert.Len(t, result.Hits, 2)
+
+	_, err = useCase.SearchAuctions(ctx, auction_entity.SearchQuery{
+		Text: "guitar", Limit: auction_entity.MaxSearchLimit + 1})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "bad_request", err.Err)
+	}
+}
+
+func TestSearchAuctionsByCategoryName(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	eventDispatcher := event.NewEventDispatcher()
+	auctionRepository := auction.NewMemoryAuctionRepository(eventDispatcher, fakeClock)
+	bidRepository := bid.NewMemoryBidRepository(auctionRepository, eventDispatcher, fakeClock)
+	categoryRepository := category.NewMemoryCategoryRepository()
+
+	categoryUseCase := category_usecase.NewCategoryUseCase(categoryRepository, auctionRepository, fakeClock)
+	createUseCase := auction_usecase.NewAuctionUseCase(auctionRepository, bidRepository, categoryRepository, fakeClock)
+	findUseCase := auction_usecase.NewAuctionFindUseCase(auctionRepository, bidRepository, categoryRepository)
+
+	admin := auth.WithIdentity(context.Background(), &auth.Identity{
+		UserId: "6b0d6f1c-0b5e-4d6b-8f8e-2b9d1a3c4e5f", Roles: []auth.Role{auth.RoleAdmin, auth.RoleSeller}})
+
+	electronics, err := categoryUseCase.CreateCategory(admin, category_usecase.CategoryInputDTO{Name: "Eletrônicos"})
+	assert.Nil(t, err)
+	created, err := createUseCase.CreateAuction(admin, auction_usecase.AuctionInputDTO{
+		ProductName: "iPhone 13 Pro",
+		Category:    "eletronicos",
+		Description: "Novo na caixa, selado",
+		Condition:   auction_entity.New,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, electronics.Id, created.Category)
+
+	search := func(text string) *auction_usecase.SearchOutputDTO {
+		result, err := findUseCase.SearchAuctions(admin, auction_entity.SearchQuery{Text: text})
+		assert.Nil(t, err, text)
+		return result
+	}
+
+	result := search("eletronicos")
+	if assert.Len(t, result.Hits, 1) {
+		assert.Equal(t, created.Id, result.Hits[0].Auction.Id)
+	}
+	assert.Equal(t, []auction_usecase.CategoryFacetOutputDTO{
+		{Category: "eletronicos", Name: "Eletrônicos", Count: 1}}, result.Categories)
+
+	assert.Empty(t, search(electronics.Id[:8]).Hits)
+
+	_, err = categoryUseCase.UpdateCategory(admin, "eletronicos", category_usecase.CategoryInputDTO{
+		Name: "Informática", Slug: "informatica"})
+	assert.Nil(t, err)
+
+	assert.Empty(t, search("eletronicos").Hits)
+	result = search("informatica")
+	assert.Len(t, result.Hits, 1)
+	assert.Equal(t, []auction_usecase.CategoryFacetOutputDTO{
+		{Category: "informatica", Name: "Informática", Count: 1}}, result.Categories)
+}
+
+func TestFindWinningBidOfAuctionWithoutBids(t *testing.T) {
+	t.Setenv("AUCTION_DURATION", "1m")
+	ctx := context.Background()
+	fakeClock := clock.NewFakeClock(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	eventDispatcher := event.NewEventDispatcher()
+	auctionRepository := auction.NewMemoryAuctionRepository(eventDispatcher, fakeClock)
+
+	auctionEntity, err := auction_entity.CreateAuction(
+		"Guitar", "Instruments", "Electric guitar with case", auction_entity.Used, fakeClock.Now())
+	assert.Nil(t, err)
+	assert.Nil(t, auctionRepository.CreateAuction(ctx, auctionEntity))
+
+	fakeClock.Advance(time.Minute)
+	auctionRepository.CloseExpiredAuctions(ctx)
+
+	useCase := auction_usecase.NewAuctionFindUseCase(
+		auctionRepository,
+		bid.NewMemoryBidRepository(auctionRepository, eventDispatcher, fakeClock),
+		category.NewMemoryCategoryRepository())
+
+	_, err = useCase.FindWinningBidByAuctionId(ctx, auctionEntity.Id)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "not_found", err.Err)
+	}
+}
@@ -0,0 +1,224 @@
+package bid_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type FlushReason string
+
+const (
+	FlushOnSize     FlushReason = "size"
+	FlushOnInterval FlushReason = "interval"
+	FlushOnShutdown FlushReason = "shutdown"
+)
+
+// BidBatcherStats are cumulative counters of one BidBatcher. FlushedBids
+// counts the bids the repository placed and FailedBids the ones it did not,
+// such as bids that did not beat the auction's best bid.
+type BidBatcherStats struct {
+	QueuedBids    int64                 `json:"queued_bids"`
+	FlushedBids   int64                 `json:"flushed_bids"`
+	FailedBids    int64                 `json:"failed_bids"`
+	Flushes       map[FlushReason]int64 `json:"flushes"`
+	LastBatchSize int                   `json:"last_batch_size"`
+	MaxBatchSize  int                   `json:"max_batch_size"`
+	// BatchSizes counts flushes by the number of bids they wrote.
+	BatchSizes map[int]int64 `json:"batch_sizes"`
+}
+
+// BidBatcher collects bids and writes them to the repository in batches of
+// up to maxBatchSize, or once the oldest pending bid has waited interval.
+type BidBatcher struct {
+	repository   bid_entity.BidEntityRepository
+	maxBatchSize int
+	interval     time.Duration
+	bids         chan bid_entity.Bid
+
+	// closedMutex makes sure no bid is sent to bids once Shutdown has
+	// started draining it.
+	closedMutex sync.RWMutex
+	closed      bool
+	stop        chan struct{}
+	stopped     chan struct{}
+	startOnce   sync.Once
+	stopOnce    sync.Once
+
+	statsMutex sync.Mutex
+	stats      BidBatcherStats
+}
+
+func NewBidBatcher(
+	repository bid_entity.BidEntityRepository,
+	maxBatchSize int,
+	interval time.Duration) *BidBatcher {
+
+	if maxBatchSize < 1 {
+		maxBatchSize = 1
+	}
+
+	return &BidBatcher{
+		repository:   repository,
+		maxBatchSize: maxBatchSize,
+		interval:     interval,
+		bids:         make(chan bid_entity.Bid, maxBatchSize),
+		stop:         make(chan struct{}),
+		stopped:      make(chan struct{}),
+		stats: BidBatcherStats{
+			Flushes:    map[FlushReason]int64{},
+			BatchSizes: map[int]int64{},
+		},
+	}
+}
+
+// Start runs the batching routine. Batches are written with ctx rather than
+// the context of the requests that added the bids, which may be over by the
+// time the batch is flushed; ctx should stay alive until Shutdown returns.
+func (bb *BidBatcher) Start(ctx context.Context) {
+	bb.startOnce.Do(func() {
+		go bb.run(ctx)
+	})
+}
+
+// Add queues a bid, waiting for room in the queue until ctx is done.
+func (bb *BidBatcher) Add(ctx context.Context, bid bid_entity.Bid) *internal_error.InternalError {
+	bb.closedMutex.RLock()
+	defer bb.closedMutex.RUnlock()
+
+	if bb.closed {
+		return internal_error.NewServiceUnavailableError("Bids are not accepted while the server shuts down")
+	}
+
+	select {
+	case bb.bids <- bid:
+		bb.statsMutex.Lock()
+		bb.stats.QueuedBids++
+		bb.statsMutex.Unlock()
+		return nil
+	case <-ctx.Done():
+		return internal_error.NewServiceUnavailableError("Bid was not queued before the request ended")
+	}
+}
+
+// Shutdown stops accepting bids, writes the pending batch and waits for the
+// batching routine to finish or for ctx to expire.
+func (bb *BidBatcher) Shutdown(ctx context.Context) error {
+	bb.stopOnce.Do(func() {
+		bb.closedMutex.Lock()
+		bb.closed = true
+		bb.closedMutex.Unlock()
+
+		close(bb.stop)
+	})
+
+	// A batcher that was never started has nothing to wait for.
+	bb.startOnce.Do(func() {
+		close(bb.stopped)
+	})
+
+	select {
+	case <-bb.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (bb *BidBatcher) Stats() BidBatcherStats {
+	bb.statsMutex.Lock()
+	defer bb.statsMutex.Unlock()
+
+	stats := bb.stats
+	stats.Flushes = make(map[FlushReason]int64, len(bb.stats.Flushes))
+	for reason, count := range bb.stats.Flushes {
+		stats.Flushes[reason] = count
+	}
+	stats.BatchSizes = make(map[int]int64, len(bb.stats.BatchSizes))
+	for size, count := range bb.stats.BatchSizes {
+		stats.BatchSizes[size] = count
+	}
+
+	return stats
+}
+
+func (bb *BidBatcher) run(ctx context.Context) {
+	defer close(bb.stopped)
+
+	var batch []bid_entity.Bid
+
+	// The timer only runs while the batch holds bids, so an idle batcher
+	// never flushes.
+	timer := time.NewTimer(bb.interval)
+	timer.Stop()
+	var timeout <-chan time.Time
+
+	flush := func(reason FlushReason) {
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timeout = nil
+
+		if len(batch) == 0 {
+			return
+		}
+
+		bb.flush(ctx, batch, reason)
+		batch = nil
+	}
+
+	for {
+		select {
+		case bid := <-bb.bids:
+			batch = append(batch, bid)
+			if len(batch) == 1 {
+				timer.Reset(bb.interval)
+				timeout = timer.C
+			}
+
+			if len(batch) >= bb.maxBatchSize {
+				flush(FlushOnSize)
+			}
+		case <-timeout:
+			flush(FlushOnInterval)
+		case <-bb.stop:
+			for len(bb.bids) > 0 {
+				batch = append(batch, <-bb.bids)
+			}
+
+			flush(FlushOnShutdown)
+			return
+		}
+	}
+}
+
+func (bb *BidBatcher) flush(ctx context.Context, batch []bid_entity.Bid, reason FlushReason) {
+	failures := bb.repository.CreateBid(ctx, batch)
+
+	bb.statsMutex.Lock()
+	bb.stats.Flushes[reason]++
+	bb.stats.BatchSizes[len(batch)]++
+	bb.stats.LastBatchSize = len(batch)
+	if len(batch) > bb.stats.MaxBatchSize {
+		bb.stats.MaxBatchSize = len(batch)
+	}
+	bb.stats.FailedBids += int64(len(failures))
+	bb.stats.FlushedBids += int64(len(batch) - len(failures))
+	bb.statsMutex.Unlock()
+
+	for _, failure := range failures {
+		logger.Info("Bid was not placed",
+			zap.String("bid_id", failure.Bid.Id),
+			zap.String("auction_id", failure.Bid.AuctionId),
+			zap.String("reason", failure.Err.Error()),
+			zap.String("flush_reason", string(reason)))
+	}
+}
@@ -0,0 +1,170 @@
+package bid_usecase_test
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/clock"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newBid(amount float64) bid_entity.Bid {
+	return bid_entity.Bid{Id: fmt.Sprint(amount), UserId: userId, AuctionId: auctionId, Amount: amount}
+}
+
+func (r *recordingBidRepositoryStub) batches() [][]bid_entity.Bid {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([][]bid_entity.Bid(nil), r.batchList...)
+}
+
+func TestBidBatcherFlushesOnSize(t *testing.T) {
+	repository := &recordingBidRepositoryStub{}
+	batcher := bid_usecase.NewBidBatcher(repository, 3, time.Hour)
+	batcher.Start(context.Background())
+
+	for i := 1; i <= 7; i++ {
+		assert.Nil(t, batcher.Add(context.Background(), newBid(float64(i))))
+	}
+
+	assert.Eventually(t, func() bool { return len(repository.batches()) == 2 }, time.Second, 5*time.Millisecond)
+	assert.NoError(t, batcher.Shutdown(context.Background()))
+
+	batches := repository.batches()
+	assert.Len(t, batches, 3)
+	assert.Len(t, batches[0], 3)
+	assert.Len(t, batches[1], 3)
+	assert.Equal(t, []bid_entity.Bid{newBid(7)}, batches[2])
+
+	stats := batcher.Stats()
+	assert.Equal(t, int64(7), stats.QueuedBids)
+	assert.Equal(t, int64(7), stats.FlushedBids)
+	assert.Equal(t, int64(2), stats.Flushes[bid_usecase.FlushOnSize])
+	assert.Equal(t, int64(1), stats.Flushes[bid_usecase.FlushOnShutdown])
+	assert.Equal(t, map[int]int64{3: 2, 1: 1}, stats.BatchSizes)
+	assert.Equal(t, 3, stats.MaxBatchSize)
+	assert.Equal(t, 1, stats.LastBatchSize)
+}
+
+func TestBidBatcherFlushesOnIntervalAndNeverEmpty(t *testing.T) {
+	repository := &recordingBidRepositoryStub{}
+	batcher := bid_usecase.NewBidBatcher(repository, 10, 20*time.Millisecond)
+	batcher.Start(context.Background())
+
+	// An idle batcher must not write empty batches.
+	time.Sleep(100 * time.Millisecond)
+	assert.Empty(t, repository.batches())
+
+	assert.Nil(t, batcher.Add(context.Background(), newBid(1)))
+	assert.Nil(t, batcher.Add(context.Background(), newBid(2)))
+	assert.Eventually(t, func() bool { return len(repository.batches()) == 1 }, time.Second, 5*time.Millisecond)
+
+	time.Sleep(100 * time.Millisecond)
+	assert.NoError(t, batcher.Shutdown(context.Background()))
+
+	assert.Equal(t, [][]bid_entity.Bid{{newBid(1), newBid(2)}}, repository.batches())
+	assert.Equal(t, int64(1), batcher.Stats().Flushes[bid_usecase.FlushOnInterval])
+	assert.Zero(t, batcher.Stats().Flushes[bid_usecase.FlushOnShutdown])
+}
+
+func TestBidBatcherRejectsBidsAfterShutdown(t *testing.T) {
+	batcher := bid_usecase.NewBidBatcher(&recordingBidRepositoryStub{}, 10, time.Hour)
+
+	// Shutdown does not wait for a batcher that was never started.
+	assert.NoError(t, batcher.Shutdown(context.Background()))
+
+	err := batcher.Add(context.Background(), newBid(1))
+	assert.Equal(t, "service_unavailable", err.Err)
+}
+
+func TestBidBatcherGivesUpWhenTheRequestEnds(t *testing.T) {
+	batcher := bid_usecase.NewBidBatcher(&recordingBidRepositoryStub{}, 1, time.Hour)
+
+	// Not started, so the queue fills after one bid.
+	assert.Nil(t, batcher.Add(context.Background(), newBid(1)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := batcher.Add(ctx, newBid(2))
+	assert.Equal(t, "service_unavailable", err.Err)
+}
+
+func TestConcurrentBidUseCasesKeepTheirOwnBatches(t *testing.T) {
+	t.Setenv("BATCH_INSERT_INTERVAL", "5ms")
+	t.Setenv("MAX_BATCH_SIZE", "4")
+
+	const useCases = 4
+	const bidsPerUseCase = 50
+
+	authenticated := auth.WithIdentity(context.Background(), &auth.Identity{
+		UserId: userId, Roles: []auth.Role{auth.RoleBidder}})
+
+	repositories := make([]*recordingBidRepositoryStub, useCases)
+	var wg sync.WaitGroup
+	for i := range repositories {
+		repositories[i] = &recordingBidRepositoryStub{}
+		bidUseCase := bid_usecase.NewBidUseCase(context.Background(), repositories[i], clock.System)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var placed sync.WaitGroup
+			for j := 1; j <= bidsPerUseCase; j++ {
+				placed.Add(1)
+				go func(amount float64) {
+					defer placed.Done()
+					assert.Nil(t, bidUseCase.CreateBid(authenticated, bid_usecase.BidInputDTO{
+						AuctionId: auctionId, Amount: amount}))
+				}(float64(j))
+			}
+			placed.Wait()
+
+			assert.NoError(t, bidUseCase.Shutdown(context.Background()))
+			assert.Equal(t, int64(bidsPerUseCase), bidUseCase.Batcher.Stats().FlushedBids)
+		}()
+	}
+	wg.Wait()
+
+	for _, repository := range repositories {
+		total := 0
+		for _, batch := range repository.batches() {
+			assert.NotEmpty(t, batch)
+			total += len(batch)
+		}
+		assert.Equal(t, bidsPerUseCase, total)
+	}
+}
+
+type rejectingBidRepositoryStub struct {
+	bid_entity.BidEntityRepository
+}
+
+func (rejectingBidRepositoryStub) CreateBid(
+	ctx context.Context, bidEntities []bid_entity.Bid) []bid_entity.BidFailure {
+	return []bid_entity.BidFailure{{
+		Bid: bidEntities[0],
+		Err: internal_error.NewBadRequestError("Bid must be higher than the current best"),
+	}}
+}
+
+func TestBidBatcherCountsFailedBids(t *testing.T) {
+	batcher := bid_usecase.NewBidBatcher(rejectingBidRepositoryStub{}, 3, time.Hour)
+	batcher.Start(context.Background())
+
+	for i := 1; i <= 3; i++ {
+		assert.Nil(t, batcher.Add(context.Background(), newBid(float64(i))))
+	}
+	assert.NoError(t, batcher.Shutdown(context.Background()))
+
+	stats := batcher.Stats()
+	assert.Equal(t, int64(2), stats.FlushedBids)
+	assert.Equal(t, int64(1), stats.FailedBids)
+}
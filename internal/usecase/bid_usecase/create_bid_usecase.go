@@ -2,7 +2,8 @@ package bid_usecase
 
 import (
 	"context"
-	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/clock"
 	"fullcycle-auction_go/internal/entity/bid_entity"
 	"fullcycle-auction_go/internal/internal_error"
 	"os"
@@ -26,32 +27,27 @@ type BidOutputDTO struct {
 
 type BidUseCase struct {
 	BidRepository bid_entity.BidEntityRepository
-
-	timer               *time.Timer
-	maxBatchSize        int
-	batchInsertInterval time.Duration
-	bidChannel          chan bid_entity.Bid
+	Batcher       *BidBatcher
+	Clock         clock.Clock
 }
 
-func NewBidUseCase(bidRepository bid_entity.BidEntityRepository) BidUseCaseInterface {
-	maxSizeInterval := getMaxBatchSizeInterval()
-	maxBatchSize := getMaxBatchSize()
-
-	bidUseCase := &BidUseCase{
-		BidRepository:       bidRepository,
-		maxBatchSize:        maxBatchSize,
-		batchInsertInterval: maxSizeInterval,
-		timer:               time.NewTimer(maxSizeInterval),
-		bidChannel:          make(chan bid_entity.Bid, maxBatchSize),
+// NewBidUseCase starts a BidBatcher configured by MAX_BATCH_SIZE and
+// BATCH_INSERT_INTERVAL that writes with ctx; see BidBatcher.Start. Bids are
+// timestamped with clock.
+func NewBidUseCase(
+	ctx context.Context,
+	bidRepository bid_entity.BidEntityRepository,
+	clock clock.Clock) *BidUseCase {
+	batcher := NewBidBatcher(bidRepository, getMaxBatchSize(), getMaxBatchSizeInterval())
+	batcher.Start(ctx)
+
+	return &BidUseCase{
+		BidRepository: bidRepository,
+		Batcher:       batcher,
+		Clock:         clock,
 	}
-
-	bidUseCase.triggerCreateRoutine(context.Background())
-
-	return bidUseCase
 }
 
-var bidBatch []bid_entity.Bid
-
 type BidUseCaseInterface interface {
 	CreateBid(
 		ctx context.Context,
@@ -62,57 +58,39 @@ type BidUseCaseInterface interface {
 
 	FindBidByAuctionId(
 		ctx context.Context, auctionId string) ([]BidOutputDTO, *internal_error.InternalError)
+
+	FindBidsByAuctionIds(
+		ctx context.Context, auctionIds []string) ([]BidOutputDTO, *internal_error.InternalError)
 }
 
-func (bu *BidUseCase) triggerCreateRoutine(ctx context.Context) {
-	go func() {
-		defer close(bu.bidChannel)
-
-		for {
-			select {
-			case bidEntity, ok := <-bu.bidChannel:
-				if !ok {
-					if len(bidBatch) > 0 {
-						if err := bu.BidRepository.CreateBid(ctx, bidBatch); err != nil {
-							logger.Error("error trying to process bid batch list", err)
-						}
-					}
-					return
-				}
-
-				bidBatch = append(bidBatch, bidEntity)
-
-				if len(bidBatch) >= bu.maxBatchSize {
-					if err := bu.BidRepository.CreateBid(ctx, bidBatch); err != nil {
-						logger.Error("error trying to process bid batch list", err)
-					}
-
-					bidBatch = nil
-					bu.timer.Reset(bu.batchInsertInterval)
-				}
-			case <-bu.timer.C:
-				if err := bu.BidRepository.CreateBid(ctx, bidBatch); err != nil {
-					logger.Error("error trying to process bid batch list", err)
-				}
-				bidBatch = nil
-				bu.timer.Reset(bu.batchInsertInterval)
-			}
-		}
-	}()
+// Shutdown stops accepting bids and writes the pending batch; see
+// BidBatcher.Shutdown.
+func (bu *BidUseCase) Shutdown(ctx context.Context) error {
+	return bu.Batcher.Shutdown(ctx)
 }
 
 func (bu *BidUseCase) CreateBid(
 	ctx context.Context,
 	bidInputDTO BidInputDTO) *internal_error.InternalError {
 
-	bidEntity, err := bid_entity.CreateBid(bidInputDTO.UserId, bidInputDTO.AuctionId, bidInputDTO.Amount)
-	if err != nil {
+	if err := auth.Authorize(ctx, auth.PlaceBid, ""); err != nil {
 		return err
 	}
 
-	bu.bidChannel <- *bidEntity
+	caller, _ := auth.IdentityFromContext(ctx)
+	if bidInputDTO.UserId == "" {
+		bidInputDTO.UserId = caller.UserId
+	} else if bidInputDTO.UserId != caller.UserId {
+		return internal_error.NewForbiddenError("user_id does not match the authenticated user")
+	}
+
+	bidEntity, err := bid_entity.CreateBid(
+		bidInputDTO.UserId, bidInputDTO.AuctionId, bidInputDTO.Amount, bu.Clock.Now())
+	if err != nil {
+		return err
+	}
 
-	return nil
+	return bu.Batcher.Add(ctx, *bidEntity)
 }
 
 func getMaxBatchSizeInterval() time.Duration {
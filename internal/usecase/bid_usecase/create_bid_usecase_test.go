@@ -0,0 +1,100 @@
+package bid_usecase_test
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/clock"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	userId    = "6b0d6f1c-0b5e-4d6b-8f8e-2b9d1a3c4e5f"
+	auctionId = "2c8a3f1e-5d47-4b8b-9a0e-7f3b6c1d2e4f"
+)
+
+type bidRepositoryStub struct {
+	bid_entity.BidEntityRepository
+}
+
+func (bidRepositoryStub) CreateBid(ctx context.Context, bidEntities []bid_entity.Bid) []bid_entity.BidFailure {
+	return nil
+}
+
+func TestCreateBidUsesCallerIdentity(t *testing.T) {
+	bidUseCase := bid_usecase.NewBidUseCase(context.Background(), bidRepositoryStub{}, clock.System)
+	authenticated := auth.WithIdentity(context.Background(), &auth.Identity{
+		UserId: userId, Roles: []auth.Role{auth.RoleBidder}})
+
+	err := bidUseCase.CreateBid(context.Background(), bid_usecase.BidInputDTO{
+		UserId: userId, AuctionId: auctionId, Amount: 10})
+	assert.Equal(t, "unauthorized", err.Err)
+
+	err = bidUseCase.CreateBid(authenticated, bid_usecase.BidInputDTO{
+		UserId: "9e1f2a3b-4c5d-4e6f-8a7b-0c1d2e3f4a5b", AuctionId: auctionId, Amount: 10})
+	assert.Equal(t, "forbidden", err.Err)
+
+	seller := auth.WithIdentity(context.Background(), &auth.Identity{
+		UserId: userId, Roles: []auth.Role{auth.RoleSeller}})
+	err = bidUseCase.CreateBid(seller, bid_usecase.BidInputDTO{AuctionId: auctionId, Amount: 10})
+	assert.Equal(t, "forbidden", err.Err)
+
+	assert.Nil(t, bidUseCase.CreateBid(authenticated, bid_usecase.BidInputDTO{
+		UserId: userId, AuctionId: auctionId, Amount: 10}))
+	assert.Nil(t, bidUseCase.CreateBid(authenticated, bid_usecase.BidInputDTO{
+		AuctionId: auctionId, Amount: 10}))
+	assert.NoError(t, bidUseCase.Shutdown(context.Background()))
+}
+
+type recordingBidRepositoryStub struct {
+	bid_entity.BidEntityRepository
+
+	mutex     sync.Mutex
+	bids      []bid_entity.Bid
+	batchList [][]bid_entity.Bid
+}
+
+func (r *recordingBidRepositoryStub) CreateBid(
+	ctx context.Context, bidEntities []bid_entity.Bid) []bid_entity.BidFailure {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.bids = append(r.bids, bidEntities...)
+	r.batchList = append(r.batchList, append([]bid_entity.Bid(nil), bidEntities...))
+	return nil
+}
+
+func TestShutdownFlushesPendingBids(t *testing.T) {
+	t.Setenv("BATCH_INSERT_INTERVAL", "1h")
+	t.Setenv("MAX_BATCH_SIZE", "10")
+
+	repository := &recordingBidRepositoryStub{}
+	fakeClock := clock.NewFakeClock(time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC))
+	bidUseCase := bid_usecase.NewBidUseCase(context.Background(), repository, fakeClock)
+	authenticated := auth.WithIdentity(context.Background(), &auth.Identity{
+		UserId: userId, Roles: []auth.Role{auth.RoleBidder}})
+
+	for i := 1; i <= 3; i++ {
+		assert.Nil(t, bidUseCase.CreateBid(authenticated, bid_usecase.BidInputDTO{
+			AuctionId: auctionId, Amount: float64(i * 10)}))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, bidUseCase.Shutdown(ctx))
+
+	repository.mutex.Lock()
+	assert.Len(t, repository.bids, 3)
+	for _, bid := range repository.bids {
+		assert.Equal(t, fakeClock.Now(), bid.Timestamp)
+	}
+	repository.mutex.Unlock()
+
+	err := bidUseCase.CreateBid(authenticated, bid_usecase.BidInputDTO{AuctionId: auctionId, Amount: 40})
+	assert.Equal(t, "service_unavailable", err.Err)
+	assert.NoError(t, bidUseCase.Shutdown(ctx))
+}
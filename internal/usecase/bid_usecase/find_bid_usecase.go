@@ -26,6 +26,27 @@ func (bu *BidUseCase) FindBidByAuctionId(
 	return bidOutputList, nil
 }
 
+func (bu *BidUseCase) FindBidsByAuctionIds(
+	ctx context.Context, auctionIds []string) ([]BidOutputDTO, *internal_error.InternalError) {
+	bidList, err := bu.BidRepository.FindBidsByAuctionIds(ctx, auctionIds)
+	if err != nil {
+		return nil, err
+	}
+
+	bidOutputList := make([]BidOutputDTO, 0, len(bidList))
+	for _, bid := range bidList {
+		bidOutputList = append(bidOutputList, BidOutputDTO{
+			Id:        bid.Id,
+			UserId:    bid.UserId,
+			AuctionId: bid.AuctionId,
+			Amount:    bid.Amount,
+			Timestamp: bid.Timestamp,
+		})
+	}
+
+	return bidOutputList, nil
+}
+
 func (bu *BidUseCase) FindWinningBidByAuctionId(
 	ctx context.Context, auctionId string) (*BidOutputDTO, *internal_error.InternalError) {
 	bidEntity, err := bu.BidRepository.FindWinningBidByAuctionId(ctx, auctionId)
@@ -33,6 +54,10 @@ func (bu *BidUseCase) FindWinningBidByAuctionId(
 		return nil, err
 	}
 
+	if bidEntity == nil {
+		return nil, internal_error.NewNotFoundError("No winning bid found for this auction")
+	}
+
 	bidOutput := &BidOutputDTO{
 		Id:        bidEntity.Id,
 		UserId:    bidEntity.UserId,
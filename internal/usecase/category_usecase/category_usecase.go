@@ -0,0 +1,320 @@
+package category_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/clock"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+// CategoryInputDTO describes a category. An empty Slug is derived from the
+// name; ParentId may be a category id or slug, or empty for a root
+// category.
+type CategoryInputDTO struct {
+	Name     string
+	Slug     string
+	ParentId string
+}
+
+type CategoryOutputDTO struct {
+	Id        string
+	Name      string
+	Slug      string
+	ParentId  string
+	Timestamp time.Time
+	Children  []CategoryOutputDTO
+}
+
+type CategoryUseCaseInterface interface {
+	CreateCategory(
+		ctx context.Context,
+		categoryInput CategoryInputDTO) (*CategoryOutputDTO, *internal_error.InternalError)
+
+	UpdateCategory(
+		ctx context.Context,
+		category string,
+		categoryInput CategoryInputDTO) (*CategoryOutputDTO, *internal_error.InternalError)
+
+	DeleteCategory(
+		ctx context.Context, category string) *internal_error.InternalError
+
+	FindCategory(
+		ctx context.Context, category string) (*CategoryOutputDTO, *internal_error.InternalError)
+
+	FindCategoryTree(
+		ctx context.Context) ([]CategoryOutputDTO, *internal_error.InternalError)
+}
+
+type CategoryUseCase struct {
+	categoryRepositoryInterface category_entity.CategoryRepositoryInterface
+	auctionRepositoryInterface  auction_entity.AuctionRepositoryInterface
+	clock                       clock.Clock
+}
+
+func NewCategoryUseCase(
+	categoryRepositoryInterface category_entity.CategoryRepositoryInterface,
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface,
+	clock clock.Clock) CategoryUseCaseInterface {
+
+	return &CategoryUseCase{
+		categoryRepositoryInterface: categoryRepositoryInterface,
+		auctionRepositoryInterface:  auctionRepositoryInterface,
+		clock:                       clock,
+	}
+}
+
+func (cu *CategoryUseCase) CreateCategory(
+	ctx context.Context,
+	categoryInput CategoryInputDTO) (*CategoryOutputDTO, *internal_error.InternalError) {
+
+	if err := auth.Authorize(ctx, auth.ManageCategories, ""); err != nil {
+		return nil, err
+	}
+
+	parentId, err := cu.findParentId(ctx, categoryInput.ParentId)
+	if err != nil {
+		return nil, err
+	}
+
+	category, err := category_entity.CreateCategory(
+		categoryInput.Name, categoryInput.Slug, parentId, cu.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cu.checkSlugIsFree(ctx, category); err != nil {
+		return nil, err
+	}
+
+	if err := cu.categoryRepositoryInterface.CreateCategory(ctx, category); err != nil {
+		return nil, err
+	}
+
+	return &CategoryOutputDTO{
+		Id:        category.Id,
+		Name:      category.Name,
+		Slug:      category.Slug,
+		ParentId:  category.ParentId,
+		Timestamp: category.Timestamp,
+		Children:  []CategoryOutputDTO{},
+	}, nil
+}
+
+// UpdateCategory renames or moves a category. It cannot be moved below
+// itself or one of its subcategories. A new name is copied to the auctions
+// of the category, which search matches by it.
+func (cu *CategoryUseCase) UpdateCategory(
+	ctx context.Context,
+	categoryValue string,
+	categoryInput CategoryInputDTO) (*CategoryOutputDTO, *internal_error.InternalError) {
+
+	if err := auth.Authorize(ctx, auth.ManageCategories, ""); err != nil {
+		return nil, err
+	}
+
+	category, err := category_entity.FindCategory(ctx, cu.categoryRepositoryInterface, categoryValue)
+	if err != nil {
+		return nil, err
+	}
+
+	parentId, err := cu.findParentId(ctx, categoryInput.ParentId)
+	if err != nil {
+		return nil, err
+	}
+
+	previousName := category.Name
+	if err := category.Change(categoryInput.Name, categoryInput.Slug, parentId); err != nil {
+		return nil, err
+	}
+
+	categories, err := cu.categoryRepositoryInterface.FindCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentId != "" {
+		for _, id := range category_entity.SubtreeIds(categories, category.Id) {
+			if id == parentId {
+				return nil, internal_error.NewBadRequestError(
+					"A category cannot be moved below one of its subcategories")
+			}
+		}
+	}
+
+	if err := cu.checkSlugIsFree(ctx, category); err != nil {
+		return nil, err
+	}
+
+	if err := cu.categoryRepositoryInterface.UpdateCategory(ctx, category); err != nil {
+		return nil, err
+	}
+
+	if category.Name != previousName {
+		if err := cu.auctionRepositoryInterface.UpdateCategoryName(ctx, category.Id, category.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range categories {
+		if categories[i].Id == category.Id {
+			categories[i] = *category
+		}
+	}
+
+	return newCategoryTree(categories, category.Id), nil
+}
+
+// DeleteCategory only deletes categories without subcategories and
+// auctions.
+func (cu *CategoryUseCase) DeleteCategory(
+	ctx context.Context, categoryValue string) *internal_error.InternalError {
+
+	if err := auth.Authorize(ctx, auth.ManageCategories, ""); err != nil {
+		return err
+	}
+
+	category, err := category_entity.FindCategory(ctx, cu.categoryRepositoryInterface, categoryValue)
+	if err != nil {
+		return err
+	}
+
+	categories, err := cu.categoryRepositoryInterface.FindCategories(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(category_entity.SubtreeIds(categories, category.Id)) > 1 {
+		return internal_error.NewConflictError("Category has subcategories")
+	}
+
+	auctions, err := cu.auctionRepositoryInterface.FindAuctions(ctx, 0, []string{category.Id}, "")
+	if err != nil {
+		return err
+	}
+
+	if len(auctions) > 0 {
+		return internal_error.NewConflictError("Category has auctions")
+	}
+
+	return cu.categoryRepositoryInterface.DeleteCategory(ctx, category.Id)
+}
+
+// FindCategory returns the category with its subcategories.
+func (cu *CategoryUseCase) FindCategory(
+	ctx context.Context, categoryValue string) (*CategoryOutputDTO, *internal_error.InternalError) {
+
+	category, err := category_entity.FindCategory(ctx, cu.categoryRepositoryInterface, categoryValue)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := cu.categoryRepositoryInterface.FindCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCategoryTree(categories, category.Id), nil
+}
+
+// FindCategoryTree returns the root categories with their subcategories.
+func (cu *CategoryUseCase) FindCategoryTree(
+	ctx context.Context) ([]CategoryOutputDTO, *internal_error.InternalError) {
+
+	categories, err := cu.categoryRepositoryInterface.FindCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	roots := []CategoryOutputDTO{}
+	for _, category := range categories {
+		if category.ParentId == "" {
+			roots = append(roots, *newCategoryTree(categories, category.Id))
+		}
+	}
+
+	return roots, nil
+}
+
+// findParentId resolves a parent category id or slug to its id.
+func (cu *CategoryUseCase) findParentId(
+	ctx context.Context, parent string) (string, *internal_error.InternalError) {
+
+	if parent == "" {
+		return "", nil
+	}
+
+	category, err := category_entity.FindCategory(ctx, cu.categoryRepositoryInterface, parent)
+	if err != nil {
+		if err.Err == "not_found" {
+			return "", internal_error.NewBadRequestError("Parent category not found")
+		}
+		return "", err
+	}
+
+	return category.Id, nil
+}
+
+func (cu *CategoryUseCase) checkSlugIsFree(
+	ctx context.Context, category *category_entity.Category) *internal_error.InternalError {
+
+	existing, err := cu.categoryRepositoryInterface.FindCategoryBySlug(ctx, category.Slug)
+	if err != nil {
+		if err.Err == "not_found" {
+			return nil
+		}
+		return err
+	}
+
+	if existing.Id != category.Id {
+		return internal_error.NewConflictError("Slug " + category.Slug + " is already in use")
+	}
+
+	return nil
+}
+
+// newCategoryTree builds the category with the given id and its
+// subcategories, in creation order, from the flat list of categories.
+func newCategoryTree(categories []category_entity.Category, id string) *CategoryOutputDTO {
+	children := make(map[string][]category_entity.Category)
+	var root *CategoryOutputDTO
+	for _, category := range categories {
+		if category.Id == id {
+			root = &CategoryOutputDTO{
+				Id:        category.Id,
+				Name:      category.Name,
+				Slug:      category.Slug,
+				ParentId:  category.ParentId,
+				Timestamp: category.Timestamp,
+			}
+		}
+		if category.ParentId != "" {
+			children[category.ParentId] = append(children[category.ParentId], category)
+		}
+	}
+
+	if root == nil {
+		return nil
+	}
+
+	var build func(node *CategoryOutputDTO)
+	build = func(node *CategoryOutputDTO) {
+		node.Children = make([]CategoryOutputDTO, 0, len(children[node.Id]))
+		for _, child := range children[node.Id] {
+			childNode := CategoryOutputDTO{
+				Id:        child.Id,
+				Name:      child.Name,
+				Slug:      child.Slug,
+				ParentId:  child.ParentId,
+				Timestamp: child.Timestamp,
+			}
+			build(&childNode)
+			node.Children = append(node.Children, childNode)
+		}
+	}
+	build(root)
+
+	return root
+}
@@ -0,0 +1,170 @@
+package category_usecase_test
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/clock"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"fullcycle-auction_go/internal/infra/database/category"
+	"fullcycle-auction_go/internal/infra/event"
+	"fullcycle-auction_go/internal/usecase/category_usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const adminId = "6b0d6f1c-0b5e-4d6b-8f8e-2b9d1a3c4e5f"
+
+func as(roles ...auth.Role) context.Context {
+	return auth.WithIdentity(context.Background(), &auth.Identity{UserId: adminId, Roles: roles})
+}
+
+func newCategoryUseCase() (category_usecase.CategoryUseCaseInterface, *auction.MemoryAuctionRepository) {
+	fakeClock := clock.NewFakeClock(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	auctionRepository := auction.NewMemoryAuctionRepository(event.NewEventDispatcher(), fakeClock)
+
+	return category_usecase.NewCategoryUseCase(
+		category.NewMemoryCategoryRepository(), auctionRepository, fakeClock), auctionRepository
+}
+
+func TestCategoryTree(t *testing.T) {
+	useCase, _ := newCategoryUseCase()
+	ctx := as(auth.RoleAdmin)
+
+	music, err := useCase.CreateCategory(ctx, category_usecase.CategoryInputDTO{Name: "Música"})
+	assert.Nil(t, err)
+	assert.Equal(t, "musica", music.Slug)
+
+	guitars, err := useCase.CreateCategory(ctx, category_usecase.CategoryInputDTO{
+		Name: "Guitars", ParentId: "musica"})
+	assert.Nil(t, err)
+	assert.Equal(t, music.Id, guitars.ParentId)
+
+	_, err = useCase.CreateCategory(ctx, category_usecase.CategoryInputDTO{
+		Name: "Bass guitars", Slug: "bass", ParentId: guitars.Id})
+	assert.Nil(t, err)
+
+	_, err = useCase.CreateCategory(ctx, category_usecase.CategoryInputDTO{Name: "Books"})
+	assert.Nil(t, err)
+
+	tree, err := useCase.FindCategoryTree(ctx)
+	assert.Nil(t, err)
+	if assert.Len(t, tree, 2) {
+		assert.Equal(t, "musica", tree[0].Slug)
+		assert.Equal(t, "books", tree[1].Slug)
+		assert.Empty(t, tree[1].Children)
+		if assert.Len(t, tree[0].Children, 1) && assert.Len(t, tree[0].Children[0].Children, 1) {
+			assert.Equal(t, "bass", tree[0].Children[0].Children[0].Slug)
+		}
+	}
+
+	found, err := useCase.FindCategory(ctx, "guitars")
+	assert.Nil(t, err)
+	assert.Equal(t, guitars.Id, found.Id)
+	assert.Len(t, found.Children, 1)
+
+	_, err = useCase.FindCategory(ctx, "drums")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "not_found", err.Err)
+	}
+}
+
+func TestCreateCategoryValidation(t *testing.T) {
+	useCase, _ := newCategoryUseCase()
+	ctx := as(auth.RoleAdmin)
+
+	_, err := useCase.CreateCategory(ctx, category_usecase.CategoryInputDTO{Name: "Books"})
+	assert.Nil(t, err)
+
+	_, err = useCase.CreateCategory(ctx, category_usecase.CategoryInputDTO{Name: "books"})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "conflict", err.Err)
+	}
+
+	_, err = useCase.CreateCategory(ctx, category_usecase.CategoryInputDTO{Name: "Comics", ParentId: "magazines"})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "bad_request", err.Err)
+	}
+
+	_, err = useCase.CreateCategory(ctx, category_usecase.CategoryInputDTO{Name: "Comics", Slug: "Comics!"})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "bad_request", err.Err)
+	}
+
+	_, err = useCase.CreateCategory(as(auth.RoleSeller), category_usecase.CategoryInputDTO{Name: "Comics"})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "forbidden", err.Err)
+	}
+}
+
+func TestUpdateCategoryCannotCreateCycles(t *testing.T) {
+	useCase, _ := newCategoryUseCase()
+	ctx := as(auth.RoleAdmin)
+
+	music, err := useCase.CreateCategory(ctx, category_usecase.CategoryInputDTO{Name: "Music"})
+	assert.Nil(t, err)
+	guitars, err := useCase.CreateCategory(ctx, category_usecase.CategoryInputDTO{Name: "Guitars", ParentId: music.Id})
+	assert.Nil(t, err)
+	bass, err := useCase.CreateCategory(ctx, category_usecase.CategoryInputDTO{Name: "Bass", ParentId: guitars.Id})
+	assert.Nil(t, err)
+
+	for _, parentId := range []string{music.Id, guitars.Id, bass.Id} {
+		_, err = useCase.UpdateCategory(ctx, music.Id, category_usecase.CategoryInputDTO{
+			Name: "Music", ParentId: parentId})
+		if assert.NotNil(t, err, parentId) {
+			assert.Equal(t, "bad_request", err.Err)
+		}
+	}
+
+	updated, err := useCase.UpdateCategory(ctx, "bass", category_usecase.CategoryInputDTO{
+		Name: "Bass guitars", ParentId: music.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, "bass-guitars", updated.Slug)
+	assert.Equal(t, music.Id, updated.ParentId)
+
+	found, err := useCase.FindCategory(ctx, music.Id)
+	assert.Nil(t, err)
+	assert.Len(t, found.Children, 2)
+
+	_, err = useCase.UpdateCategory(ctx, guitars.Id, category_usecase.CategoryInputDTO{
+		Name: "Guitars", Slug: "bass-guitars"})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "conflict", err.Err)
+	}
+}
+
+func TestDeleteCategory(t *testing.T) {
+	useCase, auctionRepository := newCategoryUseCase()
+	ctx := as(auth.RoleAdmin)
+
+	music, err := useCase.CreateCategory(ctx, category_usecase.CategoryInputDTO{Name: "Music"})
+	assert.Nil(t, err)
+	guitars, err := useCase.CreateCategory(ctx, category_usecase.CategoryInputDTO{Name: "Guitars", ParentId: music.Id})
+	assert.Nil(t, err)
+
+	err = useCase.DeleteCategory(ctx, music.Id)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "conflict", err.Err)
+	}
+
+	auctionEntity, err := auction_entity.CreateAuction(
+		"Guitar", guitars.Id, "Electric guitar with case", auction_entity.Used, time.Now())
+	assert.Nil(t, err)
+	assert.Nil(t, auctionRepository.CreateAuction(context.Background(), auctionEntity))
+
+	err = useCase.DeleteCategory(ctx, guitars.Id)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "conflict", err.Err)
+	}
+
+	_, err = useCase.CreateCategory(ctx, category_usecase.CategoryInputDTO{Name: "Drums", ParentId: music.Id})
+	assert.Nil(t, err)
+	assert.Nil(t, useCase.DeleteCategory(ctx, "drums"))
+
+	_, err = useCase.FindCategory(ctx, "drums")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "not_found", err.Err)
+	}
+}
@@ -25,6 +25,10 @@ type UserUseCaseInterface interface {
 	FindUserById(
 		ctx context.Context,
 		id string) (*UserOutputDTO, *internal_error.InternalError)
+
+	FindUsersByIds(
+		ctx context.Context,
+		ids []string) ([]UserOutputDTO, *internal_error.InternalError)
 }
 
 func (u *UserUseCase) FindUserById(
@@ -39,3 +43,21 @@ func (u *UserUseCase) FindUserById(
 		Name: userEntity.Name,
 	}, nil
 }
+
+func (u *UserUseCase) FindUsersByIds(
+	ctx context.Context, ids []string) ([]UserOutputDTO, *internal_error.InternalError) {
+	userEntities, err := u.UserRepository.FindUsersByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	userOutputList := make([]UserOutputDTO, 0, len(userEntities))
+	for _, userEntity := range userEntities {
+		userOutputList = append(userOutputList, UserOutputDTO{
+			Id:   userEntity.Id,
+			Name: userEntity.Name,
+		})
+	}
+
+	return userOutputList, nil
+}
@@ -0,0 +1,88 @@
+package webhook_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/entity/webhook_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+type WebhookInputDTO struct {
+	Url    string
+	Events []event_entity.EventType
+	Secret string
+}
+
+type WebhookOutputDTO struct {
+	Id        string                   `json:"id"`
+	UserId    string                   `json:"user_id"`
+	Url       string                   `json:"url"`
+	Events    []event_entity.EventType `json:"events"`
+	Secret    string                   `json:"secret,omitempty"`
+	Timestamp time.Time                `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+}
+
+type DeliveryOutputDTO struct {
+	Id         string                 `json:"id"`
+	WebhookId  string                 `json:"webhook_id"`
+	EventId    uint64                 `json:"event_id"`
+	EventType  event_entity.EventType `json:"event_type"`
+	Attempt    int                    `json:"attempt"`
+	StatusCode int                    `json:"status_code"`
+	Success    bool                   `json:"success"`
+	Error      string                 `json:"error,omitempty"`
+	Timestamp  time.Time              `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+}
+
+type WebhookUseCase struct {
+	WebhookRepository webhook_entity.WebhookRepositoryInterface
+}
+
+type WebhookUseCaseInterface interface {
+	CreateWebhook(
+		ctx context.Context,
+		webhookInput WebhookInputDTO) (*WebhookOutputDTO, *internal_error.InternalError)
+
+	FindDeliveriesByWebhookId(
+		ctx context.Context, webhookId string) ([]DeliveryOutputDTO, *internal_error.InternalError)
+}
+
+func NewWebhookUseCase(webhookRepository webhook_entity.WebhookRepositoryInterface) WebhookUseCaseInterface {
+	return &WebhookUseCase{
+		WebhookRepository: webhookRepository,
+	}
+}
+
+func (wu *WebhookUseCase) CreateWebhook(
+	ctx context.Context,
+	webhookInput WebhookInputDTO) (*WebhookOutputDTO, *internal_error.InternalError) {
+
+	if err := auth.Authorize(ctx, auth.RegisterWebhook, ""); err != nil {
+		return nil, err
+	}
+
+	caller, _ := auth.IdentityFromContext(ctx)
+	webhook, err := webhook_entity.CreateWebhook(
+		caller.UserId,
+		webhookInput.Url,
+		webhookInput.Events,
+		webhookInput.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := wu.WebhookRepository.CreateWebhook(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return &WebhookOutputDTO{
+		Id:        webhook.Id,
+		UserId:    webhook.UserId,
+		Url:       webhook.Url,
+		Events:    webhook.Events,
+		Secret:    webhook.Secret,
+		Timestamp: webhook.Timestamp,
+	}, nil
+}
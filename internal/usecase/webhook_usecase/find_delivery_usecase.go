@@ -0,0 +1,46 @@
+package webhook_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+func (wu *WebhookUseCase) FindDeliveriesByWebhookId(
+	ctx context.Context, webhookId string) ([]DeliveryOutputDTO, *internal_error.InternalError) {
+
+	if _, ok := auth.IdentityFromContext(ctx); !ok {
+		return nil, internal_error.NewUnauthorizedError("Authentication is required")
+	}
+
+	webhook, err := wu.WebhookRepository.FindWebhookById(ctx, webhookId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := auth.Authorize(ctx, auth.ManageWebhook, webhook.UserId); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := wu.WebhookRepository.FindDeliveriesByWebhookId(ctx, webhookId)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveryOutputList := make([]DeliveryOutputDTO, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		deliveryOutputList = append(deliveryOutputList, DeliveryOutputDTO{
+			Id:         delivery.Id,
+			WebhookId:  delivery.WebhookId,
+			EventId:    delivery.EventId,
+			EventType:  delivery.EventType,
+			Attempt:    delivery.Attempt,
+			StatusCode: delivery.StatusCode,
+			Success:    delivery.Success,
+			Error:      delivery.Error,
+			Timestamp:  delivery.Timestamp,
+		})
+	}
+
+	return deliveryOutputList, nil
+}
@@ -0,0 +1,75 @@
+package webhook_usecase_test
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/auth"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/infra/database/webhook"
+	"fullcycle-auction_go/internal/usecase/webhook_usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	userId      = "6b0d6f1c-0b5e-4d6b-8f8e-2b9d1a3c4e5f"
+	otherUserId = "9e1f2a3b-4c5d-4e6f-8a7b-0c1d2e3f4a5b"
+)
+
+func as(userId string, roles ...auth.Role) context.Context {
+	return auth.WithIdentity(context.Background(), &auth.Identity{UserId: userId, Roles: roles})
+}
+
+func withApiKey(userId string, scopes ...auth.Scope) context.Context {
+	return auth.WithIdentity(context.Background(), &auth.Identity{
+		UserId: userId, Roles: []auth.Role{auth.RoleSeller}, Scopes: scopes, ApiKeyId: otherUserId})
+}
+
+func TestWebhooksBelongToTheirOwner(t *testing.T) {
+	webhookUseCase := webhook_usecase.NewWebhookUseCase(webhook.NewMemoryWebhookRepository())
+	input := webhook_usecase.WebhookInputDTO{
+		Url:    "https://example.com/hooks",
+		Events: []event_entity.EventType{event_entity.BidCreated},
+	}
+
+	_, err := webhookUseCase.CreateWebhook(context.Background(), input)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "unauthorized", err.Err)
+	}
+
+	_, err = webhookUseCase.CreateWebhook(as(userId, auth.RoleBidder), input)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "forbidden", err.Err)
+	}
+
+	_, err = webhookUseCase.CreateWebhook(withApiKey(userId, auth.ScopeReadOnly), input)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "forbidden", err.Err)
+	}
+
+	created, err := webhookUseCase.CreateWebhook(as(userId, auth.RoleSeller), input)
+	assert.Nil(t, err)
+	assert.Equal(t, userId, created.UserId)
+
+	deliveries, err := webhookUseCase.FindDeliveriesByWebhookId(as(userId, auth.RoleSeller), created.Id)
+	assert.Nil(t, err)
+	assert.Empty(t, deliveries)
+
+	_, err = webhookUseCase.FindDeliveriesByWebhookId(as(otherUserId, auth.RoleSeller), created.Id)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "forbidden", err.Err)
+	}
+
+	_, err = webhookUseCase.FindDeliveriesByWebhookId(withApiKey(userId, auth.ScopeReadOnly), created.Id)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "forbidden", err.Err)
+	}
+
+	_, err = webhookUseCase.FindDeliveriesByWebhookId(as(otherUserId, auth.RoleAdmin), created.Id)
+	assert.Nil(t, err)
+
+	_, err = webhookUseCase.FindDeliveriesByWebhookId(context.Background(), created.Id)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "unauthorized", err.Err)
+	}
+}
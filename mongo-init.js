@@ -9,10 +9,22 @@ db.createUser({
   }]
 });
 
+// Indexes are created by the application's migrations
+// (configuration/database/mongodb/migrations.go) on startup.
 db.createCollection('auctions');
-db.auctions.createIndex({ "end_time": 1 });
 
 db.createCollection('bids');
-db.bids.createIndex({ "auction_id": 1 });
 
-db.createCollection('users');
\ No newline at end of file
+db.createCollection('users');
+
+db.createCollection('webhooks');
+
+db.createCollection('webhook_deliveries');
+
+db.createCollection('api_keys');
+
+db.createCollection('categories');
+
+db.createCollection('rate_limits');
+
+db.createCollection('idempotency_keys');
\ No newline at end of file
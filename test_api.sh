@@ -9,6 +9,12 @@ test_endpoint() {
     local path=$2
     local name=$3
     local payload=$4
+    local token=$5
+    local auth_header=()
+
+    if [ -n "$token" ]; then
+        auth_header=(-H "Authorization: Bearer $token")
+    fi
     
     echo "=== $name ==="
     echo "URL: $BASE_URL$path"
@@ -16,9 +22,9 @@ test_endpoint() {
     
     if [ -n "$payload" ]; then
         echo "Payload: $payload"
-        response=$(curl -s -i -X $method "$BASE_URL$path" -H "Content-Type: application/json" -d "$payload")
+        response=$(curl -s -i -X $method "$BASE_URL$path" -H "Content-Type: application/json" "${auth_header[@]}" -d "$payload")
     else
-        response=$(curl -s -i -X $method "$BASE_URL$path")
+        response=$(curl -s -i -X $method "$BASE_URL$path" "${auth_header[@]}")
     fi
     
     echo "Response:"
@@ -28,20 +34,43 @@ test_endpoint() {
     echo ""
 }
 
+# Gera um JWT HS256 para o usuário e papéis informados usando o JWT_SECRET do .env
+base64url() {
+    openssl base64 -e -A | tr '+/' '-_' | tr -d '='
+}
+
+generate_jwt() {
+    local subject=$1
+    local roles=${2:-bidder}
+    local secret
+    secret=$(grep '^JWT_SECRET=' .env | cut -d'=' -f2-)
+    local header
+    header=$(printf '{"alg":"HS256","typ":"JWT"}' | base64url)
+    local payload
+    payload=$(printf '{"sub":"%s","roles":["%s"],"exp":%d}' "$subject" "$roles" $(( $(date +%s) + 3600 )) | base64url)
+    local signature
+    signature=$(printf '%s.%s' "$header" "$payload" | openssl dgst -sha256 -hmac "$secret" -binary | base64url)
+    echo "$header.$payload.$signature"
+}
+
 # 1. Testar endpoint de saúde
 test_endpoint "GET" "/health" "Testando /health"
 
 # 2. Testar listagem de leilões (deve retornar vazio)
 test_endpoint "GET" "/auction" "Listar leilões"
 
-# 3. Criar um novo leilão
+# 3. Criar a categoria e um novo leilão nela
+ADMIN_TOKEN=$(generate_jwt "$(uuidgen)" "admin")
+test_endpoint "POST" "/v1/categories" "Criar categoria" '{"name": "Eletrônicos"}' "$ADMIN_TOKEN"
+
 AUCTION_PAYLOAD='{
     "product_name": "iPhone 13 Pro",
-    "category": "Eletrônicos",
+    "category": "eletronicos",
     "description": "Novo na caixa, selado",
     "condition": "new"
 }'
-test_endpoint "POST" "/auction" "Criar leilão" "$AUCTION_PAYLOAD"
+SELLER_TOKEN=$(generate_jwt "$(uuidgen)" "seller")
+test_endpoint "POST" "/auction" "Criar leilão" "$AUCTION_PAYLOAD" "$SELLER_TOKEN"
 
 # Extrair o ID do leilão da resposta se criado com sucesso
 if [[ "$response" == *"201 Created"* ]]; then
@@ -70,7 +99,8 @@ BID_PAYLOAD='{
     "auction_id": "'$AUCTION_ID'",
     "amount": 3500.00
 }'
-test_endpoint "POST" "/bid" "Fazer lance" "$BID_PAYLOAD"
+TOKEN=$(generate_jwt "$USER_ID")
+test_endpoint "POST" "/bid" "Fazer lance" "$BID_PAYLOAD" "$TOKEN"
 
 # 7. Buscar lances por leilão
 test_endpoint "GET" "/bid/$AUCTION_ID" "Buscar lances por leilão"